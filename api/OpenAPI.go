@@ -0,0 +1,8 @@
+package api
+
+import _ "embed" //for the OpenAPI document
+
+// OpenAPISpec is the OpenAPI v3 document of the REST gateway, generated from the protos (make apigen-v3).
+//
+//go:embed v1alpha/openapi-authz-v1alpha.yaml
+var OpenAPISpec []byte
@@ -1,13 +1,89 @@
 // Package api is for communication purposes
 package api
 
+import "time"
+
 // ServerConfig contains all server-related configuration.
 type ServerConfig struct {
 	GrpcPort    string
 	HTTPPort    string
 	HTTPSPort   string
+	MetricsPort string //empty disables the metrics endpoint
 	TLSConfig   TLSConfig
 	StoreConfig StoreConfig
+	CORSConfig  CORSConfig
+	// IdentitySources are tried in order to find the requestor identity of a request, the first one present is used. Empty uses the bearer token headers.
+	IdentitySources []IdentitySource
+	// InsecureDevAuth takes the requestor identity from bearer tokens as is, without validating them. For local development only: the gRPC server refuses to start with it if TLS is configured.
+	InsecureDevAuth bool
+	// AllowedResourceTypes restricts the resource types accepted by permission checks. Empty allows all types.
+	AllowedResourceTypes []string
+	// DefaultOperation and DefaultResourceType are used by permission checks that leave the operation or resource type blank. Empty requires them on every check.
+	DefaultOperation    string
+	DefaultResourceType string
+	// AuditAccessDecisions records permission check decisions in the audit log. Denies are always recorded, allows at AllowedDecisionSampleRate, ex: 0.01 for about 1%.
+	AuditAccessDecisions      bool
+	AllowedDecisionSampleRate float64
+	// CheckCacheTTL is the duration permission check results are cached for. 0 disables the cache.
+	CheckCacheTTL time.Duration
+	// LicenseCacheTTL is the duration licenses (seat limits and counts) are cached for. Seat modifications invalidate the license. 0 disables the cache.
+	LicenseCacheTTL time.Duration
+	// MaxRecvMsgSize and MaxSendMsgSize limit the size of gRPC messages in bytes. 0 uses the gRPC defaults (4MB received, unlimited sent).
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	// SeatWriteBatchWindow coalesces seat assignments (and unassignments) of a license that arrive within the window, ex: 10ms, into one store write. 0 writes each request on its own.
+	SeatWriteBatchWindow time.Duration
+	// MaxSeatChanges limits the number of assignments plus unassignments per ModifySeats request. 0 is unlimited.
+	MaxSeatChanges int
+	// ServiceFilePath is a YAML file defining the display names and descriptions of services. Empty leaves services with only their ID.
+	ServiceFilePath string
+	// ManageLicensesOperation is the operation on the organization requestors must be allowed to assign and unassign its seats, ex: "manage_licenses". Empty only requires an authenticated requestor.
+	ManageLicensesOperation string
+	// ViewLicensesOperation is the operation on the organization requestors must be allowed to read its licenses and seats, ex: "view_licenses". Empty only requires an authenticated requestor, ex: when authorization is handled in front of this service.
+	ViewLicensesOperation string
+	// AdminLicensesOperation is the operation on the organization requestors must be allowed to create, delete, resize and reconcile its licenses and to unassign all seats of a subject, ex: "administer_licenses". Empty denies all requestors.
+	AdminLicensesOperation string
+	// AdminRelationshipsOperation is the operation on the endpoint:relationships resource requestors must be allowed to write, delete and read relationships directly, ex: "administer". Empty denies all requestors.
+	AdminRelationshipsOperation string
+	// AuditSubjectsOperation is the operation on a resource requestors must be allowed to list all subjects with access to it, ex: "audit". Empty denies all requestors.
+	AuditSubjectsOperation string
+	// VerifySeatMembership makes seat assignments fail if any subject to assign is not a member of the organization. Off by default, as trusted callers may assign seats before membership is known to the principal repository.
+	VerifySeatMembership bool
+	// SeatUtilizationWarningThreshold is the share of seats in use (ex: 0.9 for 90%) above which assigning seats logs a warning. 0 disables the warning.
+	SeatUtilizationWarningThreshold float64
+	// SeatUsageLicenses are the licenses, as <org ID>/<service ID>, whose seats in use, max seats and available seats are exported as gauges on the metrics endpoint. Empty exports none.
+	SeatUsageLicenses []string
+	// SeatUsageInterval is the interval the seat usage of SeatUsageLicenses is read at, ex: 1m. 0 uses one minute.
+	SeatUsageInterval time.Duration
+	// MaxConcurrentChecks limits the checks of one batch check that are sent to the store at the same time. 0 uses the store's default.
+	MaxConcurrentChecks int
+	// StrictChecks makes permission checks on resources that do not exist fail with NotFound instead of being denied. It costs an extra store read per check.
+	StrictChecks bool
+	// RateLimit limits the requests per requestor on the gRPC server.
+	RateLimit RateLimitConfig
+}
+
+// RateLimitConfig includes the token bucket settings of the per-requestor rate limit. A Rate of 0 disables rate limiting.
+// Anonymous requestors share one bucket, which should be configured stricter than the per-requestor ones.
+type RateLimitConfig struct {
+	Rate           float64 //requests per second
+	Burst          int
+	AnonymousRate  float64 //requests per second and client address, for requests without a valid identity
+	AnonymousBurst int
+}
+
+// IdentitySource names a request header (gRPC metadata key) carrying the requestor identity and the strategy to extract the principal ID from it.
+type IdentitySource struct {
+	Header   string
+	Strategy string //"bearer" or "x-rh-identity"
+}
+
+// CORSConfig includes the cross-origin settings of the REST gateway. Without AllowedOrigins, cross-origin requests are not allowed.
+type CORSConfig struct {
+	AllowedOrigins   []string //ex: https://console.example.com, "*" allows any origin
+	AllowedMethods   []string //default: GET, HEAD, POST, PUT, PATCH, DELETE
+	AllowedHeaders   []string //default: Accept, Authorization, Content-Type and the identity headers
+	AllowCredentials bool
 }
 
 // TLSConfig includes a possible TLS configuration.
@@ -24,4 +100,24 @@ type StoreConfig struct {
 	Endpoint  string
 	AuthToken string
 	UseTLS    bool
+	// CACertPath is the CA certificate to verify the store's TLS certificate with. Empty uses the system certificates.
+	CACertPath string
+	Keepalive  KeepaliveConfig
+	// AllowedRelationships are the kinds of relationships that may be written and deleted directly, as <resource type>#<relation>@<subject type>, ex: group#member@user. Empty allows none.
+	AllowedRelationships []string
+	// RequestTimeout bounds each call to the store, also when the client sent no deadline. 0 leaves calls bounded by the client's deadline only.
+	RequestTimeout time.Duration
+	// RetryJitter randomizes the backoff of retried store reads: "none", "full" or "equal". Empty uses the store's default.
+	RetryJitter string
+}
+
+// KeepaliveConfig includes the client-side gRPC keepalive settings for the connection to the store.
+// If the client pings more often than the server allows, the server answers with GOAWAY (too_many_pings) and drops the connection.
+// Zero Time and Timeout use the store's defaults, which match grpc-go's default server-side enforcement policy used by SpiceDB (see authzed.DefaultKeepalive).
+// Lower Time or enable PermitWithoutStream only if the SpiceDB server's enforcement is relaxed accordingly.
+// There is no client-side max connection age: SpiceDB enforces it server-side (--grpc-max-conn-age) and the client reconnects on GOAWAY.
+type KeepaliveConfig struct {
+	Time                time.Duration
+	Timeout             time.Duration
+	PermitWithoutStream bool
 }
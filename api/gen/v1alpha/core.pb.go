@@ -68,6 +68,52 @@ func (SeatFilterType) EnumDescriptor() ([]byte, []int) {
 	return file_v1alpha_core_proto_rawDescGZIP(), []int{0}
 }
 
+type SeatSortField int32
+
+const (
+	SeatSortField_id          SeatSortField = 0
+	SeatSortField_displayName SeatSortField = 1 // Ignoring case.
+)
+
+// Enum value maps for SeatSortField.
+var (
+	SeatSortField_name = map[int32]string{
+		0: "id",
+		1: "displayName",
+	}
+	SeatSortField_value = map[string]int32{
+		"id":          0,
+		"displayName": 1,
+	}
+)
+
+func (x SeatSortField) Enum() *SeatSortField {
+	p := new(SeatSortField)
+	*p = x
+	return p
+}
+
+func (x SeatSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1alpha_core_proto_enumTypes[1].Descriptor()
+}
+
+func (SeatSortField) Type() protoreflect.EnumType {
+	return &file_v1alpha_core_proto_enumTypes[1]
+}
+
+func (x SeatSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatSortField.Descriptor instead.
+func (SeatSortField) EnumDescriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{1}
+}
+
 type CheckPermissionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -144,8 +190,9 @@ type CheckPermissionResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Result      bool   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
-	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Result      bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Description string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Obligations []string `protobuf:"bytes,3,rep,name=obligations,proto3" json:"obligations,omitempty"` // Conditions the caller must fulfill when access is allowed, ex: log, empty if there are none.
 }
 
 func (x *CheckPermissionResponse) Reset() {
@@ -194,17 +241,23 @@ func (x *CheckPermissionResponse) GetDescription() string {
 	return ""
 }
 
-type GetLicenseRequest struct {
+func (x *CheckPermissionResponse) GetObligations() []string {
+	if x != nil {
+		return x.Obligations
+	}
+	return nil
+}
+
+type BatchCheckPermissionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrgId     string `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`         // The id of an license-able organization.
-	ServiceId string `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"` // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
+	Checks []*CheckPermissionRequest `protobuf:"bytes,1,rep,name=checks,proto3" json:"checks,omitempty"` // The checks to perform.
 }
 
-func (x *GetLicenseRequest) Reset() {
-	*x = GetLicenseRequest{}
+func (x *BatchCheckPermissionRequest) Reset() {
+	*x = BatchCheckPermissionRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1alpha_core_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -212,13 +265,13 @@ func (x *GetLicenseRequest) Reset() {
 	}
 }
 
-func (x *GetLicenseRequest) String() string {
+func (x *BatchCheckPermissionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLicenseRequest) ProtoMessage() {}
+func (*BatchCheckPermissionRequest) ProtoMessage() {}
 
-func (x *GetLicenseRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchCheckPermissionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_v1alpha_core_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -230,36 +283,28 @@ func (x *GetLicenseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLicenseRequest.ProtoReflect.Descriptor instead.
-func (*GetLicenseRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchCheckPermissionRequest.ProtoReflect.Descriptor instead.
+func (*BatchCheckPermissionRequest) Descriptor() ([]byte, []int) {
 	return file_v1alpha_core_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetLicenseRequest) GetOrgId() string {
-	if x != nil {
-		return x.OrgId
-	}
-	return ""
-}
-
-func (x *GetLicenseRequest) GetServiceId() string {
+func (x *BatchCheckPermissionRequest) GetChecks() []*CheckPermissionRequest {
 	if x != nil {
-		return x.ServiceId
+		return x.Checks
 	}
-	return ""
+	return nil
 }
 
-type GetLicenseResponse struct {
+type BatchCheckPermissionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SeatsTotal     int32 `protobuf:"zigzag32,1,opt,name=seatsTotal,proto3" json:"seatsTotal,omitempty"`         // Total number of seats assignable.
-	SeatsAvailable int32 `protobuf:"zigzag32,2,opt,name=seatsAvailable,proto3" json:"seatsAvailable,omitempty"` // Current number of available seats which can be assigned.
+	Results []*BatchCheckPermissionResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // One result per check, in the order of the checks.
 }
 
-func (x *GetLicenseResponse) Reset() {
-	*x = GetLicenseResponse{}
+func (x *BatchCheckPermissionResponse) Reset() {
+	*x = BatchCheckPermissionResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1alpha_core_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -267,13 +312,13 @@ func (x *GetLicenseResponse) Reset() {
 	}
 }
 
-func (x *GetLicenseResponse) String() string {
+func (x *BatchCheckPermissionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLicenseResponse) ProtoMessage() {}
+func (*BatchCheckPermissionResponse) ProtoMessage() {}
 
-func (x *GetLicenseResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchCheckPermissionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_v1alpha_core_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -285,39 +330,32 @@ func (x *GetLicenseResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLicenseResponse.ProtoReflect.Descriptor instead.
-func (*GetLicenseResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchCheckPermissionResponse.ProtoReflect.Descriptor instead.
+func (*BatchCheckPermissionResponse) Descriptor() ([]byte, []int) {
 	return file_v1alpha_core_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetLicenseResponse) GetSeatsTotal() int32 {
-	if x != nil {
-		return x.SeatsTotal
-	}
-	return 0
-}
-
-func (x *GetLicenseResponse) GetSeatsAvailable() int32 {
+func (x *BatchCheckPermissionResponse) GetResults() []*BatchCheckPermissionResult {
 	if x != nil {
-		return x.SeatsAvailable
+		return x.Results
 	}
-	return 0
+	return nil
 }
 
-// ModifySeatsRequest assuming we get the userId etc from the requester in the authorization header to validate if an "admin" can actually add licenses.
-type ModifySeatsRequest struct {
+type BatchCheckPermissionResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrgId     string   `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`         // The id of an license-able organization.
-	ServiceId string   `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"` // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
-	Assign    []string `protobuf:"bytes,3,rep,name=assign,proto3" json:"assign,omitempty"`       // User IDs to assign to the license.
-	Unassign  []string `protobuf:"bytes,4,rep,name=unassign,proto3" json:"unassign,omitempty"`   // User IDs to remove from the license.
+	Result      bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Description string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Error       string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`             // Why the check failed, empty if it was performed. A failed check does not fail the others.
+	Reason      string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`           // The stable reason of the failure, ex: INVALID_RESOURCE_ID, empty if the check was performed.
+	Obligations []string `protobuf:"bytes,5,rep,name=obligations,proto3" json:"obligations,omitempty"` // Conditions the caller must fulfill when access is allowed, ex: log, empty if there are none.
 }
 
-func (x *ModifySeatsRequest) Reset() {
-	*x = ModifySeatsRequest{}
+func (x *BatchCheckPermissionResult) Reset() {
+	*x = BatchCheckPermissionResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1alpha_core_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -325,13 +363,13 @@ func (x *ModifySeatsRequest) Reset() {
 	}
 }
 
-func (x *ModifySeatsRequest) String() string {
+func (x *BatchCheckPermissionResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModifySeatsRequest) ProtoMessage() {}
+func (*BatchCheckPermissionResult) ProtoMessage() {}
 
-func (x *ModifySeatsRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchCheckPermissionResult) ProtoReflect() protoreflect.Message {
 	mi := &file_v1alpha_core_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -343,47 +381,58 @@ func (x *ModifySeatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModifySeatsRequest.ProtoReflect.Descriptor instead.
-func (*ModifySeatsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchCheckPermissionResult.ProtoReflect.Descriptor instead.
+func (*BatchCheckPermissionResult) Descriptor() ([]byte, []int) {
 	return file_v1alpha_core_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ModifySeatsRequest) GetOrgId() string {
+func (x *BatchCheckPermissionResult) GetResult() bool {
 	if x != nil {
-		return x.OrgId
+		return x.Result
+	}
+	return false
+}
+
+func (x *BatchCheckPermissionResult) GetDescription() string {
+	if x != nil {
+		return x.Description
 	}
 	return ""
 }
 
-func (x *ModifySeatsRequest) GetServiceId() string {
+func (x *BatchCheckPermissionResult) GetError() string {
 	if x != nil {
-		return x.ServiceId
+		return x.Error
 	}
 	return ""
 }
 
-func (x *ModifySeatsRequest) GetAssign() []string {
+func (x *BatchCheckPermissionResult) GetReason() string {
 	if x != nil {
-		return x.Assign
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-func (x *ModifySeatsRequest) GetUnassign() []string {
+func (x *BatchCheckPermissionResult) GetObligations() []string {
 	if x != nil {
-		return x.Unassign
+		return x.Obligations
 	}
 	return nil
 }
 
-type ModifySeatsResponse struct {
+type LookupResourcesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Subject      string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Operation    string `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Resourcetype string `protobuf:"bytes,3,opt,name=resourcetype,proto3" json:"resourcetype,omitempty"`
 }
 
-func (x *ModifySeatsResponse) Reset() {
-	*x = ModifySeatsResponse{}
+func (x *LookupResourcesRequest) Reset() {
+	*x = LookupResourcesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1alpha_core_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -391,13 +440,13 @@ func (x *ModifySeatsResponse) Reset() {
 	}
 }
 
-func (x *ModifySeatsResponse) String() string {
+func (x *LookupResourcesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModifySeatsResponse) ProtoMessage() {}
+func (*LookupResourcesRequest) ProtoMessage() {}
 
-func (x *ModifySeatsResponse) ProtoReflect() protoreflect.Message {
+func (x *LookupResourcesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_v1alpha_core_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -409,24 +458,42 @@ func (x *ModifySeatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModifySeatsResponse.ProtoReflect.Descriptor instead.
-func (*ModifySeatsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LookupResourcesRequest.ProtoReflect.Descriptor instead.
+func (*LookupResourcesRequest) Descriptor() ([]byte, []int) {
 	return file_v1alpha_core_proto_rawDescGZIP(), []int{5}
 }
 
-type GetSeatsRequest struct {
+func (x *LookupResourcesRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *LookupResourcesRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *LookupResourcesRequest) GetResourcetype() string {
+	if x != nil {
+		return x.Resourcetype
+	}
+	return ""
+}
+
+type LookupResourcesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrgId        string          `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`                                          // The id of an license-able organization.
-	ServiceId    string          `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"`                                  // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
-	IncludeUsers *bool           `protobuf:"varint,3,opt,name=includeUsers,proto3,oneof" json:"includeUsers,omitempty"`                     // true: include enriched user representation. false: do not include (only IDs). Default: true.
-	Filter       *SeatFilterType `protobuf:"varint,4,opt,name=filter,proto3,enum=api.v1alpha.SeatFilterType,oneof" json:"filter,omitempty"` // filter, either assigned or assignable users returned. Default: assigned.
+	Resourceids []string `protobuf:"bytes,1,rep,name=resourceids,proto3" json:"resourceids,omitempty"` // The IDs of all resources of the type on which the subject can perform the operation.
 }
 
-func (x *GetSeatsRequest) Reset() {
-	*x = GetSeatsRequest{}
+func (x *LookupResourcesResponse) Reset() {
+	*x = LookupResourcesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1alpha_core_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -434,13 +501,13 @@ func (x *GetSeatsRequest) Reset() {
 	}
 }
 
-func (x *GetSeatsRequest) String() string {
+func (x *LookupResourcesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSeatsRequest) ProtoMessage() {}
+func (*LookupResourcesResponse) ProtoMessage() {}
 
-func (x *GetSeatsRequest) ProtoReflect() protoreflect.Message {
+func (x *LookupResourcesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_v1alpha_core_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -452,64 +519,106 @@ func (x *GetSeatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSeatsRequest.ProtoReflect.Descriptor instead.
-func (*GetSeatsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LookupResourcesResponse.ProtoReflect.Descriptor instead.
+func (*LookupResourcesResponse) Descriptor() ([]byte, []int) {
 	return file_v1alpha_core_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetSeatsRequest) GetOrgId() string {
+func (x *LookupResourcesResponse) GetResourceids() []string {
 	if x != nil {
-		return x.OrgId
+		return x.Resourceids
 	}
-	return ""
+	return nil
 }
 
-func (x *GetSeatsRequest) GetServiceId() string {
+type LookupSubjectsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation    string `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Resourcetype string `protobuf:"bytes,2,opt,name=resourcetype,proto3" json:"resourcetype,omitempty"`
+	Resourceid   string `protobuf:"bytes,3,opt,name=resourceid,proto3" json:"resourceid,omitempty"`
+}
+
+func (x *LookupSubjectsRequest) Reset() {
+	*x = LookupSubjectsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookupSubjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupSubjectsRequest) ProtoMessage() {}
+
+func (x *LookupSubjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupSubjectsRequest.ProtoReflect.Descriptor instead.
+func (*LookupSubjectsRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LookupSubjectsRequest) GetOperation() string {
 	if x != nil {
-		return x.ServiceId
+		return x.Operation
 	}
 	return ""
 }
 
-func (x *GetSeatsRequest) GetIncludeUsers() bool {
-	if x != nil && x.IncludeUsers != nil {
-		return *x.IncludeUsers
+func (x *LookupSubjectsRequest) GetResourcetype() string {
+	if x != nil {
+		return x.Resourcetype
 	}
-	return false
+	return ""
 }
 
-func (x *GetSeatsRequest) GetFilter() SeatFilterType {
-	if x != nil && x.Filter != nil {
-		return *x.Filter
+func (x *LookupSubjectsRequest) GetResourceid() string {
+	if x != nil {
+		return x.Resourceid
 	}
-	return SeatFilterType_assigned
+	return ""
 }
 
-type GetSeatsResponse struct {
+type LookupSubjectsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Users []*GetSeatsUserRepresentation `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // Just user IDs, unless "includeUsers" = true.
+	Subjects []string `protobuf:"bytes,1,rep,name=subjects,proto3" json:"subjects,omitempty"` // All subjects that can perform the operation on the resource, including the ones granted access indirectly.
 }
 
-func (x *GetSeatsResponse) Reset() {
-	*x = GetSeatsResponse{}
+func (x *LookupSubjectsResponse) Reset() {
+	*x = LookupSubjectsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1alpha_core_proto_msgTypes[7]
+		mi := &file_v1alpha_core_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetSeatsResponse) String() string {
+func (x *LookupSubjectsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSeatsResponse) ProtoMessage() {}
+func (*LookupSubjectsResponse) ProtoMessage() {}
 
-func (x *GetSeatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1alpha_core_proto_msgTypes[7]
+func (x *LookupSubjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,46 +629,44 @@ func (x *GetSeatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSeatsResponse.ProtoReflect.Descriptor instead.
-func (*GetSeatsResponse) Descriptor() ([]byte, []int) {
-	return file_v1alpha_core_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use LookupSubjectsResponse.ProtoReflect.Descriptor instead.
+func (*LookupSubjectsResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetSeatsResponse) GetUsers() []*GetSeatsUserRepresentation {
+func (x *LookupSubjectsResponse) GetSubjects() []string {
 	if x != nil {
-		return x.Users
+		return x.Subjects
 	}
 	return nil
 }
 
-// we may return more userinfo, this is a starting point.
-type GetSeatsUserRepresentation struct {
+type GetLicenseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DisplayName string `protobuf:"bytes,1,opt,name=displayName,proto3" json:"displayName,omitempty"`
-	Id          string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Assigned    bool   `protobuf:"varint,3,opt,name=assigned,proto3" json:"assigned,omitempty"`
+	OrgId     string `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`         // The id of an license-able organization.
+	ServiceId string `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"` // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
 }
 
-func (x *GetSeatsUserRepresentation) Reset() {
-	*x = GetSeatsUserRepresentation{}
+func (x *GetLicenseRequest) Reset() {
+	*x = GetLicenseRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1alpha_core_proto_msgTypes[8]
+		mi := &file_v1alpha_core_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetSeatsUserRepresentation) String() string {
+func (x *GetLicenseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSeatsUserRepresentation) ProtoMessage() {}
+func (*GetLicenseRequest) ProtoMessage() {}
 
-func (x *GetSeatsUserRepresentation) ProtoReflect() protoreflect.Message {
-	mi := &file_v1alpha_core_proto_msgTypes[8]
+func (x *GetLicenseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,33 +677,1748 @@ func (x *GetSeatsUserRepresentation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSeatsUserRepresentation.ProtoReflect.Descriptor instead.
-func (*GetSeatsUserRepresentation) Descriptor() ([]byte, []int) {
-	return file_v1alpha_core_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetLicenseRequest.ProtoReflect.Descriptor instead.
+func (*GetLicenseRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetSeatsUserRepresentation) GetDisplayName() string {
+func (x *GetLicenseRequest) GetOrgId() string {
 	if x != nil {
-		return x.DisplayName
+		return x.OrgId
 	}
 	return ""
 }
 
-func (x *GetSeatsUserRepresentation) GetId() string {
+func (x *GetLicenseRequest) GetServiceId() string {
 	if x != nil {
-		return x.Id
+		return x.ServiceId
 	}
 	return ""
 }
 
-func (x *GetSeatsUserRepresentation) GetAssigned() bool {
-	if x != nil {
-		return x.Assigned
-	}
-	return false
+type GetLicenseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeatsTotal     int32 `protobuf:"zigzag32,1,opt,name=seatsTotal,proto3" json:"seatsTotal,omitempty"`         // Total number of seats assignable.
+	SeatsAvailable int32 `protobuf:"zigzag32,2,opt,name=seatsAvailable,proto3" json:"seatsAvailable,omitempty"` // Current number of available seats which can be assigned.
 }
 
-var File_v1alpha_core_proto protoreflect.FileDescriptor
+func (x *GetLicenseResponse) Reset() {
+	*x = GetLicenseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLicenseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLicenseResponse) ProtoMessage() {}
+
+func (x *GetLicenseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLicenseResponse.ProtoReflect.Descriptor instead.
+func (*GetLicenseResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetLicenseResponse) GetSeatsTotal() int32 {
+	if x != nil {
+		return x.SeatsTotal
+	}
+	return 0
+}
+
+func (x *GetLicenseResponse) GetSeatsAvailable() int32 {
+	if x != nil {
+		return x.SeatsAvailable
+	}
+	return 0
+}
+
+// ModifySeatsRequest assuming we get the userId etc from the requester in the authorization header to validate if an "admin" can actually add licenses.
+type ModifySeatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId     string   `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`         // The id of an license-able organization.
+	ServiceId string   `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"` // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
+	Assign    []string `protobuf:"bytes,3,rep,name=assign,proto3" json:"assign,omitempty"`       // User IDs to assign to the license.
+	Unassign  []string `protobuf:"bytes,4,rep,name=unassign,proto3" json:"unassign,omitempty"`   // User IDs to remove from the license.
+}
+
+func (x *ModifySeatsRequest) Reset() {
+	*x = ModifySeatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifySeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifySeatsRequest) ProtoMessage() {}
+
+func (x *ModifySeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifySeatsRequest.ProtoReflect.Descriptor instead.
+func (*ModifySeatsRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ModifySeatsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ModifySeatsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ModifySeatsRequest) GetAssign() []string {
+	if x != nil {
+		return x.Assign
+	}
+	return nil
+}
+
+func (x *ModifySeatsRequest) GetUnassign() []string {
+	if x != nil {
+		return x.Unassign
+	}
+	return nil
+}
+
+type ModifySeatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ModifySeatsResponse) Reset() {
+	*x = ModifySeatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifySeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifySeatsResponse) ProtoMessage() {}
+
+func (x *ModifySeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifySeatsResponse.ProtoReflect.Descriptor instead.
+func (*ModifySeatsResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{12}
+}
+
+// BulkAssignSeatsRequest is streamed by the client, ex: one message per page of a department's users. The license is taken from the first message, later messages may leave it empty.
+type BulkAssignSeatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId     string   `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`         // The id of an license-able organization.
+	ServiceId string   `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"` // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
+	Subjects  []string `protobuf:"bytes,3,rep,name=subjects,proto3" json:"subjects,omitempty"`   // User IDs to assign to the license.
+}
+
+func (x *BulkAssignSeatsRequest) Reset() {
+	*x = BulkAssignSeatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkAssignSeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAssignSeatsRequest) ProtoMessage() {}
+
+func (x *BulkAssignSeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAssignSeatsRequest.ProtoReflect.Descriptor instead.
+func (*BulkAssignSeatsRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BulkAssignSeatsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *BulkAssignSeatsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *BulkAssignSeatsRequest) GetSubjects() []string {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+type BulkAssignSeatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Assigned               int32 `protobuf:"zigzag32,1,opt,name=assigned,proto3" json:"assigned,omitempty"`                             // Number of users assigned a seat.
+	SkippedAlreadyAssigned int32 `protobuf:"zigzag32,2,opt,name=skippedAlreadyAssigned,proto3" json:"skippedAlreadyAssigned,omitempty"` // Number of users that already had a seat, including duplicates in the stream.
+	SkippedLimit           int32 `protobuf:"zigzag32,3,opt,name=skippedLimit,proto3" json:"skippedLimit,omitempty"`                     // Number of users not assigned because the license ran out of seats.
+	Failed                 int32 `protobuf:"zigzag32,4,opt,name=failed,proto3" json:"failed,omitempty"`                                 // Number of users whose assignment failed, ex: because they are not members of the organization.
+	LimitReached           bool  `protobuf:"varint,5,opt,name=limitReached,proto3" json:"limitReached,omitempty"`                       // true: the license ran out of seats before all users were assigned.
+}
+
+func (x *BulkAssignSeatsResponse) Reset() {
+	*x = BulkAssignSeatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkAssignSeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAssignSeatsResponse) ProtoMessage() {}
+
+func (x *BulkAssignSeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAssignSeatsResponse.ProtoReflect.Descriptor instead.
+func (*BulkAssignSeatsResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BulkAssignSeatsResponse) GetAssigned() int32 {
+	if x != nil {
+		return x.Assigned
+	}
+	return 0
+}
+
+func (x *BulkAssignSeatsResponse) GetSkippedAlreadyAssigned() int32 {
+	if x != nil {
+		return x.SkippedAlreadyAssigned
+	}
+	return 0
+}
+
+func (x *BulkAssignSeatsResponse) GetSkippedLimit() int32 {
+	if x != nil {
+		return x.SkippedLimit
+	}
+	return 0
+}
+
+func (x *BulkAssignSeatsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkAssignSeatsResponse) GetLimitReached() bool {
+	if x != nil {
+		return x.LimitReached
+	}
+	return false
+}
+
+type CreateLicenseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId      string `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`              // The id of an license-able organization.
+	ServiceId  string `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"`      // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
+	SeatsTotal int32  `protobuf:"zigzag32,3,opt,name=seatsTotal,proto3" json:"seatsTotal,omitempty"` // Total number of seats assignable.
+}
+
+func (x *CreateLicenseRequest) Reset() {
+	*x = CreateLicenseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateLicenseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLicenseRequest) ProtoMessage() {}
+
+func (x *CreateLicenseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLicenseRequest.ProtoReflect.Descriptor instead.
+func (*CreateLicenseRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateLicenseRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *CreateLicenseRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *CreateLicenseRequest) GetSeatsTotal() int32 {
+	if x != nil {
+		return x.SeatsTotal
+	}
+	return 0
+}
+
+type CreateLicenseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CreateLicenseResponse) Reset() {
+	*x = CreateLicenseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateLicenseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLicenseResponse) ProtoMessage() {}
+
+func (x *CreateLicenseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLicenseResponse.ProtoReflect.Descriptor instead.
+func (*CreateLicenseResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{16}
+}
+
+type DeleteLicenseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId     string `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`         // The id of an license-able organization.
+	ServiceId string `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"` // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
+}
+
+func (x *DeleteLicenseRequest) Reset() {
+	*x = DeleteLicenseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteLicenseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLicenseRequest) ProtoMessage() {}
+
+func (x *DeleteLicenseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLicenseRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLicenseRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteLicenseRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *DeleteLicenseRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+type DeleteLicenseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeatsReleased int32 `protobuf:"zigzag32,1,opt,name=seatsReleased,proto3" json:"seatsReleased,omitempty"` // Number of seats that were assigned and got removed with the license. 0 if the license did not exist.
+}
+
+func (x *DeleteLicenseResponse) Reset() {
+	*x = DeleteLicenseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteLicenseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLicenseResponse) ProtoMessage() {}
+
+func (x *DeleteLicenseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLicenseResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLicenseResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteLicenseResponse) GetSeatsReleased() int32 {
+	if x != nil {
+		return x.SeatsReleased
+	}
+	return 0
+}
+
+type SetLicenseSeatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId      string `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`              // The id of an license-able organization.
+	ServiceId  string `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"`      // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
+	SeatsTotal int32  `protobuf:"zigzag32,3,opt,name=seatsTotal,proto3" json:"seatsTotal,omitempty"` // The new total number of seats assignable. Must not be below the number of seats in use.
+}
+
+func (x *SetLicenseSeatsRequest) Reset() {
+	*x = SetLicenseSeatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLicenseSeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLicenseSeatsRequest) ProtoMessage() {}
+
+func (x *SetLicenseSeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLicenseSeatsRequest.ProtoReflect.Descriptor instead.
+func (*SetLicenseSeatsRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetLicenseSeatsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SetLicenseSeatsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *SetLicenseSeatsRequest) GetSeatsTotal() int32 {
+	if x != nil {
+		return x.SeatsTotal
+	}
+	return 0
+}
+
+type SetLicenseSeatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetLicenseSeatsResponse) Reset() {
+	*x = SetLicenseSeatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLicenseSeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLicenseSeatsResponse) ProtoMessage() {}
+
+func (x *SetLicenseSeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLicenseSeatsResponse.ProtoReflect.Descriptor instead.
+func (*SetLicenseSeatsResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{20}
+}
+
+type ReconcileSeatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId     string `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`         // The id of an license-able organization.
+	ServiceId string `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"` // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
+	Repair    bool   `protobuf:"varint,3,opt,name=repair,proto3" json:"repair,omitempty"`      // true: remove the seats of non-members and correct the number of seats in use. false: only report the discrepancies.
+}
+
+func (x *ReconcileSeatsRequest) Reset() {
+	*x = ReconcileSeatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconcileSeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileSeatsRequest) ProtoMessage() {}
+
+func (x *ReconcileSeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileSeatsRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileSeatsRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReconcileSeatsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ReconcileSeatsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ReconcileSeatsRequest) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+type ReconcileSeatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordedSeatsInUse int32    `protobuf:"zigzag32,1,opt,name=recordedSeatsInUse,proto3" json:"recordedSeatsInUse,omitempty"` // Number of seats in use according to the license before reconciling.
+	ActualSeatsInUse   int32    `protobuf:"zigzag32,2,opt,name=actualSeatsInUse,proto3" json:"actualSeatsInUse,omitempty"`     // Number of assigned seats before reconciling.
+	NonMembers         []string `protobuf:"bytes,3,rep,name=nonMembers,proto3" json:"nonMembers,omitempty"`                    // User IDs holding a seat without being a member of the organization, ex: deleted users.
+	Repaired           bool     `protobuf:"varint,4,opt,name=repaired,proto3" json:"repaired,omitempty"`                       // true: the seats of nonMembers were removed and the number of seats in use was corrected.
+	SeatsInUse         int32    `protobuf:"zigzag32,5,opt,name=seatsInUse,proto3" json:"seatsInUse,omitempty"`                 // Number of seats in use after reconciling.
+	HasDiscrepancies   bool     `protobuf:"varint,6,opt,name=hasDiscrepancies,proto3" json:"hasDiscrepancies,omitempty"`       // true: the recorded seats in use did not match the assigned seats, or seats were held by non-members.
+}
+
+func (x *ReconcileSeatsResponse) Reset() {
+	*x = ReconcileSeatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconcileSeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileSeatsResponse) ProtoMessage() {}
+
+func (x *ReconcileSeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileSeatsResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileSeatsResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReconcileSeatsResponse) GetRecordedSeatsInUse() int32 {
+	if x != nil {
+		return x.RecordedSeatsInUse
+	}
+	return 0
+}
+
+func (x *ReconcileSeatsResponse) GetActualSeatsInUse() int32 {
+	if x != nil {
+		return x.ActualSeatsInUse
+	}
+	return 0
+}
+
+func (x *ReconcileSeatsResponse) GetNonMembers() []string {
+	if x != nil {
+		return x.NonMembers
+	}
+	return nil
+}
+
+func (x *ReconcileSeatsResponse) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+func (x *ReconcileSeatsResponse) GetSeatsInUse() int32 {
+	if x != nil {
+		return x.SeatsInUse
+	}
+	return 0
+}
+
+func (x *ReconcileSeatsResponse) GetHasDiscrepancies() bool {
+	if x != nil {
+		return x.HasDiscrepancies
+	}
+	return false
+}
+
+type UnassignAllForSubjectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId     string `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`         // The id of an license-able organization.
+	SubjectId string `protobuf:"bytes,2,opt,name=subjectId,proto3" json:"subjectId,omitempty"` // The user ID whose seats are removed in all services of the organization, ex: when the user leaves it.
+}
+
+func (x *UnassignAllForSubjectRequest) Reset() {
+	*x = UnassignAllForSubjectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnassignAllForSubjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignAllForSubjectRequest) ProtoMessage() {}
+
+func (x *UnassignAllForSubjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignAllForSubjectRequest.ProtoReflect.Descriptor instead.
+func (*UnassignAllForSubjectRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UnassignAllForSubjectRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *UnassignAllForSubjectRequest) GetSubjectId() string {
+	if x != nil {
+		return x.SubjectId
+	}
+	return ""
+}
+
+type UnassignAllForSubjectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceIds []string `protobuf:"bytes,1,rep,name=serviceIds,proto3" json:"serviceIds,omitempty"` // The services the user held a seat for. Empty if the user held none, ex: because it was already unassigned.
+}
+
+func (x *UnassignAllForSubjectResponse) Reset() {
+	*x = UnassignAllForSubjectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnassignAllForSubjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignAllForSubjectResponse) ProtoMessage() {}
+
+func (x *UnassignAllForSubjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignAllForSubjectResponse.ProtoReflect.Descriptor instead.
+func (*UnassignAllForSubjectResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UnassignAllForSubjectResponse) GetServiceIds() []string {
+	if x != nil {
+		return x.ServiceIds
+	}
+	return nil
+}
+
+type GetOrgSeatSummaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId string `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"` // The id of an license-able organization.
+}
+
+func (x *GetOrgSeatSummaryRequest) Reset() {
+	*x = GetOrgSeatSummaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrgSeatSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgSeatSummaryRequest) ProtoMessage() {}
+
+func (x *GetOrgSeatSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgSeatSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrgSeatSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetOrgSeatSummaryRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type GetOrgSeatSummaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Services       []*ServiceSeatUsage `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`                // The seat usage of the license of each service, ordered by service ID.
+	SeatsTotal     int32               `protobuf:"zigzag32,2,opt,name=seatsTotal,proto3" json:"seatsTotal,omitempty"`         // Total number of seats assignable across all licenses.
+	SeatsInUse     int32               `protobuf:"zigzag32,3,opt,name=seatsInUse,proto3" json:"seatsInUse,omitempty"`         // Number of seats assigned across all licenses.
+	SeatsAvailable int32               `protobuf:"zigzag32,4,opt,name=seatsAvailable,proto3" json:"seatsAvailable,omitempty"` // Number of available seats across all licenses.
+}
+
+func (x *GetOrgSeatSummaryResponse) Reset() {
+	*x = GetOrgSeatSummaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrgSeatSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgSeatSummaryResponse) ProtoMessage() {}
+
+func (x *GetOrgSeatSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgSeatSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrgSeatSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetOrgSeatSummaryResponse) GetServices() []*ServiceSeatUsage {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *GetOrgSeatSummaryResponse) GetSeatsTotal() int32 {
+	if x != nil {
+		return x.SeatsTotal
+	}
+	return 0
+}
+
+func (x *GetOrgSeatSummaryResponse) GetSeatsInUse() int32 {
+	if x != nil {
+		return x.SeatsInUse
+	}
+	return 0
+}
+
+func (x *GetOrgSeatSummaryResponse) GetSeatsAvailable() int32 {
+	if x != nil {
+		return x.SeatsAvailable
+	}
+	return 0
+}
+
+type ServiceSeatUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceId      string `protobuf:"bytes,1,opt,name=serviceId,proto3" json:"serviceId,omitempty"`
+	DisplayName    string `protobuf:"bytes,2,opt,name=displayName,proto3" json:"displayName,omitempty"`          // The human-readable name of the service, if known.
+	SeatsTotal     int32  `protobuf:"zigzag32,3,opt,name=seatsTotal,proto3" json:"seatsTotal,omitempty"`         // Total number of seats assignable.
+	SeatsInUse     int32  `protobuf:"zigzag32,4,opt,name=seatsInUse,proto3" json:"seatsInUse,omitempty"`         // Number of seats assigned.
+	SeatsAvailable int32  `protobuf:"zigzag32,5,opt,name=seatsAvailable,proto3" json:"seatsAvailable,omitempty"` // Current number of available seats which can be assigned.
+}
+
+func (x *ServiceSeatUsage) Reset() {
+	*x = ServiceSeatUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceSeatUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceSeatUsage) ProtoMessage() {}
+
+func (x *ServiceSeatUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceSeatUsage.ProtoReflect.Descriptor instead.
+func (*ServiceSeatUsage) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ServiceSeatUsage) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ServiceSeatUsage) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *ServiceSeatUsage) GetSeatsTotal() int32 {
+	if x != nil {
+		return x.SeatsTotal
+	}
+	return 0
+}
+
+func (x *ServiceSeatUsage) GetSeatsInUse() int32 {
+	if x != nil {
+		return x.SeatsInUse
+	}
+	return 0
+}
+
+func (x *ServiceSeatUsage) GetSeatsAvailable() int32 {
+	if x != nil {
+		return x.SeatsAvailable
+	}
+	return 0
+}
+
+type GetSeatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrgId           string          `protobuf:"bytes,1,opt,name=orgId,proto3" json:"orgId,omitempty"`                                          // The id of an license-able organization.
+	ServiceId       string          `protobuf:"bytes,2,opt,name=serviceId,proto3" json:"serviceId,omitempty"`                                  // A "serviceId" is an arbitrary identifier for a service with limited access that may be granted to an organization.
+	IncludeUsers    *bool           `protobuf:"varint,3,opt,name=includeUsers,proto3,oneof" json:"includeUsers,omitempty"`                     // true: include enriched user representation. false: do not include (only IDs). Default: true.
+	Filter          *SeatFilterType `protobuf:"varint,4,opt,name=filter,proto3,enum=api.v1alpha.SeatFilterType,oneof" json:"filter,omitempty"` // filter, either assigned or assignable users returned. Default: assigned.
+	SortBy          *SeatSortField  `protobuf:"varint,5,opt,name=sortBy,proto3,enum=api.v1alpha.SeatSortField,oneof" json:"sortBy,omitempty"`  // The field users are sorted by, ties are broken by ID. Default: id.
+	Descending      *bool           `protobuf:"varint,6,opt,name=descending,proto3,oneof" json:"descending,omitempty"`                         // true: sort users in descending order. Default: false.
+	OnlyReclaimable *bool           `protobuf:"varint,7,opt,name=onlyReclaimable,proto3,oneof" json:"onlyReclaimable,omitempty"`               // true: only return assigned users that are disabled, ex: former employees, whose seats can be reclaimed. Requires the assigned filter. Default: false.
+}
+
+func (x *GetSeatsRequest) Reset() {
+	*x = GetSeatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatsRequest) ProtoMessage() {}
+
+func (x *GetSeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeatsRequest.ProtoReflect.Descriptor instead.
+func (*GetSeatsRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetSeatsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *GetSeatsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *GetSeatsRequest) GetIncludeUsers() bool {
+	if x != nil && x.IncludeUsers != nil {
+		return *x.IncludeUsers
+	}
+	return false
+}
+
+func (x *GetSeatsRequest) GetFilter() SeatFilterType {
+	if x != nil && x.Filter != nil {
+		return *x.Filter
+	}
+	return SeatFilterType_assigned
+}
+
+func (x *GetSeatsRequest) GetSortBy() SeatSortField {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return SeatSortField_id
+}
+
+func (x *GetSeatsRequest) GetDescending() bool {
+	if x != nil && x.Descending != nil {
+		return *x.Descending
+	}
+	return false
+}
+
+func (x *GetSeatsRequest) GetOnlyReclaimable() bool {
+	if x != nil && x.OnlyReclaimable != nil {
+		return *x.OnlyReclaimable
+	}
+	return false
+}
+
+type GetSeatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users []*GetSeatsUserRepresentation `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // Just user IDs, unless "includeUsers" = true.
+}
+
+func (x *GetSeatsResponse) Reset() {
+	*x = GetSeatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatsResponse) ProtoMessage() {}
+
+func (x *GetSeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeatsResponse.ProtoReflect.Descriptor instead.
+func (*GetSeatsResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetSeatsResponse) GetUsers() []*GetSeatsUserRepresentation {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// we may return more userinfo, this is a starting point.
+type GetSeatsUserRepresentation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DisplayName string `protobuf:"bytes,1,opt,name=displayName,proto3" json:"displayName,omitempty"`
+	Id          string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Assigned    bool   `protobuf:"varint,3,opt,name=assigned,proto3" json:"assigned,omitempty"`
+	Reclaimable bool   `protobuf:"varint,4,opt,name=reclaimable,proto3" json:"reclaimable,omitempty"` // true: the user is disabled and holds a seat that can be reclaimed. Only known if "includeUsers" or "onlyReclaimable" is true, false otherwise.
+}
+
+func (x *GetSeatsUserRepresentation) Reset() {
+	*x = GetSeatsUserRepresentation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSeatsUserRepresentation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatsUserRepresentation) ProtoMessage() {}
+
+func (x *GetSeatsUserRepresentation) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeatsUserRepresentation.ProtoReflect.Descriptor instead.
+func (*GetSeatsUserRepresentation) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetSeatsUserRepresentation) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *GetSeatsUserRepresentation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetSeatsUserRepresentation) GetAssigned() bool {
+	if x != nil {
+		return x.Assigned
+	}
+	return false
+}
+
+func (x *GetSeatsUserRepresentation) GetReclaimable() bool {
+	if x != nil {
+		return x.Reclaimable
+	}
+	return false
+}
+
+type WriteRelationshipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceType string `protobuf:"bytes,1,opt,name=resourceType,proto3" json:"resourceType,omitempty"` // The type of the resource, ex: "group". Licenses and seats cannot be written directly.
+	ResourceId   string `protobuf:"bytes,2,opt,name=resourceId,proto3" json:"resourceId,omitempty"`     // The id of the resource, ex: "g1".
+	Relation     string `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`         // The relation of the subject to the resource, ex: "member".
+	SubjectType  string `protobuf:"bytes,4,opt,name=subjectType,proto3" json:"subjectType,omitempty"`   // The type of the subject, ex: "user".
+	SubjectId    string `protobuf:"bytes,5,opt,name=subjectId,proto3" json:"subjectId,omitempty"`       // The id of the subject, ex: "u1".
+}
+
+func (x *WriteRelationshipRequest) Reset() {
+	*x = WriteRelationshipRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteRelationshipRequest) ProtoMessage() {}
+
+func (x *WriteRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*WriteRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WriteRelationshipRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *WriteRelationshipRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *WriteRelationshipRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *WriteRelationshipRequest) GetSubjectType() string {
+	if x != nil {
+		return x.SubjectType
+	}
+	return ""
+}
+
+func (x *WriteRelationshipRequest) GetSubjectId() string {
+	if x != nil {
+		return x.SubjectId
+	}
+	return ""
+}
+
+type WriteRelationshipResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WriteRelationshipResponse) Reset() {
+	*x = WriteRelationshipResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteRelationshipResponse) ProtoMessage() {}
+
+func (x *WriteRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*WriteRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{32}
+}
+
+type DeleteRelationshipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceType string `protobuf:"bytes,1,opt,name=resourceType,proto3" json:"resourceType,omitempty"` // The type of the resource, ex: "group". Licenses and seats cannot be deleted directly.
+	ResourceId   string `protobuf:"bytes,2,opt,name=resourceId,proto3" json:"resourceId,omitempty"`     // The id of the resource, ex: "g1".
+	Relation     string `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`         // The relation of the subject to the resource, ex: "member".
+	SubjectType  string `protobuf:"bytes,4,opt,name=subjectType,proto3" json:"subjectType,omitempty"`   // The type of the subject, ex: "user".
+	SubjectId    string `protobuf:"bytes,5,opt,name=subjectId,proto3" json:"subjectId,omitempty"`       // The id of the subject, ex: "u1".
+}
+
+func (x *DeleteRelationshipRequest) Reset() {
+	*x = DeleteRelationshipRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRelationshipRequest) ProtoMessage() {}
+
+func (x *DeleteRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteRelationshipRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *DeleteRelationshipRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *DeleteRelationshipRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *DeleteRelationshipRequest) GetSubjectType() string {
+	if x != nil {
+		return x.SubjectType
+	}
+	return ""
+}
+
+func (x *DeleteRelationshipRequest) GetSubjectId() string {
+	if x != nil {
+		return x.SubjectId
+	}
+	return ""
+}
+
+type DeleteRelationshipResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteRelationshipResponse) Reset() {
+	*x = DeleteRelationshipResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRelationshipResponse) ProtoMessage() {}
+
+func (x *DeleteRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{34}
+}
+
+type ReadRelationshipsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceType string `protobuf:"bytes,1,opt,name=resourceType,proto3" json:"resourceType,omitempty"` // The type of the resources to list the relationships of, ex: "license".
+	ResourceId   string `protobuf:"bytes,2,opt,name=resourceId,proto3" json:"resourceId,omitempty"`     // Optional: only list the relationships of this resource, ex: "o1/smarts".
+	Relation     string `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`         // Optional: only list relationships with this relation, ex: "assigned".
+	Cursor       string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`             // The nextCursor of the previous page. Empty reads the first page.
+	Limit        int32  `protobuf:"zigzag32,5,opt,name=limit,proto3" json:"limit,omitempty"`            // Maximum number of relationships in the page. 0 uses the default of 100, at most 1000 are returned.
+}
+
+func (x *ReadRelationshipsRequest) Reset() {
+	*x = ReadRelationshipsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadRelationshipsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRelationshipsRequest) ProtoMessage() {}
+
+func (x *ReadRelationshipsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRelationshipsRequest.ProtoReflect.Descriptor instead.
+func (*ReadRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReadRelationshipsRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ReadRelationshipsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ReadRelationshipsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Relationships []*Relationship `protobuf:"bytes,1,rep,name=relationships,proto3" json:"relationships,omitempty"` // The relationships of this page.
+	NextCursor    string          `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`       // Reads the following page. Empty on the last page.
+}
+
+func (x *ReadRelationshipsResponse) Reset() {
+	*x = ReadRelationshipsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadRelationshipsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRelationshipsResponse) ProtoMessage() {}
+
+func (x *ReadRelationshipsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRelationshipsResponse.ProtoReflect.Descriptor instead.
+func (*ReadRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReadRelationshipsResponse) GetRelationships() []*Relationship {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+func (x *ReadRelationshipsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type Relationship struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceType string `protobuf:"bytes,1,opt,name=resourceType,proto3" json:"resourceType,omitempty"` // The type of the resource, ex: "license".
+	ResourceId   string `protobuf:"bytes,2,opt,name=resourceId,proto3" json:"resourceId,omitempty"`     // The id of the resource, ex: "o1/smarts".
+	Relation     string `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`         // The relation of the subject to the resource, ex: "assigned".
+	SubjectType  string `protobuf:"bytes,4,opt,name=subjectType,proto3" json:"subjectType,omitempty"`   // The type of the subject, ex: "user".
+	SubjectId    string `protobuf:"bytes,5,opt,name=subjectId,proto3" json:"subjectId,omitempty"`       // The id of the subject, ex: "u1".
+}
+
+func (x *Relationship) Reset() {
+	*x = Relationship{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1alpha_core_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Relationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relationship) ProtoMessage() {}
+
+func (x *Relationship) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha_core_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relationship.ProtoReflect.Descriptor instead.
+func (*Relationship) Descriptor() ([]byte, []int) {
+	return file_v1alpha_core_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Relationship) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *Relationship) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *Relationship) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *Relationship) GetSubjectType() string {
+	if x != nil {
+		return x.SubjectType
+	}
+	return ""
+}
+
+func (x *Relationship) GetSubjectId() string {
+	if x != nil {
+		return x.SubjectId
+	}
+	return ""
+}
+
+var File_v1alpha_core_proto protoreflect.FileDescriptor
 
 var file_v1alpha_core_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70,
@@ -610,87 +2432,394 @@ var file_v1alpha_core_proto_rawDesc = []byte{
 	0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f,
 	0x75, 0x72, 0x63, 0x65, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f,
 	0x75, 0x72, 0x63, 0x65, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x69, 0x64, 0x22, 0x53, 0x0a, 0x17, 0x43, 0x68, 0x65, 0x63,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x69, 0x64, 0x22, 0x75, 0x0a, 0x17, 0x43, 0x68, 0x65, 0x63,
 	0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
 	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64,
 	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x47, 0x0a,
-	0x11, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x5c, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x63,
-	0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
-	0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x11,
-	0x52, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0e,
-	0x73, 0x65, 0x61, 0x74, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x11, 0x52, 0x0e, 0x73, 0x65, 0x61, 0x74, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c,
-	0x61, 0x62, 0x6c, 0x65, 0x22, 0x7c, 0x0a, 0x12, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65,
-	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72,
-	0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64,
-	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
-	0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x61, 0x73, 0x73, 0x69,
-	0x67, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x75, 0x6e, 0x61, 0x73, 0x73, 0x69,
-	0x67, 0x6e, 0x22, 0x15, 0x0a, 0x13, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc4, 0x01, 0x0a, 0x0f, 0x47, 0x65,
-	0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72,
-	0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49,
-	0x64, 0x12, 0x27, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x73, 0x65, 0x72,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75,
-	0x64, 0x65, 0x55, 0x73, 0x65, 0x72, 0x73, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a, 0x06, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x48, 0x01, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x88, 0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x22, 0x51, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65,
-	0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x75, 0x73,
-	0x65, 0x72, 0x73, 0x22, 0x6a, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x55,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a,
+	0x0b, 0x6f, 0x62, 0x6c, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x6f, 0x62, 0x6c, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x5a, 0x0a, 0x1b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b,
+	0x0a, 0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x22, 0x61, 0x0a, 0x1c, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xa6,
+	0x01, 0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x62, 0x6c, 0x69, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x62, 0x6c, 0x69,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x74, 0x0a, 0x16, 0x4c, 0x6f, 0x6f, 0x6b, 0x75,
+	0x70, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3b, 0x0a,
+	0x17, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x69, 0x64, 0x73, 0x22, 0x79, 0x0a, 0x15, 0x4c, 0x6f,
+	0x6f, 0x6b, 0x75, 0x70, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x69, 0x64, 0x22, 0x34, 0x0a, 0x16, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53,
+	0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x22, 0x47, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x49, 0x64, 0x22, 0x5c, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65,
+	0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x11, 0x52, 0x0a,
+	0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x65,
+	0x61, 0x74, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x11, 0x52, 0x0e, 0x73, 0x65, 0x61, 0x74, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x22, 0x7c, 0x0a, 0x12, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x67, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x61, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x75, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x22, 0x15, 0x0a, 0x13, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x68, 0x0a, 0x16, 0x42, 0x75, 0x6c, 0x6b, 0x41,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x22, 0xcd, 0x01, 0x0a, 0x17, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x11, 0x52,
+	0x08, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x16, 0x73, 0x6b, 0x69,
+	0x70, 0x70, 0x65, 0x64, 0x41, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x11, 0x52, 0x16, 0x73, 0x6b, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x41, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x11, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x11, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x22, 0x0a,
+	0x0c, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x61, 0x63, 0x68, 0x65,
+	0x64, 0x22, 0x6a, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x67,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x11, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x17, 0x0a,
+	0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4a, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f,
+	0x72, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x64, 0x22, 0x3d, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x69, 0x63, 0x65,
+	0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x73,
+	0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x11, 0x52, 0x0d, 0x73, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x64, 0x22, 0x6c, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x53,
+	0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f,
+	0x72, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x11, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22,
+	0x19, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x65, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x63, 0x0a, 0x15, 0x52, 0x65,
+	0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x61, 0x69,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x22,
+	0xfc, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x12, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x53, 0x65, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x55, 0x73, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x11, 0x52, 0x12, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64,
+	0x53, 0x65, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x55, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x61, 0x63,
+	0x74, 0x75, 0x61, 0x6c, 0x53, 0x65, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x55, 0x73, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x11, 0x52, 0x10, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x65, 0x61, 0x74,
+	0x73, 0x49, 0x6e, 0x55, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x6f, 0x6e, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x6f, 0x6e, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72,
+	0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x55, 0x73, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x11, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x55,
+	0x73, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x68, 0x61, 0x73, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x70,
+	0x61, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x68, 0x61,
+	0x73, 0x44, 0x69, 0x73, 0x63, 0x72, 0x65, 0x70, 0x61, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x22, 0x52,
+	0x0a, 0x1c, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x41, 0x6c, 0x6c, 0x46, 0x6f, 0x72,
+	0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f,
+	0x72, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x64, 0x22, 0x3f, 0x0a, 0x1d, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x41, 0x6c,
+	0x6c, 0x46, 0x6f, 0x72, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x64, 0x73, 0x22, 0x30, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x67, 0x53, 0x65, 0x61,
+	0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6f, 0x72, 0x67, 0x49, 0x64, 0x22, 0xbe, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x67,
+	0x53, 0x65, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x74, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x11, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1e,
+	0x0a, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x55, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x11, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x55, 0x73, 0x65, 0x12, 0x26,
+	0x0a, 0x0e, 0x73, 0x65, 0x61, 0x74, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x11, 0x52, 0x0e, 0x73, 0x65, 0x61, 0x74, 0x73, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x22, 0xba, 0x01, 0x0a, 0x10, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x53, 0x65, 0x61, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x11, 0x52,
+	0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x65, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x55, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x11, 0x52,
+	0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x55, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x73,
+	0x65, 0x61, 0x74, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x11, 0x52, 0x0e, 0x73, 0x65, 0x61, 0x74, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x22, 0xff, 0x02, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x72, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0c, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x48, 0x01, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x37,
+	0x0a, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x53, 0x65, 0x61,
+	0x74, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x48, 0x02, 0x52, 0x06, 0x73, 0x6f,
+	0x72, 0x74, 0x42, 0x79, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x03, 0x52, 0x0a, 0x64,
+	0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f,
+	0x6f, 0x6e, 0x6c, 0x79, 0x52, 0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x04, 0x52, 0x0f, 0x6f, 0x6e, 0x6c, 0x79, 0x52, 0x65, 0x63,
+	0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x73, 0x65, 0x72, 0x73, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x6f, 0x72, 0x74,
+	0x42, 0x79, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x52, 0x65, 0x63, 0x6c, 0x61, 0x69,
+	0x6d, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x51, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x05, 0x75, 0x73, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x55,
 	0x73, 0x65, 0x72, 0x52, 0x65, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x2a,
-	0x2e, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0c, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x10, 0x00, 0x12,
-	0x0e, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x10, 0x01, 0x32,
-	0x71, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x12, 0x5e, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x32, 0x80, 0x02, 0x0a, 0x0e, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65,
-	0x6e, 0x73, 0x65, 0x12, 0x1e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0b, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79,
-	0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x6e, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x22, 0x8c, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74,
+	0x53, 0x65, 0x61, 0x74, 0x73, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x70, 0x72, 0x65, 0x73, 0x65,
+	0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d,
+	0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6c,
+	0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x22, 0xba, 0x01, 0x0a, 0x18, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x49, 0x64, 0x22, 0x1b, 0x0a, 0x19, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0xbb, 0x01, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x22, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x20, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22,
+	0x1c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa8, 0x01,
+	0x0a, 0x18, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68,
+	0x69, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x11, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x7c, 0x0a, 0x19, 0x52, 0x65, 0x61, 0x64,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x0d, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74,
+	0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xae, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x2a, 0x2e, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x74, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x61, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x10, 0x01, 0x2a, 0x28, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x74, 0x53,
+	0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x06, 0x0a, 0x02, 0x69, 0x64, 0x10, 0x00,
+	0x12, 0x0f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x10,
+	0x01, 0x32, 0x9d, 0x03, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x5e, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6d, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x0f, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75,
+	0x70, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x0e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53,
+	0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x32, 0xab, 0x07, 0x0a, 0x0e, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x12, 0x1e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0b, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53,
+	0x65, 0x61, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
 	0x70, 0x68, 0x61, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x08, 0x47, 0x65,
-	0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x65, 0x64, 0x48, 0x61, 0x74, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68,
-	0x74, 0x73, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67,
-	0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x3b, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x08, 0x47, 0x65, 0x74,
+	0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x67, 0x53, 0x65,
+	0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x25, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x67, 0x53, 0x65,
+	0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x47,
+	0x65, 0x74, 0x4f, 0x72, 0x67, 0x53, 0x65, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x0f, 0x42, 0x75,
+	0x6c, 0x6b, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x23, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x53, 0x65, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x5b, 0x0a, 0x0e,
+	0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x22,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x52, 0x65, 0x63,
+	0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x70, 0x0a, 0x15, 0x55, 0x6e, 0x61,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x41, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x53, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x12, 0x29, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x2e, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x41, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x53,
+	0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x55, 0x6e, 0x61, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x41, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x0f, 0x53,
+	0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x23,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x53, 0x65, 0x74,
+	0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x53, 0x65, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0d, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c,
+	0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x69,
+	0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32,
+	0xca, 0x02, 0x0a, 0x13, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x64, 0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x25, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x67, 0x0a,
+	0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x68, 0x69, 0x70, 0x12, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x11, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x12, 0x25, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69,
+	0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x40, 0x5a, 0x3e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x65, 0x64, 0x48, 0x61,
+	0x74, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x73, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x72,
+	0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x3b, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -705,36 +2834,97 @@ func file_v1alpha_core_proto_rawDescGZIP() []byte {
 	return file_v1alpha_core_proto_rawDescData
 }
 
-var file_v1alpha_core_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_v1alpha_core_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_v1alpha_core_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_v1alpha_core_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_v1alpha_core_proto_goTypes = []interface{}{
-	(SeatFilterType)(0),                // 0: api.v1alpha.SeatFilterType
-	(*CheckPermissionRequest)(nil),     // 1: api.v1alpha.CheckPermissionRequest
-	(*CheckPermissionResponse)(nil),    // 2: api.v1alpha.CheckPermissionResponse
-	(*GetLicenseRequest)(nil),          // 3: api.v1alpha.GetLicenseRequest
-	(*GetLicenseResponse)(nil),         // 4: api.v1alpha.GetLicenseResponse
-	(*ModifySeatsRequest)(nil),         // 5: api.v1alpha.ModifySeatsRequest
-	(*ModifySeatsResponse)(nil),        // 6: api.v1alpha.ModifySeatsResponse
-	(*GetSeatsRequest)(nil),            // 7: api.v1alpha.GetSeatsRequest
-	(*GetSeatsResponse)(nil),           // 8: api.v1alpha.GetSeatsResponse
-	(*GetSeatsUserRepresentation)(nil), // 9: api.v1alpha.GetSeatsUserRepresentation
+	(SeatFilterType)(0),                   // 0: api.v1alpha.SeatFilterType
+	(SeatSortField)(0),                    // 1: api.v1alpha.SeatSortField
+	(*CheckPermissionRequest)(nil),        // 2: api.v1alpha.CheckPermissionRequest
+	(*CheckPermissionResponse)(nil),       // 3: api.v1alpha.CheckPermissionResponse
+	(*BatchCheckPermissionRequest)(nil),   // 4: api.v1alpha.BatchCheckPermissionRequest
+	(*BatchCheckPermissionResponse)(nil),  // 5: api.v1alpha.BatchCheckPermissionResponse
+	(*BatchCheckPermissionResult)(nil),    // 6: api.v1alpha.BatchCheckPermissionResult
+	(*LookupResourcesRequest)(nil),        // 7: api.v1alpha.LookupResourcesRequest
+	(*LookupResourcesResponse)(nil),       // 8: api.v1alpha.LookupResourcesResponse
+	(*LookupSubjectsRequest)(nil),         // 9: api.v1alpha.LookupSubjectsRequest
+	(*LookupSubjectsResponse)(nil),        // 10: api.v1alpha.LookupSubjectsResponse
+	(*GetLicenseRequest)(nil),             // 11: api.v1alpha.GetLicenseRequest
+	(*GetLicenseResponse)(nil),            // 12: api.v1alpha.GetLicenseResponse
+	(*ModifySeatsRequest)(nil),            // 13: api.v1alpha.ModifySeatsRequest
+	(*ModifySeatsResponse)(nil),           // 14: api.v1alpha.ModifySeatsResponse
+	(*BulkAssignSeatsRequest)(nil),        // 15: api.v1alpha.BulkAssignSeatsRequest
+	(*BulkAssignSeatsResponse)(nil),       // 16: api.v1alpha.BulkAssignSeatsResponse
+	(*CreateLicenseRequest)(nil),          // 17: api.v1alpha.CreateLicenseRequest
+	(*CreateLicenseResponse)(nil),         // 18: api.v1alpha.CreateLicenseResponse
+	(*DeleteLicenseRequest)(nil),          // 19: api.v1alpha.DeleteLicenseRequest
+	(*DeleteLicenseResponse)(nil),         // 20: api.v1alpha.DeleteLicenseResponse
+	(*SetLicenseSeatsRequest)(nil),        // 21: api.v1alpha.SetLicenseSeatsRequest
+	(*SetLicenseSeatsResponse)(nil),       // 22: api.v1alpha.SetLicenseSeatsResponse
+	(*ReconcileSeatsRequest)(nil),         // 23: api.v1alpha.ReconcileSeatsRequest
+	(*ReconcileSeatsResponse)(nil),        // 24: api.v1alpha.ReconcileSeatsResponse
+	(*UnassignAllForSubjectRequest)(nil),  // 25: api.v1alpha.UnassignAllForSubjectRequest
+	(*UnassignAllForSubjectResponse)(nil), // 26: api.v1alpha.UnassignAllForSubjectResponse
+	(*GetOrgSeatSummaryRequest)(nil),      // 27: api.v1alpha.GetOrgSeatSummaryRequest
+	(*GetOrgSeatSummaryResponse)(nil),     // 28: api.v1alpha.GetOrgSeatSummaryResponse
+	(*ServiceSeatUsage)(nil),              // 29: api.v1alpha.ServiceSeatUsage
+	(*GetSeatsRequest)(nil),               // 30: api.v1alpha.GetSeatsRequest
+	(*GetSeatsResponse)(nil),              // 31: api.v1alpha.GetSeatsResponse
+	(*GetSeatsUserRepresentation)(nil),    // 32: api.v1alpha.GetSeatsUserRepresentation
+	(*WriteRelationshipRequest)(nil),      // 33: api.v1alpha.WriteRelationshipRequest
+	(*WriteRelationshipResponse)(nil),     // 34: api.v1alpha.WriteRelationshipResponse
+	(*DeleteRelationshipRequest)(nil),     // 35: api.v1alpha.DeleteRelationshipRequest
+	(*DeleteRelationshipResponse)(nil),    // 36: api.v1alpha.DeleteRelationshipResponse
+	(*ReadRelationshipsRequest)(nil),      // 37: api.v1alpha.ReadRelationshipsRequest
+	(*ReadRelationshipsResponse)(nil),     // 38: api.v1alpha.ReadRelationshipsResponse
+	(*Relationship)(nil),                  // 39: api.v1alpha.Relationship
 }
 var file_v1alpha_core_proto_depIdxs = []int32{
-	0, // 0: api.v1alpha.GetSeatsRequest.filter:type_name -> api.v1alpha.SeatFilterType
-	9, // 1: api.v1alpha.GetSeatsResponse.users:type_name -> api.v1alpha.GetSeatsUserRepresentation
-	1, // 2: api.v1alpha.CheckPermission.CheckPermission:input_type -> api.v1alpha.CheckPermissionRequest
-	3, // 3: api.v1alpha.LicenseService.GetLicense:input_type -> api.v1alpha.GetLicenseRequest
-	5, // 4: api.v1alpha.LicenseService.ModifySeats:input_type -> api.v1alpha.ModifySeatsRequest
-	7, // 5: api.v1alpha.LicenseService.GetSeats:input_type -> api.v1alpha.GetSeatsRequest
-	2, // 6: api.v1alpha.CheckPermission.CheckPermission:output_type -> api.v1alpha.CheckPermissionResponse
-	4, // 7: api.v1alpha.LicenseService.GetLicense:output_type -> api.v1alpha.GetLicenseResponse
-	6, // 8: api.v1alpha.LicenseService.ModifySeats:output_type -> api.v1alpha.ModifySeatsResponse
-	8, // 9: api.v1alpha.LicenseService.GetSeats:output_type -> api.v1alpha.GetSeatsResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2,  // 0: api.v1alpha.BatchCheckPermissionRequest.checks:type_name -> api.v1alpha.CheckPermissionRequest
+	6,  // 1: api.v1alpha.BatchCheckPermissionResponse.results:type_name -> api.v1alpha.BatchCheckPermissionResult
+	29, // 2: api.v1alpha.GetOrgSeatSummaryResponse.services:type_name -> api.v1alpha.ServiceSeatUsage
+	0,  // 3: api.v1alpha.GetSeatsRequest.filter:type_name -> api.v1alpha.SeatFilterType
+	1,  // 4: api.v1alpha.GetSeatsRequest.sortBy:type_name -> api.v1alpha.SeatSortField
+	32, // 5: api.v1alpha.GetSeatsResponse.users:type_name -> api.v1alpha.GetSeatsUserRepresentation
+	39, // 6: api.v1alpha.ReadRelationshipsResponse.relationships:type_name -> api.v1alpha.Relationship
+	2,  // 7: api.v1alpha.CheckPermission.CheckPermission:input_type -> api.v1alpha.CheckPermissionRequest
+	4,  // 8: api.v1alpha.CheckPermission.BatchCheckPermission:input_type -> api.v1alpha.BatchCheckPermissionRequest
+	7,  // 9: api.v1alpha.CheckPermission.LookupResources:input_type -> api.v1alpha.LookupResourcesRequest
+	9,  // 10: api.v1alpha.CheckPermission.LookupSubjects:input_type -> api.v1alpha.LookupSubjectsRequest
+	11, // 11: api.v1alpha.LicenseService.GetLicense:input_type -> api.v1alpha.GetLicenseRequest
+	13, // 12: api.v1alpha.LicenseService.ModifySeats:input_type -> api.v1alpha.ModifySeatsRequest
+	30, // 13: api.v1alpha.LicenseService.GetSeats:input_type -> api.v1alpha.GetSeatsRequest
+	27, // 14: api.v1alpha.LicenseService.GetOrgSeatSummary:input_type -> api.v1alpha.GetOrgSeatSummaryRequest
+	15, // 15: api.v1alpha.LicenseService.BulkAssignSeats:input_type -> api.v1alpha.BulkAssignSeatsRequest
+	23, // 16: api.v1alpha.LicenseService.ReconcileSeats:input_type -> api.v1alpha.ReconcileSeatsRequest
+	25, // 17: api.v1alpha.LicenseService.UnassignAllForSubject:input_type -> api.v1alpha.UnassignAllForSubjectRequest
+	21, // 18: api.v1alpha.LicenseService.SetLicenseSeats:input_type -> api.v1alpha.SetLicenseSeatsRequest
+	17, // 19: api.v1alpha.LicenseService.CreateLicense:input_type -> api.v1alpha.CreateLicenseRequest
+	19, // 20: api.v1alpha.LicenseService.DeleteLicense:input_type -> api.v1alpha.DeleteLicenseRequest
+	33, // 21: api.v1alpha.RelationshipService.WriteRelationship:input_type -> api.v1alpha.WriteRelationshipRequest
+	35, // 22: api.v1alpha.RelationshipService.DeleteRelationship:input_type -> api.v1alpha.DeleteRelationshipRequest
+	37, // 23: api.v1alpha.RelationshipService.ReadRelationships:input_type -> api.v1alpha.ReadRelationshipsRequest
+	3,  // 24: api.v1alpha.CheckPermission.CheckPermission:output_type -> api.v1alpha.CheckPermissionResponse
+	5,  // 25: api.v1alpha.CheckPermission.BatchCheckPermission:output_type -> api.v1alpha.BatchCheckPermissionResponse
+	8,  // 26: api.v1alpha.CheckPermission.LookupResources:output_type -> api.v1alpha.LookupResourcesResponse
+	10, // 27: api.v1alpha.CheckPermission.LookupSubjects:output_type -> api.v1alpha.LookupSubjectsResponse
+	12, // 28: api.v1alpha.LicenseService.GetLicense:output_type -> api.v1alpha.GetLicenseResponse
+	14, // 29: api.v1alpha.LicenseService.ModifySeats:output_type -> api.v1alpha.ModifySeatsResponse
+	31, // 30: api.v1alpha.LicenseService.GetSeats:output_type -> api.v1alpha.GetSeatsResponse
+	28, // 31: api.v1alpha.LicenseService.GetOrgSeatSummary:output_type -> api.v1alpha.GetOrgSeatSummaryResponse
+	16, // 32: api.v1alpha.LicenseService.BulkAssignSeats:output_type -> api.v1alpha.BulkAssignSeatsResponse
+	24, // 33: api.v1alpha.LicenseService.ReconcileSeats:output_type -> api.v1alpha.ReconcileSeatsResponse
+	26, // 34: api.v1alpha.LicenseService.UnassignAllForSubject:output_type -> api.v1alpha.UnassignAllForSubjectResponse
+	22, // 35: api.v1alpha.LicenseService.SetLicenseSeats:output_type -> api.v1alpha.SetLicenseSeatsResponse
+	18, // 36: api.v1alpha.LicenseService.CreateLicense:output_type -> api.v1alpha.CreateLicenseResponse
+	20, // 37: api.v1alpha.LicenseService.DeleteLicense:output_type -> api.v1alpha.DeleteLicenseResponse
+	34, // 38: api.v1alpha.RelationshipService.WriteRelationship:output_type -> api.v1alpha.WriteRelationshipResponse
+	36, // 39: api.v1alpha.RelationshipService.DeleteRelationship:output_type -> api.v1alpha.DeleteRelationshipResponse
+	38, // 40: api.v1alpha.RelationshipService.ReadRelationships:output_type -> api.v1alpha.ReadRelationshipsResponse
+	24, // [24:41] is the sub-list for method output_type
+	7,  // [7:24] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_v1alpha_core_proto_init() }
@@ -755,8 +2945,68 @@ func file_v1alpha_core_proto_init() {
 				return nil
 			}
 		}
-		file_v1alpha_core_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckPermissionResponse); i {
+		file_v1alpha_core_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckPermissionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCheckPermissionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCheckPermissionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCheckPermissionResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupResourcesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupResourcesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -767,7 +3017,31 @@ func file_v1alpha_core_proto_init() {
 				return nil
 			}
 		}
-		file_v1alpha_core_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_v1alpha_core_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupSubjectsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookupSubjectsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetLicenseRequest); i {
 			case 0:
 				return &v.state
@@ -779,7 +3053,7 @@ func file_v1alpha_core_proto_init() {
 				return nil
 			}
 		}
-		file_v1alpha_core_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_v1alpha_core_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetLicenseResponse); i {
 			case 0:
 				return &v.state
@@ -791,7 +3065,7 @@ func file_v1alpha_core_proto_init() {
 				return nil
 			}
 		}
-		file_v1alpha_core_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_v1alpha_core_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ModifySeatsRequest); i {
 			case 0:
 				return &v.state
@@ -803,7 +3077,7 @@ func file_v1alpha_core_proto_init() {
 				return nil
 			}
 		}
-		file_v1alpha_core_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_v1alpha_core_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ModifySeatsResponse); i {
 			case 0:
 				return &v.state
@@ -815,7 +3089,187 @@ func file_v1alpha_core_proto_init() {
 				return nil
 			}
 		}
-		file_v1alpha_core_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_v1alpha_core_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkAssignSeatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkAssignSeatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateLicenseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateLicenseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteLicenseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteLicenseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLicenseSeatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLicenseSeatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconcileSeatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconcileSeatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnassignAllForSubjectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnassignAllForSubjectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrgSeatSummaryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrgSeatSummaryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServiceSeatUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSeatsRequest); i {
 			case 0:
 				return &v.state
@@ -827,7 +3281,7 @@ func file_v1alpha_core_proto_init() {
 				return nil
 			}
 		}
-		file_v1alpha_core_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_v1alpha_core_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSeatsResponse); i {
 			case 0:
 				return &v.state
@@ -839,7 +3293,7 @@ func file_v1alpha_core_proto_init() {
 				return nil
 			}
 		}
-		file_v1alpha_core_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_v1alpha_core_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSeatsUserRepresentation); i {
 			case 0:
 				return &v.state
@@ -851,17 +3305,101 @@ func file_v1alpha_core_proto_init() {
 				return nil
 			}
 		}
+		file_v1alpha_core_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteRelationshipRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteRelationshipResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRelationshipRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRelationshipResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadRelationshipsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadRelationshipsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1alpha_core_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Relationship); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_v1alpha_core_proto_msgTypes[6].OneofWrappers = []interface{}{}
+	file_v1alpha_core_proto_msgTypes[28].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1alpha_core_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   9,
+			NumEnums:      2,
+			NumMessages:   38,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_v1alpha_core_proto_goTypes,
 		DependencyIndexes: file_v1alpha_core_proto_depIdxs,
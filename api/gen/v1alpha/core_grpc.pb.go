@@ -23,6 +23,9 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CheckPermissionClient interface {
 	CheckPermission(ctx context.Context, in *CheckPermissionRequest, opts ...grpc.CallOption) (*CheckPermissionResponse, error)
+	BatchCheckPermission(ctx context.Context, in *BatchCheckPermissionRequest, opts ...grpc.CallOption) (*BatchCheckPermissionResponse, error)
+	LookupResources(ctx context.Context, in *LookupResourcesRequest, opts ...grpc.CallOption) (*LookupResourcesResponse, error)
+	LookupSubjects(ctx context.Context, in *LookupSubjectsRequest, opts ...grpc.CallOption) (*LookupSubjectsResponse, error)
 }
 
 type checkPermissionClient struct {
@@ -42,11 +45,41 @@ func (c *checkPermissionClient) CheckPermission(ctx context.Context, in *CheckPe
 	return out, nil
 }
 
+func (c *checkPermissionClient) BatchCheckPermission(ctx context.Context, in *BatchCheckPermissionRequest, opts ...grpc.CallOption) (*BatchCheckPermissionResponse, error) {
+	out := new(BatchCheckPermissionResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.CheckPermission/BatchCheckPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkPermissionClient) LookupResources(ctx context.Context, in *LookupResourcesRequest, opts ...grpc.CallOption) (*LookupResourcesResponse, error) {
+	out := new(LookupResourcesResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.CheckPermission/LookupResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkPermissionClient) LookupSubjects(ctx context.Context, in *LookupSubjectsRequest, opts ...grpc.CallOption) (*LookupSubjectsResponse, error) {
+	out := new(LookupSubjectsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.CheckPermission/LookupSubjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CheckPermissionServer is the server API for CheckPermission service.
 // All implementations should embed UnimplementedCheckPermissionServer
 // for forward compatibility
 type CheckPermissionServer interface {
 	CheckPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error)
+	BatchCheckPermission(context.Context, *BatchCheckPermissionRequest) (*BatchCheckPermissionResponse, error)
+	LookupResources(context.Context, *LookupResourcesRequest) (*LookupResourcesResponse, error)
+	LookupSubjects(context.Context, *LookupSubjectsRequest) (*LookupSubjectsResponse, error)
 }
 
 // UnimplementedCheckPermissionServer should be embedded to have forward compatible implementations.
@@ -56,6 +89,15 @@ type UnimplementedCheckPermissionServer struct {
 func (UnimplementedCheckPermissionServer) CheckPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckPermission not implemented")
 }
+func (UnimplementedCheckPermissionServer) BatchCheckPermission(context.Context, *BatchCheckPermissionRequest) (*BatchCheckPermissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCheckPermission not implemented")
+}
+func (UnimplementedCheckPermissionServer) LookupResources(context.Context, *LookupResourcesRequest) (*LookupResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupResources not implemented")
+}
+func (UnimplementedCheckPermissionServer) LookupSubjects(context.Context, *LookupSubjectsRequest) (*LookupSubjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupSubjects not implemented")
+}
 
 // UnsafeCheckPermissionServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to CheckPermissionServer will
@@ -86,6 +128,60 @@ func _CheckPermission_CheckPermission_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CheckPermission_BatchCheckPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCheckPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckPermissionServer).BatchCheckPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.CheckPermission/BatchCheckPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckPermissionServer).BatchCheckPermission(ctx, req.(*BatchCheckPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckPermission_LookupResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckPermissionServer).LookupResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.CheckPermission/LookupResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckPermissionServer).LookupResources(ctx, req.(*LookupResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckPermission_LookupSubjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupSubjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckPermissionServer).LookupSubjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.CheckPermission/LookupSubjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckPermissionServer).LookupSubjects(ctx, req.(*LookupSubjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CheckPermission_ServiceDesc is the grpc.ServiceDesc for CheckPermission service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -97,6 +193,18 @@ var CheckPermission_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckPermission",
 			Handler:    _CheckPermission_CheckPermission_Handler,
 		},
+		{
+			MethodName: "BatchCheckPermission",
+			Handler:    _CheckPermission_BatchCheckPermission_Handler,
+		},
+		{
+			MethodName: "LookupResources",
+			Handler:    _CheckPermission_LookupResources_Handler,
+		},
+		{
+			MethodName: "LookupSubjects",
+			Handler:    _CheckPermission_LookupSubjects_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1alpha/core.proto",
@@ -109,6 +217,13 @@ type LicenseServiceClient interface {
 	GetLicense(ctx context.Context, in *GetLicenseRequest, opts ...grpc.CallOption) (*GetLicenseResponse, error)
 	ModifySeats(ctx context.Context, in *ModifySeatsRequest, opts ...grpc.CallOption) (*ModifySeatsResponse, error)
 	GetSeats(ctx context.Context, in *GetSeatsRequest, opts ...grpc.CallOption) (*GetSeatsResponse, error)
+	GetOrgSeatSummary(ctx context.Context, in *GetOrgSeatSummaryRequest, opts ...grpc.CallOption) (*GetOrgSeatSummaryResponse, error)
+	BulkAssignSeats(ctx context.Context, opts ...grpc.CallOption) (LicenseService_BulkAssignSeatsClient, error)
+	ReconcileSeats(ctx context.Context, in *ReconcileSeatsRequest, opts ...grpc.CallOption) (*ReconcileSeatsResponse, error)
+	UnassignAllForSubject(ctx context.Context, in *UnassignAllForSubjectRequest, opts ...grpc.CallOption) (*UnassignAllForSubjectResponse, error)
+	SetLicenseSeats(ctx context.Context, in *SetLicenseSeatsRequest, opts ...grpc.CallOption) (*SetLicenseSeatsResponse, error)
+	CreateLicense(ctx context.Context, in *CreateLicenseRequest, opts ...grpc.CallOption) (*CreateLicenseResponse, error)
+	DeleteLicense(ctx context.Context, in *DeleteLicenseRequest, opts ...grpc.CallOption) (*DeleteLicenseResponse, error)
 }
 
 type licenseServiceClient struct {
@@ -146,6 +261,94 @@ func (c *licenseServiceClient) GetSeats(ctx context.Context, in *GetSeatsRequest
 	return out, nil
 }
 
+func (c *licenseServiceClient) GetOrgSeatSummary(ctx context.Context, in *GetOrgSeatSummaryRequest, opts ...grpc.CallOption) (*GetOrgSeatSummaryResponse, error) {
+	out := new(GetOrgSeatSummaryResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.LicenseService/GetOrgSeatSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licenseServiceClient) BulkAssignSeats(ctx context.Context, opts ...grpc.CallOption) (LicenseService_BulkAssignSeatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LicenseService_ServiceDesc.Streams[0], "/api.v1alpha.LicenseService/BulkAssignSeats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &licenseServiceBulkAssignSeatsClient{stream}
+	return x, nil
+}
+
+type LicenseService_BulkAssignSeatsClient interface {
+	Send(*BulkAssignSeatsRequest) error
+	CloseAndRecv() (*BulkAssignSeatsResponse, error)
+	grpc.ClientStream
+}
+
+type licenseServiceBulkAssignSeatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *licenseServiceBulkAssignSeatsClient) Send(m *BulkAssignSeatsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *licenseServiceBulkAssignSeatsClient) CloseAndRecv() (*BulkAssignSeatsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BulkAssignSeatsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *licenseServiceClient) ReconcileSeats(ctx context.Context, in *ReconcileSeatsRequest, opts ...grpc.CallOption) (*ReconcileSeatsResponse, error) {
+	out := new(ReconcileSeatsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.LicenseService/ReconcileSeats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licenseServiceClient) UnassignAllForSubject(ctx context.Context, in *UnassignAllForSubjectRequest, opts ...grpc.CallOption) (*UnassignAllForSubjectResponse, error) {
+	out := new(UnassignAllForSubjectResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.LicenseService/UnassignAllForSubject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licenseServiceClient) SetLicenseSeats(ctx context.Context, in *SetLicenseSeatsRequest, opts ...grpc.CallOption) (*SetLicenseSeatsResponse, error) {
+	out := new(SetLicenseSeatsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.LicenseService/SetLicenseSeats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licenseServiceClient) CreateLicense(ctx context.Context, in *CreateLicenseRequest, opts ...grpc.CallOption) (*CreateLicenseResponse, error) {
+	out := new(CreateLicenseResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.LicenseService/CreateLicense", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *licenseServiceClient) DeleteLicense(ctx context.Context, in *DeleteLicenseRequest, opts ...grpc.CallOption) (*DeleteLicenseResponse, error) {
+	out := new(DeleteLicenseResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.LicenseService/DeleteLicense", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LicenseServiceServer is the server API for LicenseService service.
 // All implementations should embed UnimplementedLicenseServiceServer
 // for forward compatibility
@@ -153,6 +356,13 @@ type LicenseServiceServer interface {
 	GetLicense(context.Context, *GetLicenseRequest) (*GetLicenseResponse, error)
 	ModifySeats(context.Context, *ModifySeatsRequest) (*ModifySeatsResponse, error)
 	GetSeats(context.Context, *GetSeatsRequest) (*GetSeatsResponse, error)
+	GetOrgSeatSummary(context.Context, *GetOrgSeatSummaryRequest) (*GetOrgSeatSummaryResponse, error)
+	BulkAssignSeats(LicenseService_BulkAssignSeatsServer) error
+	ReconcileSeats(context.Context, *ReconcileSeatsRequest) (*ReconcileSeatsResponse, error)
+	UnassignAllForSubject(context.Context, *UnassignAllForSubjectRequest) (*UnassignAllForSubjectResponse, error)
+	SetLicenseSeats(context.Context, *SetLicenseSeatsRequest) (*SetLicenseSeatsResponse, error)
+	CreateLicense(context.Context, *CreateLicenseRequest) (*CreateLicenseResponse, error)
+	DeleteLicense(context.Context, *DeleteLicenseRequest) (*DeleteLicenseResponse, error)
 }
 
 // UnimplementedLicenseServiceServer should be embedded to have forward compatible implementations.
@@ -168,6 +378,27 @@ func (UnimplementedLicenseServiceServer) ModifySeats(context.Context, *ModifySea
 func (UnimplementedLicenseServiceServer) GetSeats(context.Context, *GetSeatsRequest) (*GetSeatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSeats not implemented")
 }
+func (UnimplementedLicenseServiceServer) GetOrgSeatSummary(context.Context, *GetOrgSeatSummaryRequest) (*GetOrgSeatSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrgSeatSummary not implemented")
+}
+func (UnimplementedLicenseServiceServer) BulkAssignSeats(LicenseService_BulkAssignSeatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkAssignSeats not implemented")
+}
+func (UnimplementedLicenseServiceServer) ReconcileSeats(context.Context, *ReconcileSeatsRequest) (*ReconcileSeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileSeats not implemented")
+}
+func (UnimplementedLicenseServiceServer) UnassignAllForSubject(context.Context, *UnassignAllForSubjectRequest) (*UnassignAllForSubjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnassignAllForSubject not implemented")
+}
+func (UnimplementedLicenseServiceServer) SetLicenseSeats(context.Context, *SetLicenseSeatsRequest) (*SetLicenseSeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLicenseSeats not implemented")
+}
+func (UnimplementedLicenseServiceServer) CreateLicense(context.Context, *CreateLicenseRequest) (*CreateLicenseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLicense not implemented")
+}
+func (UnimplementedLicenseServiceServer) DeleteLicense(context.Context, *DeleteLicenseRequest) (*DeleteLicenseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLicense not implemented")
+}
 
 // UnsafeLicenseServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to LicenseServiceServer will
@@ -234,6 +465,140 @@ func _LicenseService_GetSeats_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LicenseService_GetOrgSeatSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrgSeatSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicenseServiceServer).GetOrgSeatSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.LicenseService/GetOrgSeatSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicenseServiceServer).GetOrgSeatSummary(ctx, req.(*GetOrgSeatSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicenseService_BulkAssignSeats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LicenseServiceServer).BulkAssignSeats(&licenseServiceBulkAssignSeatsServer{stream})
+}
+
+type LicenseService_BulkAssignSeatsServer interface {
+	SendAndClose(*BulkAssignSeatsResponse) error
+	Recv() (*BulkAssignSeatsRequest, error)
+	grpc.ServerStream
+}
+
+type licenseServiceBulkAssignSeatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *licenseServiceBulkAssignSeatsServer) SendAndClose(m *BulkAssignSeatsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *licenseServiceBulkAssignSeatsServer) Recv() (*BulkAssignSeatsRequest, error) {
+	m := new(BulkAssignSeatsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _LicenseService_ReconcileSeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileSeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicenseServiceServer).ReconcileSeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.LicenseService/ReconcileSeats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicenseServiceServer).ReconcileSeats(ctx, req.(*ReconcileSeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicenseService_UnassignAllForSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignAllForSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicenseServiceServer).UnassignAllForSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.LicenseService/UnassignAllForSubject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicenseServiceServer).UnassignAllForSubject(ctx, req.(*UnassignAllForSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicenseService_SetLicenseSeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLicenseSeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicenseServiceServer).SetLicenseSeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.LicenseService/SetLicenseSeats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicenseServiceServer).SetLicenseSeats(ctx, req.(*SetLicenseSeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicenseService_CreateLicense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLicenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicenseServiceServer).CreateLicense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.LicenseService/CreateLicense",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicenseServiceServer).CreateLicense(ctx, req.(*CreateLicenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LicenseService_DeleteLicense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLicenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LicenseServiceServer).DeleteLicense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.LicenseService/DeleteLicense",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LicenseServiceServer).DeleteLicense(ctx, req.(*DeleteLicenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LicenseService_ServiceDesc is the grpc.ServiceDesc for LicenseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -253,6 +618,192 @@ var LicenseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSeats",
 			Handler:    _LicenseService_GetSeats_Handler,
 		},
+		{
+			MethodName: "GetOrgSeatSummary",
+			Handler:    _LicenseService_GetOrgSeatSummary_Handler,
+		},
+		{
+			MethodName: "ReconcileSeats",
+			Handler:    _LicenseService_ReconcileSeats_Handler,
+		},
+		{
+			MethodName: "UnassignAllForSubject",
+			Handler:    _LicenseService_UnassignAllForSubject_Handler,
+		},
+		{
+			MethodName: "SetLicenseSeats",
+			Handler:    _LicenseService_SetLicenseSeats_Handler,
+		},
+		{
+			MethodName: "CreateLicense",
+			Handler:    _LicenseService_CreateLicense_Handler,
+		},
+		{
+			MethodName: "DeleteLicense",
+			Handler:    _LicenseService_DeleteLicense_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BulkAssignSeats",
+			Handler:       _LicenseService_BulkAssignSeats_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "v1alpha/core.proto",
+}
+
+// RelationshipServiceClient is the client API for RelationshipService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RelationshipServiceClient interface {
+	WriteRelationship(ctx context.Context, in *WriteRelationshipRequest, opts ...grpc.CallOption) (*WriteRelationshipResponse, error)
+	DeleteRelationship(ctx context.Context, in *DeleteRelationshipRequest, opts ...grpc.CallOption) (*DeleteRelationshipResponse, error)
+	ReadRelationships(ctx context.Context, in *ReadRelationshipsRequest, opts ...grpc.CallOption) (*ReadRelationshipsResponse, error)
+}
+
+type relationshipServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRelationshipServiceClient(cc grpc.ClientConnInterface) RelationshipServiceClient {
+	return &relationshipServiceClient{cc}
+}
+
+func (c *relationshipServiceClient) WriteRelationship(ctx context.Context, in *WriteRelationshipRequest, opts ...grpc.CallOption) (*WriteRelationshipResponse, error) {
+	out := new(WriteRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.RelationshipService/WriteRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) DeleteRelationship(ctx context.Context, in *DeleteRelationshipRequest, opts ...grpc.CallOption) (*DeleteRelationshipResponse, error) {
+	out := new(DeleteRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.RelationshipService/DeleteRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) ReadRelationships(ctx context.Context, in *ReadRelationshipsRequest, opts ...grpc.CallOption) (*ReadRelationshipsResponse, error) {
+	out := new(ReadRelationshipsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1alpha.RelationshipService/ReadRelationships", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RelationshipServiceServer is the server API for RelationshipService service.
+// All implementations should embed UnimplementedRelationshipServiceServer
+// for forward compatibility
+type RelationshipServiceServer interface {
+	WriteRelationship(context.Context, *WriteRelationshipRequest) (*WriteRelationshipResponse, error)
+	DeleteRelationship(context.Context, *DeleteRelationshipRequest) (*DeleteRelationshipResponse, error)
+	ReadRelationships(context.Context, *ReadRelationshipsRequest) (*ReadRelationshipsResponse, error)
+}
+
+// UnimplementedRelationshipServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedRelationshipServiceServer struct {
+}
+
+func (UnimplementedRelationshipServiceServer) WriteRelationship(context.Context, *WriteRelationshipRequest) (*WriteRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteRelationship not implemented")
+}
+func (UnimplementedRelationshipServiceServer) DeleteRelationship(context.Context, *DeleteRelationshipRequest) (*DeleteRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRelationship not implemented")
+}
+func (UnimplementedRelationshipServiceServer) ReadRelationships(context.Context, *ReadRelationshipsRequest) (*ReadRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadRelationships not implemented")
+}
+
+// UnsafeRelationshipServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RelationshipServiceServer will
+// result in compilation errors.
+type UnsafeRelationshipServiceServer interface {
+	mustEmbedUnimplementedRelationshipServiceServer()
+}
+
+func RegisterRelationshipServiceServer(s grpc.ServiceRegistrar, srv RelationshipServiceServer) {
+	s.RegisterService(&RelationshipService_ServiceDesc, srv)
+}
+
+func _RelationshipService_WriteRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).WriteRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.RelationshipService/WriteRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).WriteRelationship(ctx, req.(*WriteRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_DeleteRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).DeleteRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.RelationshipService/DeleteRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).DeleteRelationship(ctx, req.(*DeleteRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_ReadRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).ReadRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1alpha.RelationshipService/ReadRelationships",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).ReadRelationships(ctx, req.(*ReadRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RelationshipService_ServiceDesc is the grpc.ServiceDesc for RelationshipService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RelationshipService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.v1alpha.RelationshipService",
+	HandlerType: (*RelationshipServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WriteRelationship",
+			Handler:    _RelationshipService_WriteRelationship_Handler,
+		},
+		{
+			MethodName: "DeleteRelationship",
+			Handler:    _RelationshipService_DeleteRelationship_Handler,
+		},
+		{
+			MethodName: "ReadRelationships",
+			Handler:    _RelationshipService_ReadRelationships_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1alpha/core.proto",
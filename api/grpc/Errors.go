@@ -0,0 +1,142 @@
+package grpc
+
+import (
+	"authz/domain"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/glog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo details attached to errors of this service
+const ErrorDomain = "authz"
+
+// Stable reasons of the google.rpc.ErrorInfo details attached to errors, for clients to react to programmatically
+const (
+	ReasonNotAuthenticated     = "NOT_AUTHENTICATED"
+	ReasonNotAuthorized        = "NOT_AUTHORIZED"
+	ReasonInvalidArgument      = "INVALID_ARGUMENT"
+	ReasonInvalidRequest       = "INVALID_REQUEST"
+	ReasonPrincipalNotFound    = "PRINCIPAL_NOT_FOUND"
+	ReasonLicenseNotFound      = "LICENSE_NOT_FOUND"
+	ReasonLicenseAlreadyExists = "LICENSE_ALREADY_EXISTS"
+	ReasonSubjectNotMember     = "SUBJECT_NOT_MEMBER"
+	ReasonSeatLimitExceeded    = "SEAT_LIMIT_EXCEEDED"
+	ReasonSeatLimitBelowInUse  = "SEAT_LIMIT_BELOW_IN_USE"
+	ReasonRelationNotAllowed   = "RELATIONSHIP_NOT_ALLOWED"
+	ReasonNotSupported         = "NOT_SUPPORTED"
+	ReasonResourceNotFound     = "RESOURCE_NOT_FOUND"
+	ReasonInvalidResourceID    = "INVALID_RESOURCE_ID"
+	ReasonSchemaNotInitialized = "SCHEMA_NOT_INITIALIZED"
+	ReasonCheckTooComplex      = "CHECK_TOO_COMPLEX"
+	ReasonTooManyResults       = "TOO_MANY_RESULTS"
+	ReasonTooManySeatChanges   = "TOO_MANY_SEAT_CHANGES"
+	ReasonDeadlineExceeded     = "DEADLINE_EXCEEDED"
+	ReasonCancelled            = "CANCELLED"
+	ReasonInternal             = "INTERNAL"
+)
+
+// errRelationshipsNotSupported is returned by the RelationshipService RPCs if the server has no RelationshipAppService
+var errRelationshipsNotSupported = newErrorWithDetails(codes.Unimplemented, "Relationships cannot be managed with the configured store.", ReasonNotSupported, nil)
+
+func convertDomainErrorToGrpc(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotAuthenticated):
+		return newErrorWithDetails(codes.Unauthenticated, "Anonymous access is not allowed.", ReasonNotAuthenticated, nil)
+	case errors.Is(err, domain.ErrNotAuthorized):
+		return newErrorWithDetails(codes.PermissionDenied, "Access denied.", ReasonNotAuthorized, nil)
+	case errors.Is(err, domain.ErrRelationshipNotAllowed):
+		return newErrorWithDetails(codes.PermissionDenied, err.Error(), ReasonRelationNotAllowed, nil)
+	case errors.Is(err, domain.ErrInvalidResourceID):
+		return newErrorWithDetails(codes.InvalidArgument, err.Error(), ReasonInvalidResourceID, nil,
+			&errdetails.BadRequest_FieldViolation{Field: "resourceid", Description: err.Error()})
+	case errors.Is(err, domain.ErrPrincipalNotFound):
+		return newErrorWithDetails(codes.NotFound, err.Error(), ReasonPrincipalNotFound, nil)
+	case errors.Is(err, domain.ErrLicenseNotFound):
+		return newErrorWithDetails(codes.NotFound, err.Error(), ReasonLicenseNotFound, nil)
+	case errors.Is(err, domain.ErrLicenseAlreadyExists):
+		return newErrorWithDetails(codes.AlreadyExists, err.Error(), ReasonLicenseAlreadyExists, nil)
+	case errors.Is(err, domain.ErrResourceNotFound):
+		return newErrorWithDetails(codes.NotFound, err.Error(), ReasonResourceNotFound, nil)
+	case errors.Is(err, domain.ErrSubjectNotMember):
+		var notMember *domain.NotMemberError
+		var violations []*errdetails.BadRequest_FieldViolation
+		if errors.As(err, &notMember) {
+			for _, id := range notMember.SubjectIDs {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "assign", Description: fmt.Sprintf("%s is not a member of organization %s.", id, notMember.OrgID)})
+			}
+		}
+		return newErrorWithDetails(codes.InvalidArgument, err.Error(), ReasonSubjectNotMember, nil, violations...)
+	case errors.Is(err, domain.ErrSeatLimitExceeded):
+		var exceeded *domain.SeatLimitExceededError
+		var metadata map[string]string
+		if errors.As(err, &exceeded) {
+			metadata = map[string]string{"maxSeats": strconv.Itoa(exceeded.MaxSeats), "inUse": strconv.Itoa(exceeded.InUse)}
+		}
+		return newErrorWithDetails(codes.FailedPrecondition, err.Error(), ReasonSeatLimitExceeded, metadata)
+	case errors.Is(err, domain.ErrSeatLimitBelowInUse):
+		var belowInUse *domain.SeatLimitBelowInUseError
+		var metadata map[string]string
+		if errors.As(err, &belowInUse) {
+			metadata = map[string]string{"maxSeats": strconv.Itoa(belowInUse.MaxSeats), "inUse": strconv.Itoa(belowInUse.InUse)}
+		}
+		return newErrorWithDetails(codes.FailedPrecondition, err.Error(), ReasonSeatLimitBelowInUse, metadata)
+	case errors.Is(err, domain.ErrInvalidRequest):
+		return newErrorWithDetails(codes.InvalidArgument, err.Error(), ReasonInvalidRequest, nil)
+	case errors.Is(err, domain.ErrCheckTooComplex):
+		return newErrorWithDetails(codes.ResourceExhausted, "The permission check is too complex to evaluate, the permission graph is too deep.", ReasonCheckTooComplex, nil)
+	case errors.Is(err, domain.ErrTooManyResults):
+		return newErrorWithDetails(codes.ResourceExhausted, err.Error(), ReasonTooManyResults, nil)
+	case errors.Is(err, domain.ErrSchemaNotInitialized):
+		glog.Errorf("Authorization store schema is not initialized: %s", err)
+		return newErrorWithDetails(codes.FailedPrecondition, "Authorization schema is not initialized.", ReasonSchemaNotInitialized, nil)
+	case errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded:
+		return newErrorWithDetails(codes.DeadlineExceeded, "Request deadline exceeded.", ReasonDeadlineExceeded, nil)
+	case errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled:
+		return newErrorWithDetails(codes.Canceled, "Request cancelled.", ReasonCancelled, nil)
+	default:
+		return newErrorWithDetails(codes.Unknown, "Internal server error.", ReasonInternal, nil)
+	}
+}
+
+// errorReason returns the reason of the google.rpc.ErrorInfo detail the domain error is converted to, ex: for metrics
+func errorReason(err error) string {
+	for _, detail := range status.Convert(convertDomainErrorToGrpc(err)).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ReasonInternal
+}
+
+// newBadRequestError creates an InvalidArgument error for an invalid request field
+func newBadRequestError(field string, message string) error {
+	return newErrorWithDetails(codes.InvalidArgument, message, ReasonInvalidArgument, map[string]string{"field": field},
+		&errdetails.BadRequest_FieldViolation{Field: field, Description: message})
+}
+
+// newErrorWithDetails creates a grpc error with the given code and message and a google.rpc.ErrorInfo detail with the given reason and metadata.
+// If field violations are given, a google.rpc.BadRequest detail is attached too.
+func newErrorWithDetails(code codes.Code, message string, reason string, metadata map[string]string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(code, message)
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain, Metadata: metadata}
+
+	var detailed *status.Status
+	var err error
+	if len(violations) > 0 {
+		detailed, err = st.WithDetails(info, &errdetails.BadRequest{FieldViolations: violations})
+	} else {
+		detailed, err = st.WithDetails(info)
+	}
+
+	if err != nil {
+		glog.Errorf("Failed to attach error details: %s", err)
+		return st.Err()
+	}
+	return detailed.Err()
+}
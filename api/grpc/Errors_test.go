@@ -0,0 +1,170 @@
+package grpc
+
+import (
+	"authz/api"
+	core "authz/api/gen/v1alpha"
+	"authz/domain"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConvertDomainErrorToGrpcMapsMissingSchemaToFailedPrecondition(t *testing.T) {
+	err := convertDomainErrorToGrpc(fmt.Errorf("%w: object definition `license` not found", domain.ErrSchemaNotInitialized))
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok, "Should have been a grpc status error.")
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Equal(t, ReasonSchemaNotInitialized, getErrorInfo(t, st).Reason)
+}
+
+func TestConvertDomainErrorToGrpcMapsMissingResourceToNotFound(t *testing.T) {
+	err := convertDomainErrorToGrpc(fmt.Errorf("%w: license:o1/doesnotexist", domain.ErrResourceNotFound))
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, ReasonResourceNotFound, getErrorInfo(t, st).Reason)
+}
+
+func TestConvertDomainErrorToGrpcMapsTooComplexCheckToResourceExhausted(t *testing.T) {
+	err := convertDomainErrorToGrpc(fmt.Errorf("%w: max depth exceeded", domain.ErrCheckTooComplex))
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, ReasonCheckTooComplex, getErrorInfo(t, st).Reason)
+}
+
+func TestConvertDomainErrorToGrpcListsNonMembers(t *testing.T) {
+	err := convertDomainErrorToGrpc(&domain.NotMemberError{OrgID: "o1", SubjectIDs: []domain.SubjectID{"u1", "u2"}})
+
+	st := status.FromProto(status.Convert(err).Proto())
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, ReasonSubjectNotMember, getErrorInfo(t, st).Reason)
+	assert.Len(t, getBadRequest(t, st).FieldViolations, 2)
+}
+
+func TestConvertDomainErrorToGrpcMapsSeatLimitToFailedPrecondition(t *testing.T) {
+	err := convertDomainErrorToGrpc(fmt.Errorf("assigning: %w", &domain.SeatLimitExceededError{LicenseID: "o1/smarts", MaxSeats: 10, InUse: 10, Requested: 1}))
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Equal(t, "assigning: SeatLimitExceeded: 10 of 10 seats of license o1/smarts are in use, 1 more cannot be assigned", st.Message())
+	info := getErrorInfo(t, st)
+	assert.Equal(t, ReasonSeatLimitExceeded, info.Reason)
+	assert.Equal(t, map[string]string{"maxSeats": "10", "inUse": "10"}, info.Metadata)
+}
+
+func TestConvertDomainErrorToGrpcMapsSeatLimitBelowInUseWithSeats(t *testing.T) {
+	err := convertDomainErrorToGrpc(&domain.SeatLimitBelowInUseError{LicenseID: "o1/smarts", MaxSeats: 5, InUse: 7})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	info := getErrorInfo(t, st)
+	assert.Equal(t, ReasonSeatLimitBelowInUse, info.Reason)
+	assert.Equal(t, map[string]string{"maxSeats": "5", "inUse": "7"}, info.Metadata)
+}
+
+func TestConvertDomainErrorToGrpcMapsUntypedSeatLimitWithoutMetadata(t *testing.T) {
+	err := convertDomainErrorToGrpc(fmt.Errorf("%w: o1/smarts", domain.ErrSeatLimitExceeded))
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Empty(t, getErrorInfo(t, st).Metadata)
+}
+
+func TestConvertDomainErrorToGrpcMapsTooManyResultsToResourceExhausted(t *testing.T) {
+	err := convertDomainErrorToGrpc(fmt.Errorf("%w: subject u1 can access more than 10000 resources of type license", domain.ErrTooManyResults))
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, ReasonTooManyResults, getErrorInfo(t, st).Reason)
+}
+
+func TestConvertDomainErrorToGrpcMapsExistingLicenseToAlreadyExists(t *testing.T) {
+	err := convertDomainErrorToGrpc(fmt.Errorf("%w: o1/smarts", domain.ErrLicenseAlreadyExists))
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	assert.Equal(t, ReasonLicenseAlreadyExists, getErrorInfo(t, st).Reason)
+}
+
+func TestConvertDomainErrorToGrpcKeepsDeadlineExceeded(t *testing.T) {
+	fromStore := convertDomainErrorToGrpc(status.Error(codes.DeadlineExceeded, "context deadline exceeded"))
+	fromContext := convertDomainErrorToGrpc(fmt.Errorf("reading license: %w", context.DeadlineExceeded))
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(fromStore))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(fromContext))
+	assert.Equal(t, codes.Canceled, status.Code(convertDomainErrorToGrpc(context.Canceled)))
+}
+
+func TestConvertDomainErrorToGrpcDetailsSurviveRoundTrip(t *testing.T) {
+	err := convertDomainErrorToGrpc(domain.ErrNotAuthorized)
+
+	st := status.FromProto(status.Convert(err).Proto())
+
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	assert.Equal(t, "Access denied.", st.Message())
+	info := getErrorInfo(t, st)
+	assert.Equal(t, ReasonNotAuthorized, info.Reason)
+	assert.Equal(t, ErrorDomain, info.Domain)
+}
+
+func TestValidationErrorsCarryFieldViolations(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Subject:      "okay",
+		Resourcetype: "service",
+		Resourceid:   "smarts",
+	})
+
+	st := status.FromProto(status.Convert(err).Proto())
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "operation", getErrorInfo(t, st).Metadata["field"])
+	badRequest := getBadRequest(t, st)
+	if assert.Len(t, badRequest.FieldViolations, 1) {
+		assert.Equal(t, "operation", badRequest.FieldViolations[0].Field)
+	}
+}
+
+func TestTooManySeatChangesCarriesLimit(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{MaxSeatChanges: 1})
+
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+		Assign:    []string{"okay", "bad"},
+	})
+
+	st := status.FromProto(status.Convert(err).Proto())
+	info := getErrorInfo(t, st)
+	assert.Equal(t, ReasonTooManySeatChanges, info.Reason)
+	assert.Equal(t, "1", info.Metadata["limit"])
+}
+
+func getErrorInfo(t *testing.T, st *status.Status) *errdetails.ErrorInfo {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatal("No ErrorInfo detail found.")
+	return nil
+}
+
+func getBadRequest(t *testing.T, st *status.Status) *errdetails.BadRequest {
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			return badRequest
+		}
+	}
+	t.Fatal("No BadRequest detail found.")
+	return nil
+}
@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"authz/api"
+	"authz/domain"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+// IdentityExtractor extracts the principal ID from the value of an identity header
+type IdentityExtractor func(value string) (string, error)
+
+// BearerStrategy introspects a bearer token to find the principal ID. Until tokens are validated, bearer tokens are only accepted with insecure development auth.
+const BearerStrategy = "bearer"
+
+// RHIdentityStrategy decodes a base64 encoded x-rh-identity JSON document to find the principal ID
+const RHIdentityStrategy = "x-rh-identity"
+
+// DefaultIdentitySources are used if no identity sources are configured. The x-rh-identity header, set by the gateway in front of the service, comes first,
+// as bearer tokens are only accepted with insecure development auth.
+var DefaultIdentitySources = []api.IdentitySource{
+	{Header: "x-rh-identity", Strategy: RHIdentityStrategy},
+	{Header: "grpcgateway-authorization", Strategy: BearerStrategy},
+	{Header: "bearer-token", Strategy: BearerStrategy},
+}
+
+// DefaultIdentityExtractors are the built-in extraction strategies, keyed by strategy name
+var DefaultIdentityExtractors = map[string]IdentityExtractor{
+	BearerStrategy:     rejectUnvalidatedToken,
+	RHIdentityStrategy: decodeRHIdentity,
+}
+
+// ParseIdentitySources parses identity sources given as <header>=<strategy>, ex: x-rh-identity=x-rh-identity, in order. Only the built-in strategies are accepted.
+func ParseIdentitySources(values []string) ([]api.IdentitySource, error) {
+	sources := make([]api.IdentitySource, 0, len(values))
+	for _, value := range values {
+		header, strategy, ok := strings.Cut(value, "=")
+		header, strategy = strings.ToLower(strings.TrimSpace(header)), strings.TrimSpace(strategy)
+		if !ok || header == "" {
+			return nil, fmt.Errorf("identity source %q is not of the form <header>=<strategy>", value)
+		}
+		if _, ok := DefaultIdentityExtractors[strategy]; !ok {
+			return nil, fmt.Errorf("identity source %q has unknown strategy %q, expected %s or %s", value, strategy, BearerStrategy, RHIdentityStrategy)
+		}
+		sources = append(sources, api.IdentitySource{Header: header, Strategy: strategy})
+	}
+
+	return sources, nil
+}
+
+func convertTokenToPrincipalID(token string) (string, error) {
+	return token, nil //Placeholder for token introspection
+}
+
+func rejectUnvalidatedToken(_ string) (string, error) {
+	return "", fmt.Errorf("%w: bearer tokens cannot be validated yet, they are only accepted with insecure development auth", domain.ErrNotAuthenticated)
+}
+
+// checkInsecureDevAuth returns an error if insecure development auth is enabled together with TLS, which only production deployments use, and warns about it otherwise
+func checkInsecureDevAuth(c *api.ServerConfig) error {
+	if !c.InsecureDevAuth {
+		return nil
+	}
+
+	if c.StoreConfig.UseTLS || fileExists(c.TLSConfig.CertPath) && fileExists(c.TLSConfig.KeyPath) {
+		return errors.New("insecure development auth must not be enabled when TLS is configured")
+	}
+
+	glog.Warning("INSECURE: development auth is enabled, bearer tokens are trusted as principal IDs without validation. Never use this in production!")
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func decodeRHIdentity(value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: x-rh-identity is not base64 encoded: %v", domain.ErrNotAuthenticated, err)
+	}
+
+	identity := struct {
+		Identity struct {
+			User struct {
+				UserID string `json:"user_id"`
+			} `json:"user"`
+		} `json:"identity"`
+	}{}
+	if err := json.Unmarshal(decoded, &identity); err != nil {
+		return "", fmt.Errorf("%w: x-rh-identity is not valid JSON: %v", domain.ErrNotAuthenticated, err)
+	}
+
+	if identity.Identity.User.UserID == "" {
+		return "", fmt.Errorf("%w: x-rh-identity contains no user_id", domain.ErrNotAuthenticated)
+	}
+
+	return identity.Identity.User.UserID, nil
+}
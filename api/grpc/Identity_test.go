@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"authz/api"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestIdentityFallsBackToBearerTokenHeader(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"bearer-token": "okay",
+	}))
+
+	requestor, err := srv.getRequestorIdentityFromGrpcContext(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "okay", requestor)
+}
+
+func TestIdentityRejectsBearerTokenWithoutInsecureDevAuth(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.ServerConfig.InsecureDevAuth = false
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"bearer-token": "okay",
+	}))
+
+	_, err := srv.getRequestorIdentityFromGrpcContext(ctx)
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestInsecureDevAuthIsRefusedWithTLS(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cert, key := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	assert.NoError(t, os.WriteFile(cert, []byte("cert"), 0600))
+	assert.NoError(t, os.WriteFile(key, []byte("key"), 0600))
+
+	assert.NoError(t, checkInsecureDevAuth(&api.ServerConfig{InsecureDevAuth: true}))
+	assert.NoError(t, checkInsecureDevAuth(&api.ServerConfig{TLSConfig: api.TLSConfig{CertPath: cert, KeyPath: key}}))
+	assert.Error(t, checkInsecureDevAuth(&api.ServerConfig{InsecureDevAuth: true, TLSConfig: api.TLSConfig{CertPath: cert, KeyPath: key}}))
+	assert.Error(t, checkInsecureDevAuth(&api.ServerConfig{InsecureDevAuth: true, StoreConfig: api.StoreConfig{UseTLS: true}}))
+}
+
+func TestIdentityUsesFirstConfiguredSourcePresent(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{IdentitySources: []api.IdentitySource{
+		{Header: "x-rh-identity", Strategy: RHIdentityStrategy},
+		{Header: "grpcgateway-authorization", Strategy: BearerStrategy},
+	}})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"x-rh-identity":             encodeRHIdentity(`{"identity":{"user":{"user_id":"okay"}}}`),
+		"grpcgateway-authorization": "system",
+	}))
+
+	requestor, err := srv.getRequestorIdentityFromGrpcContext(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "okay", requestor)
+}
+
+func TestIdentityRejectsMalformedRHIdentity(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{IdentitySources: []api.IdentitySource{
+		{Header: "x-rh-identity", Strategy: RHIdentityStrategy},
+	}})
+
+	for _, value := range []string{"not base64!", encodeRHIdentity("not json"), encodeRHIdentity(`{"identity":{}}`)} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+			"x-rh-identity": value,
+		}))
+
+		_, err := srv.getRequestorIdentityFromGrpcContext(ctx)
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok, "Should have been a grpc status error.")
+		assert.Equal(t, codes.Unauthenticated, st.Code(), "Unexpected code for %s", value)
+	}
+}
+
+func TestIdentityUsesCustomExtractor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{IdentitySources: []api.IdentitySource{
+		{Header: "x-custom", Strategy: "custom"},
+	}})
+	srv.IdentityExtractors = map[string]IdentityExtractor{
+		"custom": func(value string) (string, error) { return "custom-" + value, nil },
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"x-custom": "okay",
+	}))
+
+	requestor, err := srv.getRequestorIdentityFromGrpcContext(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "custom-okay", requestor)
+}
+
+func encodeRHIdentity(json string) string {
+	return base64.StdEncoding.EncodeToString([]byte(json))
+}
+
+func TestParseIdentitySources(t *testing.T) {
+	t.Parallel()
+
+	sources, err := ParseIdentitySources([]string{"X-RH-Identity=x-rh-identity", " bearer-token = bearer "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.IdentitySource{
+		{Header: "x-rh-identity", Strategy: RHIdentityStrategy},
+		{Header: "bearer-token", Strategy: BearerStrategy},
+	}, sources)
+}
+
+func TestParseIdentitySourcesRejectsInvalidSources(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"x-rh-identity", "=bearer", "x-custom=custom"} {
+		_, err := ParseIdentitySources([]string{value})
+
+		assert.Error(t, err, "Should have rejected %s", value)
+	}
+}
+
+func TestDefaultIdentitySourcesAcceptRHIdentityWithoutInsecureDevAuth(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.ServerConfig.InsecureDevAuth = false
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"x-rh-identity":             encodeRHIdentity(`{"identity":{"user":{"user_id":"okay"}}}`),
+		"grpcgateway-authorization": "Bearer token",
+	}))
+
+	requestor, err := srv.getRequestorIdentityFromGrpcContext(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "okay", requestor)
+}
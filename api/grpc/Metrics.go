@@ -0,0 +1,264 @@
+package grpc
+
+import (
+	"authz/domain"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// metricsLatencyBuckets are the upper bounds in seconds of the handling latency histogram buckets
+var metricsLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics records request counts per method and status code and handling latency histograms per method.
+// It also counts permission check decisions per operation, resource type and result, and check errors per reason, see application.CheckMetrics.
+// The seat usage of exported licenses is kept as gauges per organization and service, see application.SeatUsageMetrics.
+// It serves them in the Prometheus text exposition format, see ServeHTTP.
+type Metrics struct {
+	handled    map[metricsHandledKey]uint64
+	latency    map[string]*metricsHistogram
+	decisions  map[metricsDecisionKey]uint64
+	errors     map[string]uint64
+	seats      map[metricsLicenseKey]domain.License
+	seatErrors map[metricsLicenseKey]uint64
+	lock       sync.Mutex
+}
+
+type metricsLicenseKey struct {
+	org     string
+	service string
+}
+
+type metricsDecisionKey struct {
+	operation    string
+	resourceType string
+	result       string
+}
+
+type metricsHandledKey struct {
+	method string
+	code   string
+}
+
+type metricsHistogram struct {
+	counts []uint64 //per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// NewMetrics constructs a new, empty Metrics
+func NewMetrics() *Metrics {
+	return &Metrics{
+		handled:    map[metricsHandledKey]uint64{},
+		latency:    map[string]*metricsHistogram{},
+		decisions:  map[metricsDecisionKey]uint64{},
+		errors:     map[string]uint64{},
+		seats:      map[metricsLicenseKey]domain.License{},
+		seatErrors: map[metricsLicenseKey]uint64{},
+	}
+}
+
+// ObserveSeatUsage replaces the seat usage gauges of the license
+func (m *Metrics) ObserveSeatUsage(lic domain.License) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.seats[metricsLicenseKey{org: lic.OrgID, service: lic.ServiceID}] = lic
+}
+
+// ObserveSeatUsageError counts one failed read of the seat usage of the license. The gauges keep the last usage read.
+func (m *Metrics) ObserveSeatUsageError(orgID string, serviceID string, _ error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.seatErrors[metricsLicenseKey{org: orgID, service: serviceID}]++
+}
+
+// ObserveDecision counts one permission check decision
+func (m *Metrics) ObserveDecision(operation string, resourceType string, allowed bool) {
+	result := "deny"
+	if allowed {
+		result = "allow"
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.decisions[metricsDecisionKey{operation: operation, resourceType: resourceType, result: result}]++
+}
+
+// ObserveCheckError counts one failed permission check by the reason of its error, see the Reason constants
+func (m *Metrics) ObserveCheckError(err error) {
+	reason := errorReason(err)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.errors[reason]++
+}
+
+// Observe records one handled request of the given method
+func (m *Metrics) Observe(method string, err error, duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.handled[metricsHandledKey{method: method, code: status.Code(err).String()}]++
+
+	h, ok := m.latency[method]
+	if !ok {
+		h = &metricsHistogram{counts: make([]uint64, len(metricsLatencyBuckets))}
+		m.latency[method] = h
+	}
+
+	seconds := duration.Seconds()
+	for i, bound := range metricsLatencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// UnaryInterceptor records the metrics of unary calls
+func (m *Metrics) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.Observe(info.FullMethod, err, time.Since(start))
+	return resp, err
+}
+
+// StreamInterceptor records the metrics of streaming calls
+func (m *Metrics) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	m.Observe(info.FullMethod, err, time.Since(start))
+	return err
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.write(w)
+}
+
+func (m *Metrics) write(w io.Writer) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	handledKeys := make([]metricsHandledKey, 0, len(m.handled))
+	for k := range m.handled {
+		handledKeys = append(handledKeys, k)
+	}
+	sort.Slice(handledKeys, func(i, j int) bool {
+		if handledKeys[i].method != handledKeys[j].method {
+			return handledKeys[i].method < handledKeys[j].method
+		}
+		return handledKeys[i].code < handledKeys[j].code
+	})
+
+	fmt.Fprintln(w, "# HELP grpc_server_handled_total Total number of RPCs completed on the server, regardless of success or failure.")
+	fmt.Fprintln(w, "# TYPE grpc_server_handled_total counter")
+	for _, k := range handledKeys {
+		fmt.Fprintf(w, "grpc_server_handled_total{grpc_method=%q,grpc_code=%q} %d\n", k.method, k.code, m.handled[k])
+	}
+
+	methods := make([]string, 0, len(m.latency))
+	for method := range m.latency {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	fmt.Fprintln(w, "# HELP grpc_server_handling_seconds Histogram of response latency (seconds) of gRPC that had been application-level handled by the server.")
+	fmt.Fprintln(w, "# TYPE grpc_server_handling_seconds histogram")
+	for _, method := range methods {
+		h := m.latency[method]
+		cumulative := uint64(0)
+		for i, bound := range metricsLatencyBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(w, "grpc_server_handling_seconds_bucket{grpc_method=%q,le=\"%g\"} %d\n", method, bound, cumulative)
+		}
+		fmt.Fprintf(w, "grpc_server_handling_seconds_bucket{grpc_method=%q,le=\"+Inf\"} %d\n", method, h.count)
+		fmt.Fprintf(w, "grpc_server_handling_seconds_sum{grpc_method=%q} %g\n", method, h.sum)
+		fmt.Fprintf(w, "grpc_server_handling_seconds_count{grpc_method=%q} %d\n", method, h.count)
+	}
+
+	decisionKeys := make([]metricsDecisionKey, 0, len(m.decisions))
+	for k := range m.decisions {
+		decisionKeys = append(decisionKeys, k)
+	}
+	sort.Slice(decisionKeys, func(i, j int) bool {
+		a, b := decisionKeys[i], decisionKeys[j]
+		if a.operation != b.operation {
+			return a.operation < b.operation
+		}
+		if a.resourceType != b.resourceType {
+			return a.resourceType < b.resourceType
+		}
+		return a.result < b.result
+	})
+
+	fmt.Fprintln(w, "# HELP authz_check_decisions_total Total number of permission check decisions.")
+	fmt.Fprintln(w, "# TYPE authz_check_decisions_total counter")
+	for _, k := range decisionKeys {
+		fmt.Fprintf(w, "authz_check_decisions_total{operation=%q,resource_type=%q,result=%q} %d\n", k.operation, k.resourceType, k.result, m.decisions[k])
+	}
+
+	reasons := make([]string, 0, len(m.errors))
+	for reason := range m.errors {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+
+	fmt.Fprintln(w, "# HELP authz_check_errors_total Total number of permission checks that failed, by error reason.")
+	fmt.Fprintln(w, "# TYPE authz_check_errors_total counter")
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "authz_check_errors_total{reason=%q} %d\n", reason, m.errors[reason])
+	}
+
+	licenseKeys := make([]metricsLicenseKey, 0, len(m.seats)+len(m.seatErrors))
+	for k := range m.seats {
+		licenseKeys = append(licenseKeys, k)
+	}
+	for k := range m.seatErrors {
+		if _, ok := m.seats[k]; !ok {
+			licenseKeys = append(licenseKeys, k)
+		}
+	}
+	sort.Slice(licenseKeys, func(i, j int) bool {
+		if licenseKeys[i].org != licenseKeys[j].org {
+			return licenseKeys[i].org < licenseKeys[j].org
+		}
+		return licenseKeys[i].service < licenseKeys[j].service
+	})
+
+	for _, gauge := range []struct {
+		name  string
+		help  string
+		value func(lic domain.License) int
+	}{
+		{name: "authz_license_seats_in_use", help: "Number of assigned seats of the license.", value: func(lic domain.License) int { return lic.InUse }},
+		{name: "authz_license_seats_max", help: "Max seats of the license.", value: func(lic domain.License) int { return lic.MaxSeats }},
+		{name: "authz_license_seats_available", help: "Number of seats of the license that can still be assigned.", value: func(lic domain.License) int { return lic.GetAvailableSeats() }},
+	} {
+		fmt.Fprintf(w, "# HELP %s %s\n", gauge.name, gauge.help)
+		fmt.Fprintf(w, "# TYPE %s gauge\n", gauge.name)
+		for _, k := range licenseKeys {
+			if lic, ok := m.seats[k]; ok {
+				fmt.Fprintf(w, "%s{org=%q,service=%q} %d\n", gauge.name, k.org, k.service, gauge.value(lic))
+			}
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP authz_license_seat_usage_errors_total Total number of failed reads of the seat usage of the license.")
+	fmt.Fprintln(w, "# TYPE authz_license_seat_usage_errors_total counter")
+	for _, k := range licenseKeys {
+		if count, ok := m.seatErrors[k]; ok {
+			fmt.Fprintf(w, "authz_license_seat_usage_errors_total{org=%q,service=%q} %d\n", k.org, k.service, count)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"authz/domain"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsExposesHandledCountsAndLatency(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.Observe("/authz.api.v1alpha.CheckPermission/CheckPermission", nil, 20*time.Millisecond)
+	metrics.Observe("/authz.api.v1alpha.CheckPermission/CheckPermission", status.Error(codes.InvalidArgument, "invalid"), 3*time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+
+	assert.Contains(t, body, `grpc_server_handled_total{grpc_method="/authz.api.v1alpha.CheckPermission/CheckPermission",grpc_code="OK"} 1`)
+	assert.Contains(t, body, `grpc_server_handled_total{grpc_method="/authz.api.v1alpha.CheckPermission/CheckPermission",grpc_code="InvalidArgument"} 1`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_bucket{grpc_method="/authz.api.v1alpha.CheckPermission/CheckPermission",le="0.005"} 1`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_bucket{grpc_method="/authz.api.v1alpha.CheckPermission/CheckPermission",le="0.025"} 2`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_count{grpc_method="/authz.api.v1alpha.CheckPermission/CheckPermission"} 2`)
+}
+
+func TestMetricsCountsCheckDecisionsAndErrors(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.ObserveDecision("use", "service", true)
+	metrics.ObserveDecision("use", "service", false)
+	metrics.ObserveDecision("use", "service", false)
+	metrics.ObserveCheckError(domain.ErrCheckTooComplex)
+	metrics.ObserveCheckError(errors.New("unexpected"))
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+
+	assert.Contains(t, body, `authz_check_decisions_total{operation="use",resource_type="service",result="allow"} 1`)
+	assert.Contains(t, body, `authz_check_decisions_total{operation="use",resource_type="service",result="deny"} 2`)
+	assert.Contains(t, body, `authz_check_errors_total{reason="CHECK_TOO_COMPLEX"} 1`)
+	assert.Contains(t, body, `authz_check_errors_total{reason="INTERNAL"} 1`)
+}
+
+func TestMetricsExposesSeatUsageGauges(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.ObserveSeatUsage(*domain.NewLicense("o1", "smarts", 10, 4))
+	metrics.ObserveSeatUsage(*domain.NewLicense("o1", "smarts", 10, 6))
+	metrics.ObserveSeatUsageError("o2", "smarts", domain.ErrLicenseNotFound)
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+
+	assert.Contains(t, body, "# TYPE authz_license_seats_in_use gauge")
+	assert.Contains(t, body, `authz_license_seats_in_use{org="o1",service="smarts"} 6`)
+	assert.Contains(t, body, `authz_license_seats_max{org="o1",service="smarts"} 10`)
+	assert.Contains(t, body, `authz_license_seats_available{org="o1",service="smarts"} 4`)
+	assert.Contains(t, body, `authz_license_seat_usage_errors_total{org="o2",service="smarts"} 1`)
+	assert.NotContains(t, body, `authz_license_seats_in_use{org="o2"`, "Licenses never read should have no gauges.")
+}
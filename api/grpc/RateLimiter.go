@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimiter decides whether a request may proceed. Requests are limited by the requestor identity, or if anonymous, by the client address, so anonymous clients do not share one limit.
+type RateLimiter interface {
+	// Allow decides on a request with the given key, the requestor identity or if anonymous the client address
+	Allow(key string, anonymous bool) bool
+}
+
+// TokenBucketRateLimiter is an in-memory RateLimiter with one token bucket per key. Anonymous keys get the anonymous rate and burst.
+type TokenBucketRateLimiter struct {
+	rate           float64
+	burst          float64
+	anonymousRate  float64
+	anonymousBurst float64
+	buckets        map[rateLimitKey]*tokenBucket
+	lastSweep      time.Time
+	lock           sync.Mutex
+	now            func() time.Time
+}
+
+// rateLimitKey keeps anonymous client addresses and requestor identities apart, ex: if a requestor ID looks like an address
+type rateLimitKey struct {
+	key       string
+	anonymous bool
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucketRateLimiter constructs a new TokenBucketRateLimiter. Rates are in requests per second, bursts are the maximum number of requests at once.
+func NewTokenBucketRateLimiter(rate float64, burst int, anonymousRate float64, anonymousBurst int) *TokenBucketRateLimiter {
+	return &TokenBucketRateLimiter{
+		rate:           rate,
+		burst:          float64(burst),
+		anonymousRate:  anonymousRate,
+		anonymousBurst: float64(anonymousBurst),
+		buckets:        map[rateLimitKey]*tokenBucket{},
+		now:            time.Now,
+	}
+}
+
+// Allow takes a token from the key's bucket and returns true, or false if the bucket is empty
+func (l *TokenBucketRateLimiter) Allow(key string, anonymous bool) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	rate, burst := l.rate, l.burst
+	if anonymous {
+		rate, burst = l.anonymousRate, l.anonymousBurst
+	}
+
+	now := l.now()
+	l.sweep(now)
+
+	bucketKey := rateLimitKey{key: key, anonymous: anonymous}
+	bucket, ok := l.buckets[bucketKey]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, last: now}
+		l.buckets[bucketKey] = bucket
+	}
+
+	bucket.tokens += now.Sub(bucket.last).Seconds() * rate
+	if bucket.tokens > burst {
+		bucket.tokens = burst
+	}
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// sweep drops the buckets of keys idle for a minute from time to time so the map does not grow unbounded. Idle buckets are refilled anyway.
+func (l *TokenBucketRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= time.Minute {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// rateLimitInterceptor rejects requests exceeding the requestor's rate limit with ResourceExhausted.
+// Requests without a valid identity are limited by their client address at the anonymous rate, the handler rejects them if they need one.
+// This includes bearer tokens unless InsecureDevAuth is set, so clients using them do not exhaust one shared anonymous limit.
+func (s *Server) rateLimitInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !s.allowRequest(ctx) {
+		return nil, status.Error(codes.ResourceExhausted, "Rate limit exceeded.")
+	}
+
+	return handler(ctx, req)
+}
+
+// rateLimitStreamInterceptor works like rateLimitInterceptor for streaming RPCs. A stream counts as one request, regardless of its number of messages.
+func (s *Server) rateLimitStreamInterceptor(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !s.allowRequest(stream.Context()) {
+		return status.Error(codes.ResourceExhausted, "Rate limit exceeded.")
+	}
+
+	return handler(srv, stream)
+}
+
+// allowRequest returns false if the request exceeds the rate limit of its requestor, or of its client address if it has no valid identity
+func (s *Server) allowRequest(ctx context.Context) bool {
+	key, err := s.getRequestorIdentityFromGrpcContext(ctx)
+	anonymous := err != nil || key == ""
+	if anonymous {
+		key = clientAddress(ctx)
+	}
+
+	return s.RateLimiter.Allow(key, anonymous)
+}
+
+// clientAddress returns the address of the client without its port, or an empty string if unknown
+func clientAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenBucketRateLimiterAllowsBurstThenRefills(t *testing.T) {
+	now := time.Now()
+	limiter := NewTokenBucketRateLimiter(1, 2, 1, 1)
+	limiter.now = func() time.Time { return now }
+
+	assert.True(t, limiter.Allow("okay", false))
+	assert.True(t, limiter.Allow("okay", false))
+	assert.False(t, limiter.Allow("okay", false), "Should have exhausted the burst.")
+	assert.True(t, limiter.Allow("system", false), "Should have had a separate bucket.")
+
+	now = now.Add(time.Second)
+	assert.True(t, limiter.Allow("okay", false), "Should have refilled one token.")
+	assert.False(t, limiter.Allow("okay", false))
+}
+
+func TestTokenBucketRateLimiterLimitsAnonymousClientsSeparately(t *testing.T) {
+	now := time.Now()
+	limiter := NewTokenBucketRateLimiter(10, 10, 1, 1)
+	limiter.now = func() time.Time { return now }
+
+	assert.True(t, limiter.Allow("10.0.0.1", true))
+	assert.False(t, limiter.Allow("10.0.0.1", true), "Should have used the stricter anonymous burst.")
+	assert.True(t, limiter.Allow("10.0.0.2", true), "Should have had a separate bucket.")
+	assert.True(t, limiter.Allow("10.0.0.1", false), "Should not have shared the bucket of an identity.")
+}
+
+func TestRateLimitInterceptorRejectsExceedingRequests(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.RateLimiter = NewTokenBucketRateLimiter(0, 1, 0, 1)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	resp, err := srv.rateLimitInterceptor(getContext("okay"), nil, nil, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = srv.rateLimitInterceptor(getContext("okay"), nil, nil, handler)
+	st, ok := status.FromError(err)
+	assert.True(t, ok, "Should have been a grpc status error.")
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+}
+
+func TestRateLimitInterceptorLimitsInvalidIdentitiesByClientAddress(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.ServerConfig.InsecureDevAuth = false //bearer tokens are rejected as invalid identities
+	srv.RateLimiter = NewTokenBucketRateLimiter(0, 1, 0, 1)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	fromClient := func(addr string) context.Context {
+		return peer.NewContext(getContext("okay"), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 4242}})
+	}
+
+	_, err := srv.rateLimitInterceptor(fromClient("10.0.0.1"), nil, nil, handler)
+	assert.NoError(t, err)
+
+	_, err = srv.rateLimitInterceptor(fromClient("10.0.0.2"), nil, nil, handler)
+	assert.NoError(t, err, "Should not have shared the limit of the other client.")
+
+	_, err = srv.rateLimitInterceptor(fromClient("10.0.0.1"), nil, nil, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRateLimitStreamInterceptorRejectsExceedingStreams(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.RateLimiter = NewTokenBucketRateLimiter(0, 1, 0, 1)
+	handler := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+
+	err := srv.rateLimitStreamInterceptor(nil, &contextStream{ctx: getContext("okay")}, nil, handler)
+	assert.NoError(t, err)
+
+	err = srv.rateLimitStreamInterceptor(nil, &contextStream{ctx: getContext("okay")}, nil, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+// contextStream is a server stream that only provides its context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
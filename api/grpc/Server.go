@@ -8,14 +8,24 @@ import (
 	"authz/domain"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/glog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/encoding/gzip" //registers the gzip compressor, responses are compressed for clients that compress their requests
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
@@ -25,6 +35,16 @@ type Server struct {
 	AccessAppService  *application.AccessAppService
 	LicenseAppService *application.LicenseAppService
 	ServerConfig      *api.ServerConfig
+	// IdentityExtractors optionally adds or replaces identity extraction strategies, ex: a custom x-rh-identity decoder. See DefaultIdentityExtractors.
+	IdentityExtractors map[string]IdentityExtractor
+	// RateLimiter optionally limits the requests per requestor. If nil, Serve creates a TokenBucketRateLimiter if rate limiting is configured.
+	RateLimiter RateLimiter
+	// Metrics optionally records request metrics. If nil, Serve creates one if a metrics port is configured.
+	Metrics *Metrics
+	// Health optionally reports the serving status over the standard gRPC health checking protocol
+	Health *health.Server
+	// RelationshipAppService optionally manages raw relationships. If nil, the RelationshipService RPCs fail with Unimplemented, ex: if the store does not support them.
+	RelationshipAppService *application.RelationshipAppService
 }
 
 // GetLicense ToDo - just a stub for now.
@@ -39,9 +59,9 @@ func (s *Server) GetLicense(ctx context.Context, grpcReq *core.GetLicenseRequest
 		OrgID:     grpcReq.OrgId,
 		ServiceID: grpcReq.ServiceId,
 	}
-	limit, available, err := s.LicenseAppService.GetSeatAssignmentCounts(req)
+	limit, available, err := s.LicenseAppService.GetSeatAssignmentCounts(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, convertDomainErrorToGrpc(err)
 	}
 
 	return &core.GetLicenseResponse{
@@ -52,11 +72,18 @@ func (s *Server) GetLicense(ctx context.Context, grpcReq *core.GetLicenseRequest
 
 // ModifySeats ToDo - just a stub for now.
 func (s *Server) ModifySeats(ctx context.Context, grpcReq *core.ModifySeatsRequest) (*core.ModifySeatsResponse, error) {
-	requestor, err := s.getRequestorIdentityFromGrpcContext(ctx)
+	requestor, err := s.authenticate(ctx, "ModifySeats")
 	if err != nil {
 		return nil, err
 	}
 
+	if s.ServerConfig != nil && s.ServerConfig.MaxSeatChanges > 0 && len(grpcReq.Assign)+len(grpcReq.Unassign) > s.ServerConfig.MaxSeatChanges {
+		return nil, newErrorWithDetails(codes.InvalidArgument, fmt.Sprintf("at most %d seats can be assigned or unassigned per request.", s.ServerConfig.MaxSeatChanges),
+			ReasonTooManySeatChanges, map[string]string{"limit": strconv.Itoa(s.ServerConfig.MaxSeatChanges)},
+			&errdetails.BadRequest_FieldViolation{Field: "assign", Description: "too many seat changes"},
+			&errdetails.BadRequest_FieldViolation{Field: "unassign", Description: "too many seat changes"})
+	}
+
 	req := application.ModifySeatAssignmentRequest{
 		Requestor: requestor,
 		OrgID:     grpcReq.OrgId,
@@ -65,7 +92,7 @@ func (s *Server) ModifySeats(ctx context.Context, grpcReq *core.ModifySeatsReque
 		Unassign:  grpcReq.Unassign,
 	}
 
-	err = s.LicenseAppService.ModifySeats(req)
+	err = s.LicenseAppService.ModifySeats(ctx, req)
 
 	if err != nil {
 		return nil, convertDomainErrorToGrpc(err)
@@ -75,7 +102,7 @@ func (s *Server) ModifySeats(ctx context.Context, grpcReq *core.ModifySeatsReque
 
 // GetSeats ToDo - just a stub for now.
 func (s *Server) GetSeats(ctx context.Context, grpcReq *core.GetSeatsRequest) (*core.GetSeatsResponse, error) {
-	requestor, err := s.getRequestorIdentityFromGrpcContext(ctx)
+	requestor, err := s.authenticate(ctx, "GetSeats")
 	if err != nil {
 		return nil, err
 	}
@@ -96,17 +123,30 @@ func (s *Server) GetSeats(ctx context.Context, grpcReq *core.GetSeatsRequest) (*
 		}
 	}
 
+	var sortBy application.SeatSortField
+	switch grpcReq.GetSortBy() {
+	case core.SeatSortField_id:
+		sortBy = application.SortByID
+	case core.SeatSortField_displayName:
+		sortBy = application.SortByName
+	default:
+		return nil, newBadRequestError("sortBy", fmt.Sprintf("sortBy %s is not supported.", grpcReq.GetSortBy()))
+	}
+
 	req := application.GetSeatAssignmentRequest{
-		Requestor:    requestor,
-		OrgID:        grpcReq.OrgId,
-		ServiceID:    grpcReq.ServiceId,
-		IncludeUsers: includeUsers,
-		Assigned:     assigned,
+		Requestor:       requestor,
+		OrgID:           grpcReq.OrgId,
+		ServiceID:       grpcReq.ServiceId,
+		IncludeUsers:    includeUsers,
+		Assigned:        assigned,
+		SortBy:          sortBy,
+		Descending:      grpcReq.GetDescending(),
+		OnlyReclaimable: grpcReq.GetOnlyReclaimable(),
 	}
 
-	principals, err := s.LicenseAppService.GetSeatAssignments(req)
+	principals, err := s.LicenseAppService.GetSeatAssignments(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, convertDomainErrorToGrpc(err)
 	}
 
 	resp := &core.GetSeatsResponse{Users: make([]*core.GetSeatsUserRepresentation, len(principals))}
@@ -115,12 +155,242 @@ func (s *Server) GetSeats(ctx context.Context, grpcReq *core.GetSeatsRequest) (*
 			DisplayName: p.DisplayName,
 			Id:          string(p.ID),
 			Assigned:    assigned,
+			Reclaimable: assigned && p.Disabled,
+		}
+	}
+
+	return resp, nil
+}
+
+// GetOrgSeatSummary returns the seat usage of each license of an organization and their totals
+func (s *Server) GetOrgSeatSummary(ctx context.Context, grpcReq *core.GetOrgSeatSummaryRequest) (*core.GetOrgSeatSummaryResponse, error) {
+	requestor, err := s.authenticate(ctx, "GetOrgSeatSummary")
+	if err != nil {
+		return nil, err
+	}
+
+	req := application.GetOrgSeatSummaryRequest{
+		Requestor: requestor,
+		OrgID:     grpcReq.OrgId,
+	}
+	summary, err := s.LicenseAppService.GetOrgSeatSummary(ctx, req)
+	if err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	resp := &core.GetOrgSeatSummaryResponse{
+		Services:       make([]*core.ServiceSeatUsage, len(summary.Services)),
+		SeatsTotal:     int32(summary.MaxSeats),
+		SeatsInUse:     int32(summary.InUse),
+		SeatsAvailable: int32(summary.Available),
+	}
+	for i, usage := range summary.Services {
+		resp.Services[i] = &core.ServiceSeatUsage{
+			ServiceId:      usage.Service.ID,
+			DisplayName:    usage.Service.DisplayName,
+			SeatsTotal:     int32(usage.MaxSeats),
+			SeatsInUse:     int32(usage.InUse),
+			SeatsAvailable: int32(usage.Available),
 		}
 	}
 
 	return resp, nil
 }
 
+// CreateLicense provisions a new license without assigned seats. It requires the admin operation, and fails with AlreadyExists if the license exists.
+func (s *Server) CreateLicense(ctx context.Context, grpcReq *core.CreateLicenseRequest) (*core.CreateLicenseResponse, error) {
+	requestor, err := s.authenticate(ctx, "CreateLicense")
+	if err != nil {
+		return nil, err
+	}
+
+	req := application.CreateLicenseRequest{
+		Requestor: requestor,
+		OrgID:     grpcReq.OrgId,
+		ServiceID: grpcReq.ServiceId,
+		MaxSeats:  int(grpcReq.SeatsTotal),
+	}
+	if err := s.LicenseAppService.CreateLicense(ctx, req); err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	return &core.CreateLicenseResponse{}, nil
+}
+
+// DeleteLicense deletes a license and its seat assignments and returns the number of seats released. It requires the admin operation. Deleting a missing license is not an error.
+func (s *Server) DeleteLicense(ctx context.Context, grpcReq *core.DeleteLicenseRequest) (*core.DeleteLicenseResponse, error) {
+	requestor, err := s.authenticate(ctx, "DeleteLicense")
+	if err != nil {
+		return nil, err
+	}
+
+	req := application.DeleteLicenseRequest{
+		Requestor: requestor,
+		OrgID:     grpcReq.OrgId,
+		ServiceID: grpcReq.ServiceId,
+	}
+	released, err := s.LicenseAppService.DeleteLicense(ctx, req)
+	if err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	return &core.DeleteLicenseResponse{SeatsReleased: int32(released)}, nil
+}
+
+// SetLicenseSeats changes the seat limit of a license. It requires the admin operation, and fails with FailedPrecondition if the limit is below the seats in use.
+func (s *Server) SetLicenseSeats(ctx context.Context, grpcReq *core.SetLicenseSeatsRequest) (*core.SetLicenseSeatsResponse, error) {
+	requestor, err := s.authenticate(ctx, "SetLicenseSeats")
+	if err != nil {
+		return nil, err
+	}
+
+	req := application.SetMaxSeatsRequest{
+		Requestor: requestor,
+		OrgID:     grpcReq.OrgId,
+		ServiceID: grpcReq.ServiceId,
+		MaxSeats:  int(grpcReq.SeatsTotal),
+	}
+	if err := s.LicenseAppService.SetMaxSeats(ctx, req); err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	return &core.SetLicenseSeatsResponse{}, nil
+}
+
+// ReconcileSeats reports discrepancies between the recorded seat usage of a license and its seat assignments, and optionally repairs them. It requires the admin operation.
+func (s *Server) ReconcileSeats(ctx context.Context, grpcReq *core.ReconcileSeatsRequest) (*core.ReconcileSeatsResponse, error) {
+	requestor, err := s.authenticate(ctx, "ReconcileSeats")
+	if err != nil {
+		return nil, err
+	}
+
+	req := application.ReconcileSeatsRequest{
+		Requestor: requestor,
+		OrgID:     grpcReq.OrgId,
+		ServiceID: grpcReq.ServiceId,
+		Repair:    grpcReq.Repair,
+	}
+	result, err := s.LicenseAppService.ReconcileSeats(ctx, req)
+	if err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	resp := &core.ReconcileSeatsResponse{
+		RecordedSeatsInUse: int32(result.RecordedInUse),
+		ActualSeatsInUse:   int32(result.ActualInUse),
+		NonMembers:         make([]string, len(result.NonMembers)),
+		Repaired:           result.Repaired,
+		SeatsInUse:         int32(result.InUse),
+		HasDiscrepancies:   result.HasDiscrepancies(),
+	}
+	for i, subject := range result.NonMembers {
+		resp.NonMembers[i] = string(subject)
+	}
+
+	return resp, nil
+}
+
+// UnassignAllForSubject removes the seats of a subject in every service of an organization and returns the services it held a seat for. It requires the admin operation.
+func (s *Server) UnassignAllForSubject(ctx context.Context, grpcReq *core.UnassignAllForSubjectRequest) (*core.UnassignAllForSubjectResponse, error) {
+	requestor, err := s.authenticate(ctx, "UnassignAllForSubject")
+	if err != nil {
+		return nil, err
+	}
+
+	if grpcReq.SubjectId == "" {
+		return nil, newBadRequestError("subjectId", "subjectId is required.")
+	}
+
+	req := application.UnassignAllForSubjectRequest{
+		Requestor: requestor,
+		OrgID:     grpcReq.OrgId,
+		SubjectID: grpcReq.SubjectId,
+	}
+	services, err := s.LicenseAppService.UnassignAllForSubject(ctx, req)
+	if err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	resp := &core.UnassignAllForSubjectResponse{ServiceIds: make([]string, len(services))}
+	for i, svc := range services {
+		resp.ServiceIds[i] = svc.ID
+	}
+
+	return resp, nil
+}
+
+// bulkAssignStreamBatchSize is the number of streamed subjects BulkAssignSeats collects before assigning them
+const bulkAssignStreamBatchSize = 1000
+
+// BulkAssignSeats assigns seats to the subjects streamed by the client in batches and returns a summary once the client closes the stream.
+// Once the license runs out of seats, the remaining subjects are skipped. An error ends the stream, the subjects assigned so far keep their seats.
+func (s *Server) BulkAssignSeats(stream core.LicenseService_BulkAssignSeatsServer) error {
+	ctx := stream.Context()
+	requestor, err := s.authenticate(ctx, "BulkAssignSeats")
+	if err != nil {
+		return err
+	}
+
+	req := application.BulkAssignSeatsRequest{Requestor: requestor}
+	summary := domain.BulkAssignSummary{}
+	assigned := false
+	assign := func() error {
+		result, err := s.LicenseAppService.BulkAssignSeats(ctx, req)
+		if result != nil {
+			summary.Assigned += result.Assigned
+			summary.SkippedAlreadyAssigned += result.SkippedAlreadyAssigned
+			summary.SkippedLimit += result.SkippedLimit
+			summary.Failed += result.Failed
+			summary.LimitReached = summary.LimitReached || result.LimitReached
+		}
+		req.Subjects = req.Subjects[:0]
+		assigned = true
+		return err
+	}
+
+	for first := true; ; first = false {
+		grpcReq, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if first {
+			req.OrgID, req.ServiceID = grpcReq.OrgId, grpcReq.ServiceId
+		} else if (grpcReq.OrgId != "" && grpcReq.OrgId != req.OrgID) || (grpcReq.ServiceId != "" && grpcReq.ServiceId != req.ServiceID) {
+			return newBadRequestError("orgId", "all messages of the stream must be for the same license.")
+		}
+
+		if summary.LimitReached {
+			summary.SkippedLimit += len(grpcReq.Subjects)
+			continue
+		}
+
+		req.Subjects = append(req.Subjects, grpcReq.Subjects...)
+		if len(req.Subjects) >= bulkAssignStreamBatchSize {
+			if err := assign(); err != nil {
+				return convertDomainErrorToGrpc(err)
+			}
+		}
+	}
+
+	if len(req.Subjects) > 0 || !assigned { //Also authorize an empty stream
+		if err := assign(); err != nil {
+			return convertDomainErrorToGrpc(err)
+		}
+	}
+
+	return stream.SendAndClose(&core.BulkAssignSeatsResponse{
+		Assigned:               int32(summary.Assigned),
+		SkippedAlreadyAssigned: int32(summary.SkippedAlreadyAssigned),
+		SkippedLimit:           int32(summary.SkippedLimit),
+		Failed:                 int32(summary.Failed),
+		LimitReached:           summary.LimitReached,
+	})
+}
+
 // NewServer creates a new Server object to use.
 func NewServer(h application.AccessAppService, l application.LicenseAppService, c api.ServerConfig) *Server {
 	return &Server{AccessAppService: &h, ServerConfig: &c, LicenseAppService: &l}
@@ -130,6 +400,11 @@ func NewServer(h application.AccessAppService, l application.LicenseAppService,
 func (s *Server) Serve(wait *sync.WaitGroup) error {
 	defer wait.Done()
 
+	if err := checkInsecureDevAuth(s.ServerConfig); err != nil {
+		glog.Errorf("Refusing to start: %s", err)
+		return err
+	}
+
 	ls, err := net.Listen("tcp", ":"+s.ServerConfig.GrpcPort)
 
 	if err != nil {
@@ -154,9 +429,58 @@ func (s *Server) Serve(wait *sync.WaitGroup) error {
 			s.ServerConfig.GrpcPort)
 	}
 
-	srv := grpc.NewServer(grpc.Creds(creds))
+	opts := []grpc.ServerOption{grpc.Creds(creds)}
+	if s.ServerConfig.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(s.ServerConfig.MaxRecvMsgSize))
+	}
+	if s.ServerConfig.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(s.ServerConfig.MaxSendMsgSize))
+	}
+
+	if s.Metrics == nil && s.ServerConfig.MetricsPort != "" {
+		s.Metrics = NewMetrics()
+	}
+	if s.Metrics != nil && s.AccessAppService != nil {
+		s.AccessAppService.SetMetrics(s.Metrics)
+	}
+	if s.Metrics != nil && s.LicenseAppService != nil && len(s.ServerConfig.SeatUsageLicenses) > 0 {
+		exportCtx, stopExport := context.WithCancel(context.Background())
+		defer stopExport()
+		if err := s.LicenseAppService.ExportSeatUsage(exportCtx, s.ServerConfig.SeatUsageLicenses, s.ServerConfig.SeatUsageInterval, s.Metrics); err != nil {
+			glog.Errorf("Invalid seat usage licenses: %s", err)
+			return err
+		}
+	}
+	interceptors := make([]grpc.UnaryServerInterceptor, 0)
+	streamInterceptors := make([]grpc.StreamServerInterceptor, 0)
+	if s.Metrics != nil {
+		interceptors = append(interceptors, s.Metrics.UnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, s.Metrics.StreamInterceptor)
+	}
+
+	if s.RateLimiter == nil && s.ServerConfig.RateLimit.Rate > 0 {
+		rl := s.ServerConfig.RateLimit
+		s.RateLimiter = NewTokenBucketRateLimiter(rl.Rate, rl.Burst, rl.AnonymousRate, rl.AnonymousBurst)
+	}
+	if s.RateLimiter != nil {
+		interceptors = append(interceptors, s.rateLimitInterceptor)
+		streamInterceptors = append(streamInterceptors, s.rateLimitStreamInterceptor)
+	}
+	opts = append(opts, grpc.ChainUnaryInterceptor(interceptors...), grpc.ChainStreamInterceptor(streamInterceptors...))
+
+	srv := grpc.NewServer(opts...)
 	core.RegisterCheckPermissionServer(srv, s)
 	core.RegisterLicenseServiceServer(srv, s)
+	core.RegisterRelationshipServiceServer(srv, s)
+	if s.Health != nil {
+		healthpb.RegisterHealthServer(srv, s.Health)
+	}
+
+	if s.ServerConfig.MetricsPort != "" {
+		metricsSrv := s.serveMetrics()
+		defer metricsSrv.Close()
+	}
+
 	err = srv.Serve(ls)
 	if err != nil {
 		glog.Errorf("Error hosting gRPC service: %s", err)
@@ -165,6 +489,22 @@ func (s *Server) Serve(wait *sync.WaitGroup) error {
 	return nil
 }
 
+// serveMetrics starts the metrics endpoint in its own goroutine. Closing the returned server stops it.
+func (s *Server) serveMetrics() *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.Metrics)
+	metricsSrv := &http.Server{Addr: ":" + s.ServerConfig.MetricsPort, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		glog.Infof("Starting metrics endpoint on port %s", s.ServerConfig.MetricsPort)
+		if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			glog.Errorf("Error hosting metrics endpoint: %s", err)
+		}
+	}()
+
+	return metricsSrv
+}
+
 // GetName returns the impl name
 func (s *Server) GetName() string {
 	return "grpc"
@@ -184,40 +524,397 @@ func (s *Server) CheckPermission(ctx context.Context, rpcReq *core.CheckPermissi
 		ResourceType: rpcReq.Resourcetype,
 		ResourceID:   rpcReq.Resourceid,
 	}
+	s.applyCheckPermissionDefaults(&req)
+
+	if err := s.validateCheckRequest(req); err != nil {
+		return nil, err
+	}
 
-	result, err := s.AccessAppService.Check(req)
+	result, err := s.AccessAppService.CheckWithContext(ctx, req)
 
 	if err != nil {
 		return nil, convertDomainErrorToGrpc(err)
 	}
 
-	return &core.CheckPermissionResponse{Result: bool(result)}, nil
+	return &core.CheckPermissionResponse{Result: result.IsAllowed(), Description: result.Reason, Obligations: result.Obligations}, nil
 }
 
-func (s *Server) getRequestorIdentityFromGrpcContext(ctx context.Context) (string, error) {
-	for _, name := range []string{"grpcgateway-authorization", "bearer-token"} {
-		if md, ok := metadata.FromIncomingContext(ctx); ok {
-			headers := md.Get(name)
-			if len(headers) > 0 {
-				return convertTokenToPrincipalID(headers[0])
+// BatchCheckPermission processes multiple authorization checks at once and returns the results in the order of the checks.
+// A check that fails, ex: because it is invalid, reports the error in its result without failing the others.
+func (s *Server) BatchCheckPermission(ctx context.Context, rpcReq *core.BatchCheckPermissionRequest) (*core.BatchCheckPermissionResponse, error) {
+	requestor, err := s.authenticate(ctx, "BatchCheckPermission")
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &core.BatchCheckPermissionResponse{Results: make([]*core.BatchCheckPermissionResult, len(rpcReq.Checks))}
+	reqs := make([]application.CheckRequest, 0, len(rpcReq.Checks))
+	indexes := make([]int, 0, len(rpcReq.Checks)) //position of each valid check in the batch
+	for i, check := range rpcReq.Checks {
+		req := application.CheckRequest{
+			Requestor:    requestor,
+			Subject:      check.Subject,
+			Operation:    check.Operation,
+			ResourceType: check.Resourcetype,
+			ResourceID:   check.Resourceid,
+		}
+		s.applyCheckPermissionDefaults(&req)
+
+		if err := s.validateCheckRequest(req); err != nil {
+			resp.Results[i] = newFailedCheckResult(err)
+			continue
+		}
+		reqs = append(reqs, req)
+		indexes = append(indexes, i)
+	}
+
+	decisions, errs, err := s.AccessAppService.CheckBatch(ctx, requestor, reqs)
+	if err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	for j, i := range indexes {
+		if errs[j] != nil {
+			resp.Results[i] = newFailedCheckResult(convertDomainErrorToGrpc(errs[j]))
+			continue
+		}
+		resp.Results[i] = &core.BatchCheckPermissionResult{Result: decisions[j].IsAllowed(), Description: decisions[j].Reason, Obligations: decisions[j].Obligations}
+	}
+
+	return resp, nil
+}
+
+// LookupResources returns the IDs of all resources of a type on which the subject can perform the operation. All results are returned at once, there is no paging yet.
+func (s *Server) LookupResources(ctx context.Context, rpcReq *core.LookupResourcesRequest) (*core.LookupResourcesResponse, error) {
+	requestor, err := s.authenticate(ctx, "LookupResources")
+	if err != nil {
+		return nil, err
+	}
+
+	req := application.LookupResourcesRequest{
+		Requestor:    requestor,
+		Subject:      rpcReq.Subject,
+		Operation:    rpcReq.Operation,
+		ResourceType: rpcReq.Resourcetype,
+	}
+	if s.ServerConfig != nil {
+		if strings.TrimSpace(req.Operation) == "" {
+			req.Operation = s.ServerConfig.DefaultOperation
+		}
+		if strings.TrimSpace(req.ResourceType) == "" {
+			req.ResourceType = s.ServerConfig.DefaultResourceType
+		}
+	}
+
+	if err := s.validateLookupResourcesRequest(req); err != nil {
+		return nil, err
+	}
+
+	resources, err := s.AccessAppService.LookupResources(ctx, req)
+	if err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	resp := &core.LookupResourcesResponse{Resourceids: make([]string, len(resources))}
+	for i, resource := range resources {
+		resp.Resourceids[i] = resource.ID
+	}
+
+	return resp, nil
+}
+
+// LookupSubjects returns all subjects that can perform the operation on the resource, for audits. All results are returned at once, there is no paging yet.
+func (s *Server) LookupSubjects(ctx context.Context, rpcReq *core.LookupSubjectsRequest) (*core.LookupSubjectsResponse, error) {
+	requestor, err := s.authenticate(ctx, "LookupSubjects")
+	if err != nil {
+		return nil, err
+	}
+
+	req := application.LookupSubjectsRequest{
+		Requestor:    requestor,
+		Operation:    rpcReq.Operation,
+		ResourceType: rpcReq.Resourcetype,
+		ResourceID:   rpcReq.Resourceid,
+	}
+	if s.ServerConfig != nil {
+		if strings.TrimSpace(req.Operation) == "" {
+			req.Operation = s.ServerConfig.DefaultOperation
+		}
+		if strings.TrimSpace(req.ResourceType) == "" {
+			req.ResourceType = s.ServerConfig.DefaultResourceType
+		}
+	}
+
+	if err := s.validateLookupSubjectsRequest(req); err != nil {
+		return nil, err
+	}
+
+	subjects, err := s.AccessAppService.LookupSubjects(ctx, req)
+	if err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	resp := &core.LookupSubjectsResponse{Subjects: make([]string, len(subjects))}
+	for i, subject := range subjects {
+		resp.Subjects[i] = string(subject)
+	}
+
+	return resp, nil
+}
+
+// WriteRelationship creates a relationship whose kind is allowed by the store's allowlist. It requires the relationships admin operation.
+func (s *Server) WriteRelationship(ctx context.Context, grpcReq *core.WriteRelationshipRequest) (*core.WriteRelationshipResponse, error) {
+	requestor, err := s.authenticate(ctx, "WriteRelationship")
+	if err != nil {
+		return nil, err
+	}
+
+	if s.RelationshipAppService == nil {
+		return nil, errRelationshipsNotSupported
+	}
+
+	req := application.RelationshipRequest{
+		Requestor:    requestor,
+		ResourceType: grpcReq.ResourceType,
+		ResourceID:   grpcReq.ResourceId,
+		Relation:     grpcReq.Relation,
+		SubjectType:  grpcReq.SubjectType,
+		SubjectID:    grpcReq.SubjectId,
+	}
+	if err := s.RelationshipAppService.WriteRelationship(ctx, req); err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	return &core.WriteRelationshipResponse{}, nil
+}
+
+// DeleteRelationship removes a relationship whose kind is allowed by the store's allowlist. It requires the relationships admin operation.
+func (s *Server) DeleteRelationship(ctx context.Context, grpcReq *core.DeleteRelationshipRequest) (*core.DeleteRelationshipResponse, error) {
+	requestor, err := s.authenticate(ctx, "DeleteRelationship")
+	if err != nil {
+		return nil, err
+	}
+
+	if s.RelationshipAppService == nil {
+		return nil, errRelationshipsNotSupported
+	}
+
+	req := application.RelationshipRequest{
+		Requestor:    requestor,
+		ResourceType: grpcReq.ResourceType,
+		ResourceID:   grpcReq.ResourceId,
+		Relation:     grpcReq.Relation,
+		SubjectType:  grpcReq.SubjectType,
+		SubjectID:    grpcReq.SubjectId,
+	}
+	if err := s.RelationshipAppService.DeleteRelationship(ctx, req); err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	return &core.DeleteRelationshipResponse{}, nil
+}
+
+// ReadRelationships lists one page of the raw relationships matching the filter. It requires the relationships admin operation.
+func (s *Server) ReadRelationships(ctx context.Context, grpcReq *core.ReadRelationshipsRequest) (*core.ReadRelationshipsResponse, error) {
+	requestor, err := s.authenticate(ctx, "ReadRelationships")
+	if err != nil {
+		return nil, err
+	}
+
+	if s.RelationshipAppService == nil {
+		return nil, errRelationshipsNotSupported
+	}
+
+	req := application.ReadRelationshipsRequest{
+		Requestor:    requestor,
+		ResourceType: grpcReq.ResourceType,
+		ResourceID:   grpcReq.ResourceId,
+		Relation:     grpcReq.Relation,
+		Cursor:       grpcReq.Cursor,
+		Limit:        int(grpcReq.Limit),
+	}
+	page, err := s.RelationshipAppService.ReadRelationships(ctx, req)
+	if err != nil {
+		return nil, convertDomainErrorToGrpc(err)
+	}
+
+	resp := &core.ReadRelationshipsResponse{Relationships: make([]*core.Relationship, len(page.Relationships)), NextCursor: page.NextCursor}
+	for i, rel := range page.Relationships {
+		resp.Relationships[i] = &core.Relationship{
+			ResourceType: rel.Resource.Type,
+			ResourceId:   rel.Resource.ID,
+			Relation:     rel.Relation,
+			SubjectType:  rel.Subject.Type,
+			SubjectId:    rel.Subject.ID,
+		}
+	}
+
+	return resp, nil
+}
+
+// newFailedCheckResult reports the grpc error of a check of a batch in its result
+func newFailedCheckResult(err error) *core.BatchCheckPermissionResult {
+	st := status.Convert(err)
+	result := &core.BatchCheckPermissionResult{Error: st.Message(), Reason: ReasonInternal}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			result.Reason = info.Reason
+		}
+	}
+
+	return result
+}
+
+// applyCheckPermissionDefaults fills in the configured default operation and resource type if the request leaves them blank
+func (s *Server) applyCheckPermissionDefaults(req *application.CheckRequest) {
+	if s.ServerConfig == nil {
+		return
+	}
+
+	if strings.TrimSpace(req.Operation) == "" {
+		req.Operation = s.ServerConfig.DefaultOperation
+	}
+
+	if strings.TrimSpace(req.ResourceType) == "" {
+		req.ResourceType = s.ServerConfig.DefaultResourceType
+	}
+}
+
+func (s *Server) validateCheckRequest(req application.CheckRequest) error {
+	if strings.TrimSpace(req.Subject) == "" {
+		return newBadRequestError("subject", "subject is required.")
+	}
+
+	if strings.TrimSpace(req.Operation) == "" {
+		return newBadRequestError("operation", "operation is required.")
+	}
+
+	if strings.TrimSpace(req.ResourceType) == "" {
+		return newBadRequestError("resourcetype", "resourcetype is required.")
+	}
+
+	if strings.TrimSpace(req.ResourceID) == "" {
+		return newBadRequestError("resourceid", "resourceid is required.")
+	}
+
+	return s.validateResourceType(req.ResourceType)
+}
+
+func (s *Server) validateLookupResourcesRequest(req application.LookupResourcesRequest) error {
+	if strings.TrimSpace(req.Subject) == "" {
+		return newBadRequestError("subject", "subject is required.")
+	}
+
+	if strings.TrimSpace(req.Operation) == "" {
+		return newBadRequestError("operation", "operation is required.")
+	}
+
+	if strings.TrimSpace(req.ResourceType) == "" {
+		return newBadRequestError("resourcetype", "resourcetype is required.")
+	}
+
+	return s.validateResourceType(req.ResourceType)
+}
+
+func (s *Server) validateLookupSubjectsRequest(req application.LookupSubjectsRequest) error {
+	if strings.TrimSpace(req.Operation) == "" {
+		return newBadRequestError("operation", "operation is required.")
+	}
+
+	if strings.TrimSpace(req.ResourceType) == "" {
+		return newBadRequestError("resourcetype", "resourcetype is required.")
+	}
+
+	if strings.TrimSpace(req.ResourceID) == "" {
+		return newBadRequestError("resourceid", "resourceid is required.")
+	}
+
+	return s.validateResourceType(req.ResourceType)
+}
+
+// validateResourceType rejects resource types that are not in the configured allowed resource types, if any are configured
+func (s *Server) validateResourceType(resourceType string) error {
+	if s.ServerConfig != nil && len(s.ServerConfig.AllowedResourceTypes) > 0 {
+		for _, allowed := range s.ServerConfig.AllowedResourceTypes {
+			if resourceType == allowed {
+				return nil
 			}
 		}
+		return newBadRequestError("resourcetype", fmt.Sprintf("resourcetype %s is not supported.", resourceType))
 	}
 
-	return "", nil
+	return nil
 }
 
-func convertTokenToPrincipalID(token string) (string, error) {
-	return token, nil //Placeholder for token introspection
+// authenticationRequired lists the RPCs that reject anonymous requestors with Unauthenticated before any processing.
+// RPCs not listed (CheckPermission, GetLicense) pass anonymous requestors on, so the application layer decides whether to allow them.
+var authenticationRequired = map[string]bool{
+	"BatchCheckPermission":  true,
+	"LookupResources":       true,
+	"LookupSubjects":        true,
+	"ModifySeats":           true,
+	"BulkAssignSeats":       true,
+	"GetSeats":              true,
+	"GetOrgSeatSummary":     true,
+	"CreateLicense":         true,
+	"DeleteLicense":         true,
+	"SetLicenseSeats":       true,
+	"ReconcileSeats":        true,
+	"UnassignAllForSubject": true,
+	"WriteRelationship":     true,
+	"DeleteRelationship":    true,
+	"ReadRelationships":     true,
 }
 
-func convertDomainErrorToGrpc(err error) error {
-	switch {
-	case errors.Is(err, domain.ErrNotAuthenticated):
-		return status.Error(codes.Unauthenticated, "Anonymous access is not allowed.")
-	case errors.Is(err, domain.ErrNotAuthorized):
-		return status.Error(codes.PermissionDenied, "Access denied.")
-	default:
-		return status.Error(codes.Unknown, "Internal server error.")
+// authenticate returns the requestor identity, or ErrNotAuthenticated as a grpc error if there is none and the given RPC requires authentication.
+func (s *Server) authenticate(ctx context.Context, rpc string) (string, error) {
+	requestor, err := s.getRequestorIdentityFromGrpcContext(ctx)
+	if err != nil {
+		return "", err
 	}
+
+	if requestor == "" && authenticationRequired[rpc] {
+		return "", convertDomainErrorToGrpc(domain.ErrNotAuthenticated)
+	}
+
+	return requestor, nil
+}
+
+func (s *Server) getRequestorIdentityFromGrpcContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	sources := DefaultIdentitySources
+	if s.ServerConfig != nil && len(s.ServerConfig.IdentitySources) > 0 {
+		sources = s.ServerConfig.IdentitySources
+	}
+
+	for _, source := range sources {
+		headers := md.Get(source.Header)
+		if len(headers) == 0 {
+			continue
+		}
+
+		extract, ok := s.IdentityExtractors[source.Strategy]
+		if !ok && source.Strategy == BearerStrategy && s.ServerConfig != nil && s.ServerConfig.InsecureDevAuth {
+			extract, ok = convertTokenToPrincipalID, true
+		}
+		if !ok {
+			extract, ok = DefaultIdentityExtractors[source.Strategy]
+		}
+		if !ok {
+			glog.Errorf("Unknown identity strategy %s configured for header %s", source.Strategy, source.Header)
+			return "", status.Error(codes.Internal, "Internal server error.")
+		}
+
+		requestor, err := extract(headers[0])
+		if err != nil {
+			return "", convertDomainErrorToGrpc(err)
+		}
+		return requestor, nil
+	}
+
+	return "", nil
 }
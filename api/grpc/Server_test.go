@@ -0,0 +1,990 @@
+package grpc
+
+import (
+	"authz/api"
+	core "authz/api/gen/v1alpha"
+	"authz/application"
+	"authz/domain"
+	"authz/domain/contracts"
+	"authz/domain/services"
+	"authz/infrastructure/repository/mock"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestCheckPermissionRejectsMissingSubject(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Operation:    "use",
+		Resourcetype: "service",
+		Resourceid:   "smarts",
+	})
+
+	assertInvalidArgument(t, err, "subject is required.")
+}
+
+func TestCheckPermissionRejectsMissingOperation(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Subject:      "okay",
+		Resourcetype: "service",
+		Resourceid:   "smarts",
+	})
+
+	assertInvalidArgument(t, err, "operation is required.")
+}
+
+func TestCheckPermissionRejectsMissingResourceType(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Subject:    "okay",
+		Operation:  "use",
+		Resourceid: "smarts",
+	})
+
+	assertInvalidArgument(t, err, "resourcetype is required.")
+}
+
+func TestCheckPermissionRejectsMissingResourceID(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Subject:      "okay",
+		Operation:    "use",
+		Resourcetype: "service",
+	})
+
+	assertInvalidArgument(t, err, "resourceid is required.")
+}
+
+func TestCheckPermissionRejectsResourceTypeNotInAllowlist(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{AllowedResourceTypes: []string{"license"}})
+
+	_, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Subject:      "okay",
+		Operation:    "use",
+		Resourcetype: "service",
+		Resourceid:   "smarts",
+	})
+
+	assertInvalidArgument(t, err, "resourcetype service is not supported.")
+}
+
+func TestCheckPermissionAcceptsResourceTypeInAllowlist(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{AllowedResourceTypes: []string{"service"}})
+
+	resp, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Subject:      "okay",
+		Operation:    "op",
+		Resourcetype: "service",
+		Resourceid:   "smarts",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Result)
+}
+
+func TestCheckPermissionAppliesDefaultOperationAndResourceType(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{DefaultOperation: "access", DefaultResourceType: "license", AllowedResourceTypes: []string{"license"}})
+
+	resp, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Subject:    "okay",
+		Resourceid: "o1/smarts",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Result)
+}
+
+func TestCheckPermissionExplicitValuesOverrideDefaults(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{DefaultOperation: "access", DefaultResourceType: "license", AllowedResourceTypes: []string{"license"}})
+
+	_, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Subject:      "okay",
+		Resourcetype: "service",
+		Resourceid:   "smarts",
+	})
+
+	assertInvalidArgument(t, err, "resourcetype service is not supported.")
+}
+
+func TestCheckPermissionRequiresOperationWithoutDefault(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{DefaultResourceType: "license"})
+
+	_, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{
+		Subject:    "okay",
+		Resourceid: "o1/smarts",
+	})
+
+	assertInvalidArgument(t, err, "operation is required.")
+}
+
+func TestBatchCheckPermissionReportsErrorsPerCheck(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	resp, err := srv.BatchCheckPermission(getContext("system"), &core.BatchCheckPermissionRequest{Checks: []*core.CheckPermissionRequest{
+		{Subject: "okay", Operation: "read", Resourcetype: "service", Resourceid: "smarts"},
+		{Operation: "read", Resourcetype: "service", Resourceid: "smarts"},
+		{Subject: "bad", Operation: "read", Resourcetype: "service", Resourceid: "smarts"},
+	}})
+
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Results, 3) {
+		assert.True(t, resp.Results[0].Result)
+		assert.Empty(t, resp.Results[0].Error)
+		assert.Equal(t, "subject is required.", resp.Results[1].Error)
+		assert.Equal(t, ReasonInvalidArgument, resp.Results[1].Reason)
+		assert.False(t, resp.Results[2].Result)
+		assert.Empty(t, resp.Results[2].Error, "The invalid check should not have failed the others.")
+	}
+}
+
+func TestCheckPermissionReturnsObligations(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	var accessRepo contracts.AccessRepository = &obligingAccessRepository{StubAccessRepository: mock.StubAccessRepository{Data: map[domain.SubjectID]bool{"system": true}}}
+	srv.AccessAppService = application.NewAccessAppService(&accessRepo, &mock.StubPrincipalRepository{})
+
+	resp, err := srv.CheckPermission(getContext("system"), &core.CheckPermissionRequest{Subject: "okay", Operation: "use", Resourcetype: "service", Resourceid: "smarts"})
+	assert.NoError(t, err)
+	assert.True(t, resp.Result)
+	assert.Equal(t, []string{"log"}, resp.Obligations)
+
+	batch, err := srv.BatchCheckPermission(getContext("system"), &core.BatchCheckPermissionRequest{Checks: []*core.CheckPermissionRequest{
+		{Subject: "okay", Operation: "use", Resourcetype: "service", Resourceid: "smarts"},
+	}})
+	assert.NoError(t, err)
+	if assert.Len(t, batch.Results, 1) {
+		assert.Equal(t, []string{"log"}, batch.Results[0].Obligations)
+	}
+}
+
+func TestBatchCheckPermissionRejectsAnonymousRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.BatchCheckPermission(context.Background(), &core.BatchCheckPermissionRequest{Checks: []*core.CheckPermissionRequest{
+		{Subject: "okay", Operation: "use", Resourcetype: "service", Resourceid: "smarts"},
+	}})
+
+	assertUnauthenticated(t, err)
+}
+
+func TestLookupResourcesReturnsLicensedServices(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+		Assign:    []string{"okay"},
+	})
+	assert.NoError(t, err)
+
+	resp, err := srv.LookupResources(getContext("system"), &core.LookupResourcesRequest{Subject: "okay", Operation: "use", Resourcetype: "service"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"smarts"}, resp.Resourceids)
+
+	resp, err = srv.LookupResources(getContext("system"), &core.LookupResourcesRequest{Subject: "bad", Operation: "use", Resourcetype: "service"})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Resourceids)
+}
+
+func TestLookupResourcesRejectsAnonymousRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.LookupResources(context.Background(), &core.LookupResourcesRequest{Subject: "okay", Operation: "use", Resourcetype: "service"})
+
+	assertUnauthenticated(t, err)
+}
+
+func TestLookupResourcesRequiresSubject(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.LookupResources(getContext("system"), &core.LookupResourcesRequest{Operation: "use", Resourcetype: "service"})
+
+	assertInvalidArgument(t, err, "subject is required.")
+}
+
+func TestLookupSubjectsReturnsSubjectsToAuditors(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.AccessAppService.SetAuditOperation("audit")
+
+	resp, err := srv.LookupSubjects(getContext("system"), &core.LookupSubjectsRequest{Operation: "read", Resourcetype: "service", Resourceid: "smarts"})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"system", "okay"}, resp.Subjects)
+}
+
+func TestLookupSubjectsRejectsUnauthorizedRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.AccessAppService.SetAuditOperation("audit")
+
+	_, err := srv.LookupSubjects(getContext("bad"), &core.LookupSubjectsRequest{Operation: "read", Resourcetype: "service", Resourceid: "smarts"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestLookupSubjectsRejectsAllRequestorsWithoutAuditOperation(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.LookupSubjects(getContext("system"), &core.LookupSubjectsRequest{Operation: "read", Resourcetype: "service", Resourceid: "smarts"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestModifySeatsRejectsAnonymousRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.ModifySeats(context.Background(), &core.ModifySeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+		Assign:    []string{"okay"},
+	})
+
+	assertUnauthenticated(t, err)
+}
+
+func TestModifySeatsRejectsUnauthorizedRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetManageOperation("manage_licenses")
+
+	_, err := srv.ModifySeats(getContext("bad"), &core.ModifySeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+		Assign:    []string{"okay"},
+	})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestGetSeatsSortsUsers(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+		Assign:    []string{"okay", "zed", "bad"},
+	})
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		sortBy     *core.SeatSortField
+		descending bool
+		expected   []string
+	}{
+		{nil, false, []string{"bad", "okay", "zed"}},
+		{core.SeatSortField_displayName.Enum(), false, []string{"zed", "bad", "okay"}},
+		{core.SeatSortField_displayName.Enum(), true, []string{"okay", "bad", "zed"}},
+		{core.SeatSortField_id.Enum(), true, []string{"zed", "okay", "bad"}},
+	} {
+		resp, err := srv.GetSeats(getContext("system"), &core.GetSeatsRequest{
+			OrgId:      "aspian",
+			ServiceId:  "smarts",
+			SortBy:     tc.sortBy,
+			Descending: &tc.descending,
+		})
+		if assert.NoError(t, err) {
+			ids := make([]string, len(resp.Users))
+			for i, user := range resp.Users {
+				ids[i] = user.Id
+			}
+			assert.Equal(t, tc.expected, ids)
+		}
+	}
+}
+
+func TestGetSeatsReturnsOnlyReclaimableSeats(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+		Assign:    []string{"okay", "gone"},
+	})
+	assert.NoError(t, err)
+
+	resp, err := srv.GetSeats(getContext("system"), &core.GetSeatsRequest{OrgId: "aspian", ServiceId: "smarts"})
+	if assert.NoError(t, err) && assert.Len(t, resp.Users, 2) {
+		assert.True(t, resp.Users[0].Reclaimable, "The disabled user should hold a reclaimable seat.")
+		assert.False(t, resp.Users[1].Reclaimable)
+	}
+
+	onlyReclaimable := true
+	resp, err = srv.GetSeats(getContext("system"), &core.GetSeatsRequest{OrgId: "aspian", ServiceId: "smarts", OnlyReclaimable: &onlyReclaimable})
+	if assert.NoError(t, err) && assert.Len(t, resp.Users, 1) {
+		assert.Equal(t, "gone", resp.Users[0].Id)
+		assert.True(t, resp.Users[0].Reclaimable)
+	}
+}
+
+func TestGetSeatsRejectsReclaimableAssignableSeats(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	onlyReclaimable := true
+	_, err := srv.GetSeats(getContext("system"), &core.GetSeatsRequest{
+		OrgId:           "aspian",
+		ServiceId:       "smarts",
+		Filter:          core.SeatFilterType_assignable.Enum(),
+		OnlyReclaimable: &onlyReclaimable,
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetSeatsRejectsUnknownSortField(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.GetSeats(getContext("system"), &core.GetSeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+		SortBy:    core.SeatSortField(42).Enum(),
+	})
+
+	assertInvalidArgument(t, err, "sortBy 42 is not supported.")
+}
+
+func TestGetSeatsRejectsUnauthorizedRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetViewOperation("view_licenses")
+
+	_, err := srv.GetSeats(getContext("bad"), &core.GetSeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+	})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestGetOrgSeatSummaryAddsUpTheOrgsLicenses(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+		Assign:    []string{"okay"},
+	})
+	assert.NoError(t, err)
+
+	resp, err := srv.GetOrgSeatSummary(getContext("system"), &core.GetOrgSeatSummaryRequest{OrgId: "aspian"})
+
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Services, 1) {
+		assert.Equal(t, "smarts", resp.Services[0].ServiceId)
+		assert.Equal(t, int32(20), resp.Services[0].SeatsTotal)
+		assert.Equal(t, int32(1), resp.Services[0].SeatsInUse)
+		assert.Equal(t, int32(19), resp.Services[0].SeatsAvailable)
+	}
+	assert.Equal(t, int32(20), resp.SeatsTotal)
+	assert.Equal(t, int32(1), resp.SeatsInUse)
+	assert.Equal(t, int32(19), resp.SeatsAvailable)
+}
+
+func TestGetOrgSeatSummaryRejectsUnauthorizedRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetViewOperation("view_licenses")
+
+	_, err := srv.GetOrgSeatSummary(getContext("bad"), &core.GetOrgSeatSummaryRequest{OrgId: "aspian"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestGetLicenseRejectsUnauthorizedRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetViewOperation("view_licenses")
+
+	_, err := srv.GetLicense(getContext("bad"), &core.GetLicenseRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+	})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestGetLicenseAllowsAuthorizedRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetViewOperation("view_licenses")
+
+	resp, err := srv.GetLicense(getContext("okay"), &core.GetLicenseRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(20), resp.SeatsTotal)
+}
+
+func TestGetSeatsRejectsAnonymousRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.GetSeats(context.Background(), &core.GetSeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+	})
+
+	assertUnauthenticated(t, err)
+}
+
+func TestModifySeatsRejectsTooManyChanges(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(&api.ServerConfig{MaxSeatChanges: 2})
+
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{
+		OrgId:     "aspian",
+		ServiceId: "smarts",
+		Assign:    []string{"okay", "bad"},
+		Unassign:  []string{"system"},
+	})
+
+	assertInvalidArgument(t, err, "at most 2 seats can be assigned or unassigned per request.")
+}
+
+func assertUnauthenticated(t *testing.T, err error) {
+	if assert.Error(t, err) {
+		st, ok := status.FromError(err)
+		assert.True(t, ok, "Should have been a grpc status error.")
+		assert.Equal(t, codes.Unauthenticated, st.Code())
+	}
+}
+
+func assertInvalidArgument(t *testing.T, err error, message string) {
+	if assert.Error(t, err) {
+		st, ok := status.FromError(err)
+		assert.True(t, ok, "Should have been a grpc status error.")
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, message, st.Message())
+	}
+}
+
+func TestGetSeatsRoundTripsLargeResponseWithGzip(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 2000))
+	principals := &mock.StubPrincipalRepository{Principals: map[domain.SubjectID]domain.Principal{}}
+	subjects := make([]domain.SubjectID, 1000)
+	for i := range subjects {
+		subjects[i] = domain.SubjectID(fmt.Sprintf("u%04d", i))
+		principals.Principals[subjects[i]] = domain.NewPrincipal(subjects[i], strings.Repeat("Display Name ", 10)+string(subjects[i]), "aspian")
+	}
+	assert.NoError(t, store.AssignSeats(context.Background(), subjects, "aspian", domain.Service{ID: "smarts"}))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	srv := &Server{
+		AccessAppService:  application.NewAccessAppService(&accessRepo, principals),
+		LicenseAppService: application.NewLicenseAppService(&accessRepo, &seatRepo, principals),
+		ServerConfig:      &api.ServerConfig{InsecureDevAuth: true},
+	}
+
+	conn := dialTestServer(t, srv)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "grpcgateway-authorization", "system")
+	resp, err := core.NewLicenseServiceClient(conn).GetSeats(ctx, &core.GetSeatsRequest{OrgId: "aspian", ServiceId: "smarts"}, grpc.UseCompressor(gzip.Name))
+
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Users, 1000) {
+		names := make(map[string]string, len(resp.Users))
+		for _, user := range resp.Users {
+			names[user.Id] = user.DisplayName
+		}
+		assert.Equal(t, strings.Repeat("Display Name ", 10)+"u0999", names["u0999"])
+	}
+}
+
+func TestCreateLicenseProvisionsLicense(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetAdminOperation("administer_licenses")
+
+	_, err := srv.CreateLicense(getContext("system"), &core.CreateLicenseRequest{OrgId: "aspian", ServiceId: "new", SeatsTotal: 5})
+	assert.NoError(t, err)
+
+	lic, err := srv.GetLicense(getContext("system"), &core.GetLicenseRequest{OrgId: "aspian", ServiceId: "new"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), lic.SeatsTotal)
+	assert.Equal(t, int32(5), lic.SeatsAvailable)
+}
+
+func TestCreateLicenseRejectsExistingLicense(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetAdminOperation("administer_licenses")
+
+	_, err := srv.CreateLicense(getContext("system"), &core.CreateLicenseRequest{OrgId: "aspian", ServiceId: "smarts", SeatsTotal: 5})
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestCreateLicenseRequiresAdminOperation(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.CreateLicense(getContext("system"), &core.CreateLicenseRequest{OrgId: "aspian", ServiceId: "new", SeatsTotal: 5})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestDeleteLicenseReleasesSeats(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetAdminOperation("administer_licenses")
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{OrgId: "aspian", ServiceId: "smarts", Assign: []string{"okay", "zed"}})
+	assert.NoError(t, err)
+
+	resp, err := srv.DeleteLicense(getContext("system"), &core.DeleteLicenseRequest{OrgId: "aspian", ServiceId: "smarts"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.SeatsReleased)
+
+	seats, err := srv.GetSeats(getContext("system"), &core.GetSeatsRequest{OrgId: "aspian", ServiceId: "smarts"})
+	assert.NoError(t, err)
+	assert.Empty(t, seats.Users, "No seats should have been left.")
+
+	resp, err = srv.DeleteLicense(getContext("system"), &core.DeleteLicenseRequest{OrgId: "aspian", ServiceId: "smarts"})
+	assert.NoError(t, err, "Deleting again should not be an error.")
+	assert.Zero(t, resp.SeatsReleased)
+}
+
+func TestDeleteLicenseRequiresAdminOperation(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.DeleteLicense(getContext("system"), &core.DeleteLicenseRequest{OrgId: "aspian", ServiceId: "smarts"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestSetLicenseSeatsChangesSeatLimit(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetAdminOperation("administer_licenses")
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{OrgId: "aspian", ServiceId: "smarts", Assign: []string{"okay", "zed"}})
+	assert.NoError(t, err)
+
+	for _, seats := range []int32{30, 2} { //increase, then decrease to the seats in use
+		_, err = srv.SetLicenseSeats(getContext("system"), &core.SetLicenseSeatsRequest{OrgId: "aspian", ServiceId: "smarts", SeatsTotal: seats})
+		assert.NoError(t, err)
+
+		lic, err := srv.GetLicense(getContext("system"), &core.GetLicenseRequest{OrgId: "aspian", ServiceId: "smarts"})
+		assert.NoError(t, err)
+		assert.Equal(t, seats, lic.SeatsTotal)
+		assert.Equal(t, seats-2, lic.SeatsAvailable)
+	}
+}
+
+func TestSetLicenseSeatsRejectsLimitBelowSeatsInUse(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetAdminOperation("administer_licenses")
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{OrgId: "aspian", ServiceId: "smarts", Assign: []string{"okay", "zed"}})
+	assert.NoError(t, err)
+
+	_, err = srv.SetLicenseSeats(getContext("system"), &core.SetLicenseSeatsRequest{OrgId: "aspian", ServiceId: "smarts", SeatsTotal: 1})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	info := getErrorInfo(t, status.Convert(err))
+	assert.Equal(t, ReasonSeatLimitBelowInUse, info.Reason)
+	assert.Equal(t, map[string]string{"maxSeats": "1", "inUse": "2"}, info.Metadata)
+}
+
+func TestSetLicenseSeatsRequiresAdminOperation(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.SetLicenseSeats(getContext("system"), &core.SetLicenseSeatsRequest{OrgId: "aspian", ServiceId: "smarts", SeatsTotal: 30})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestReconcileSeatsRemovesSeatsOfNonMembers(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetAdminOperation("administer_licenses")
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{OrgId: "aspian", ServiceId: "smarts", Assign: []string{"okay", "system"}})
+	assert.NoError(t, err)
+
+	resp, err := srv.ReconcileSeats(getContext("system"), &core.ReconcileSeatsRequest{OrgId: "aspian", ServiceId: "smarts", Repair: true})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.HasDiscrepancies)
+	assert.Equal(t, []string{"system"}, resp.NonMembers)
+	assert.True(t, resp.Repaired)
+	assert.Equal(t, int32(1), resp.SeatsInUse)
+}
+
+func TestReconcileSeatsRequiresAdminOperation(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.ReconcileSeats(getContext("system"), &core.ReconcileSeatsRequest{OrgId: "aspian", ServiceId: "smarts"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestReconcileSeatsRejectsAnonymousRequestor(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.ReconcileSeats(context.Background(), &core.ReconcileSeatsRequest{OrgId: "aspian", ServiceId: "smarts"})
+
+	assertUnauthenticated(t, err)
+}
+
+func TestUnassignAllForSubjectRemovesSeats(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+	srv.LicenseAppService.SetAdminOperation("administer_licenses")
+	_, err := srv.ModifySeats(getContext("system"), &core.ModifySeatsRequest{OrgId: "aspian", ServiceId: "smarts", Assign: []string{"okay"}})
+	assert.NoError(t, err)
+
+	resp, err := srv.UnassignAllForSubject(getContext("system"), &core.UnassignAllForSubjectRequest{OrgId: "aspian", SubjectId: "okay"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"smarts"}, resp.ServiceIds)
+
+	resp, err = srv.UnassignAllForSubject(getContext("system"), &core.UnassignAllForSubjectRequest{OrgId: "aspian", SubjectId: "okay"})
+	assert.NoError(t, err, "Unassigning again should not be an error.")
+	assert.Empty(t, resp.ServiceIds)
+}
+
+func TestUnassignAllForSubjectRequiresAdminOperation(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.UnassignAllForSubject(getContext("system"), &core.UnassignAllForSubjectRequest{OrgId: "aspian", SubjectId: "okay"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestUnassignAllForSubjectRejectsMissingSubject(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.UnassignAllForSubject(getContext("system"), &core.UnassignAllForSubjectRequest{OrgId: "aspian"})
+
+	assertInvalidArgument(t, err, "subjectId is required.")
+}
+
+func TestWriteRelationshipWritesAllowedRelationship(t *testing.T) {
+	t.Parallel()
+	srv, relationships := createRelationshipTestServer()
+
+	_, err := srv.WriteRelationship(getContext("system"), &core.WriteRelationshipRequest{ResourceType: "group", ResourceId: "g1", Relation: "member", SubjectType: "user", SubjectId: "u1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"group:g1#member@user:u1"}, relationships.written)
+}
+
+func TestDeleteRelationshipDeletesAllowedRelationship(t *testing.T) {
+	t.Parallel()
+	srv, relationships := createRelationshipTestServer()
+
+	_, err := srv.DeleteRelationship(getContext("system"), &core.DeleteRelationshipRequest{ResourceType: "group", ResourceId: "g1", Relation: "member", SubjectType: "user", SubjectId: "u1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"group:g1#member@user:u1"}, relationships.deleted)
+}
+
+func TestWriteRelationshipRejectsKindNotAllowed(t *testing.T) {
+	t.Parallel()
+	srv, relationships := createRelationshipTestServer()
+
+	_, err := srv.WriteRelationship(getContext("system"), &core.WriteRelationshipRequest{ResourceType: "license_seats", ResourceId: "aspian/smarts", Relation: "assigned", SubjectType: "user", SubjectId: "u1"})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	assert.Equal(t, ReasonRelationNotAllowed, getErrorInfo(t, st).Reason)
+	assert.Empty(t, relationships.written)
+}
+
+func TestWriteRelationshipRequiresAdminOperation(t *testing.T) {
+	t.Parallel()
+	srv, relationships := createRelationshipTestServer()
+
+	_, err := srv.WriteRelationship(getContext("bad"), &core.WriteRelationshipRequest{ResourceType: "group", ResourceId: "g1", Relation: "member", SubjectType: "user", SubjectId: "u1"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Empty(t, relationships.written)
+}
+
+func TestWriteRelationshipRejectsAnonymousRequestor(t *testing.T) {
+	t.Parallel()
+	srv, _ := createRelationshipTestServer()
+
+	_, err := srv.WriteRelationship(context.Background(), &core.WriteRelationshipRequest{ResourceType: "group", ResourceId: "g1", Relation: "member", SubjectType: "user", SubjectId: "u1"})
+
+	assertUnauthenticated(t, err)
+}
+
+func TestDeleteRelationshipRejectsIncompleteRelationship(t *testing.T) {
+	t.Parallel()
+	srv, relationships := createRelationshipTestServer()
+
+	_, err := srv.DeleteRelationship(getContext("system"), &core.DeleteRelationshipRequest{ResourceType: "group", ResourceId: "g1", Relation: "member", SubjectType: "user"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Empty(t, relationships.deleted)
+}
+
+func TestReadRelationshipsReturnsPage(t *testing.T) {
+	t.Parallel()
+	srv, relationships := createRelationshipTestServer()
+
+	resp, err := srv.ReadRelationships(getContext("system"), &core.ReadRelationshipsRequest{ResourceType: "group", ResourceId: "g1", Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, relationships.limit)
+	if assert.Len(t, resp.Relationships, 1) {
+		assert.Equal(t, &core.Relationship{ResourceType: "group", ResourceId: "g1", Relation: "member", SubjectType: "user", SubjectId: "u1"}, resp.Relationships[0])
+	}
+	assert.Equal(t, "next", resp.NextCursor)
+}
+
+func TestReadRelationshipsDefaultsAndRejectsLimits(t *testing.T) {
+	t.Parallel()
+	srv, relationships := createRelationshipTestServer()
+
+	_, err := srv.ReadRelationships(getContext("system"), &core.ReadRelationshipsRequest{ResourceType: "group"})
+	assert.NoError(t, err)
+	assert.Equal(t, services.DefaultRelationshipPageSize, relationships.limit)
+
+	_, err = srv.ReadRelationships(getContext("system"), &core.ReadRelationshipsRequest{ResourceType: "group", Limit: 1000000})
+	assert.NoError(t, err)
+	assert.Equal(t, services.MaxRelationshipPageSize, relationships.limit)
+
+	_, err = srv.ReadRelationships(getContext("system"), &core.ReadRelationshipsRequest{ResourceType: "group", Limit: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestReadRelationshipsRequiresAdminOperation(t *testing.T) {
+	t.Parallel()
+	srv, relationships := createRelationshipTestServer()
+
+	_, err := srv.ReadRelationships(getContext("bad"), &core.ReadRelationshipsRequest{ResourceType: "group"})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Zero(t, relationships.limit, "The relationships should not have been read.")
+}
+
+func TestRelationshipsAreUnimplementedWithoutSupportingStore(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer(nil)
+
+	_, err := srv.WriteRelationship(getContext("system"), &core.WriteRelationshipRequest{ResourceType: "group", ResourceId: "g1", Relation: "member", SubjectType: "user", SubjectId: "u1"})
+
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestBulkAssignSeatsStopsAtLicenseLimit(t *testing.T) {
+	t.Parallel()
+	conn := dialTestServer(t, createBulkAssignTestServer(t))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "grpcgateway-authorization", "system")
+
+	stream, err := core.NewLicenseServiceClient(conn).BulkAssignSeats(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&core.BulkAssignSeatsRequest{OrgId: "aspian", ServiceId: "smarts", Subjects: []string{"u1", "u2"}}))
+	assert.NoError(t, stream.Send(&core.BulkAssignSeatsRequest{Subjects: []string{"u3", "u4", "u5"}}))
+	resp, err := stream.CloseAndRecv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Assigned)
+	assert.Equal(t, int32(1), resp.SkippedAlreadyAssigned)
+	assert.Equal(t, int32(2), resp.SkippedLimit)
+	assert.True(t, resp.LimitReached)
+}
+
+func TestBulkAssignSeatsRejectsAnonymousRequestor(t *testing.T) {
+	t.Parallel()
+	conn := dialTestServer(t, createBulkAssignTestServer(t))
+
+	stream, err := core.NewLicenseServiceClient(conn).BulkAssignSeats(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&core.BulkAssignSeatsRequest{OrgId: "aspian", ServiceId: "smarts", Subjects: []string{"u2"}}))
+	_, err = stream.CloseAndRecv()
+
+	assertUnauthenticated(t, err)
+}
+
+func TestBulkAssignSeatsRejectsChangingLicense(t *testing.T) {
+	t.Parallel()
+	conn := dialTestServer(t, createBulkAssignTestServer(t))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "grpcgateway-authorization", "system")
+
+	stream, err := core.NewLicenseServiceClient(conn).BulkAssignSeats(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&core.BulkAssignSeatsRequest{OrgId: "aspian", ServiceId: "smarts", Subjects: []string{"u2"}}))
+	assert.NoError(t, stream.Send(&core.BulkAssignSeatsRequest{OrgId: "aspian", ServiceId: "other", Subjects: []string{"u3"}}))
+	_, err = stream.CloseAndRecv()
+
+	assertInvalidArgument(t, err, "all messages of the stream must be for the same license.")
+}
+
+// createBulkAssignTestServer returns a server whose license aspian/smarts has 3 seats, one of them assigned to u1
+func createBulkAssignTestServer(t *testing.T) *Server {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 3))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"u1"}, "aspian", domain.Service{ID: "smarts"}))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	principals := &mock.StubPrincipalRepository{DefaultOrg: "aspian"}
+
+	return &Server{
+		AccessAppService:  application.NewAccessAppService(&accessRepo, principals),
+		LicenseAppService: application.NewLicenseAppService(&accessRepo, &seatRepo, principals),
+		ServerConfig:      &api.ServerConfig{InsecureDevAuth: true},
+	}
+}
+
+// dialTestServer serves the license service of the given server in memory and returns a client connection to it. Both are closed when the test ends.
+func dialTestServer(t *testing.T, srv *Server) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcSrv := grpc.NewServer()
+	core.RegisterLicenseServiceServer(grpcSrv, srv)
+	go func() { _ = grpcSrv.Serve(listener) }()
+	t.Cleanup(grpcSrv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func getContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"grpcgateway-authorization": token,
+	}))
+}
+
+// obligingAccessRepository allows every check under the obligation to log it
+type obligingAccessRepository struct {
+	mock.StubAccessRepository
+}
+
+func (r *obligingAccessRepository) CheckAccess(context.Context, domain.SubjectID, string, domain.Resource) (domain.AccessDecision, error) {
+	return domain.Allow("log"), nil
+}
+
+func (r *obligingAccessRepository) CheckAccessBulk(_ context.Context, events []domain.CheckEvent) ([]domain.AccessDecision, []error) {
+	decisions := make([]domain.AccessDecision, len(events))
+	for i := range events {
+		decisions[i] = domain.Allow("log")
+	}
+	return decisions, make([]error, len(events))
+}
+
+// createRelationshipTestServer creates a test server that manages relationships in a fake store allowing only group#member@user, with the admin operation allowed for "system"
+func createRelationshipTestServer() (*Server, *fakeRelationshipRepository) {
+	srv := createTestServer(nil)
+	var accessRepo contracts.AccessRepository = &mock.StubAccessRepository{Data: map[domain.SubjectID]bool{"system": true, "bad": false}}
+	relationships := &fakeRelationshipRepository{}
+	srv.RelationshipAppService = application.NewRelationshipAppService(relationships, &accessRepo)
+	srv.RelationshipAppService.SetAdminOperation("administer")
+
+	return srv, relationships
+}
+
+type fakeRelationshipRepository struct {
+	written []string
+	deleted []string
+	limit   int
+}
+
+func (f *fakeRelationshipRepository) WriteRelationship(_ context.Context, relationship domain.Relationship) error {
+	if relationship.Resource.Type != "group" {
+		return domain.ErrRelationshipNotAllowed
+	}
+	f.written = append(f.written, relationship.String())
+	return nil
+}
+
+func (f *fakeRelationshipRepository) DeleteRelationship(_ context.Context, relationship domain.Relationship) error {
+	if relationship.Resource.Type != "group" {
+		return domain.ErrRelationshipNotAllowed
+	}
+	f.deleted = append(f.deleted, relationship.String())
+	return nil
+}
+
+func (f *fakeRelationshipRepository) ReadRelationships(_ context.Context, _ domain.RelationshipFilter, _ string, limit int) (*domain.RelationshipPage, error) {
+	f.limit = limit
+	return &domain.RelationshipPage{
+		Relationships: []domain.Relationship{{Resource: domain.Resource{Type: "group", ID: "g1"}, Relation: "member", Subject: domain.Resource{Type: "user", ID: "u1"}}},
+		NextCursor:    "next",
+	}, nil
+}
+
+func createTestServer(config *api.ServerConfig) *Server {
+	if config == nil {
+		config = &api.ServerConfig{}
+	}
+	config.InsecureDevAuth = true
+
+	var accessRepo contracts.AccessRepository = &mock.StubAccessRepository{Data: map[domain.SubjectID]bool{
+		"system": true,
+		"okay":   true,
+		"bad":    false,
+	},
+		LicensedSeats: map[string]map[domain.SubjectID]bool{},
+		Licenses: map[string]domain.License{
+			"smarts": *domain.NewLicense("aspian", "smarts", 20, 0),
+		},
+	}
+	licenseRepo, _ := accessRepo.(contracts.SeatLicenseRepository)
+	principalRepo := &mock.StubPrincipalRepository{
+		Principals: map[domain.SubjectID]domain.Principal{
+			"system": domain.NewPrincipal("system", "System User", "smarts"),
+			"okay":   domain.NewPrincipal("okay", "Okay User", "aspian"),
+			"bad":    domain.NewPrincipal("bad", "Bad User", "aspian"),
+			"zed":    domain.NewPrincipal("zed", "Alice Zed", "aspian"),
+			"gone":   {ID: "gone", DisplayName: "Former User", OrgID: "aspian", Disabled: true},
+		},
+		DefaultOrg: "aspian",
+	}
+
+	return &Server{
+		AccessAppService:  application.NewAccessAppService(&accessRepo, principalRepo),
+		LicenseAppService: application.NewLicenseAppService(&accessRepo, &licenseRepo, principalRepo),
+		ServerConfig:      config,
+	}
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIPath is where the OpenAPI document of the gateway is served
+const openAPIPath = "/openapi.json"
+
+// newOpenAPIHandler serves the given YAML OpenAPI document as JSON. The document is converted once, so a broken document fails at startup.
+func newOpenAPIHandler(spec []byte) (http.Handler, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("openapi document is not valid YAML: %w", err)
+	}
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("openapi document cannot be converted to JSON: %w", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}), nil
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPISpecIsServedAsValidDocument(t *testing.T) {
+	t.Parallel()
+	resp := runRequest(get("/openapi.json", ""))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Info    struct{ Title, Version string }       `json:"info"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+		Comps   struct {
+			Schemas         map[string]json.RawMessage `json:"schemas"`
+			SecuritySchemes map[string]json.RawMessage `json:"securitySchemes"`
+		} `json:"components"`
+	}
+	raw := new(strings.Builder)
+	_, _ = io.Copy(raw, resp.Body)
+	assert.NoError(t, json.Unmarshal([]byte(raw.String()), &doc))
+
+	assert.True(t, strings.HasPrefix(doc.OpenAPI, "3."), "Unexpected OpenAPI version %s", doc.OpenAPI)
+	assert.NotEmpty(t, doc.Info.Title)
+	assert.NotEmpty(t, doc.Info.Version)
+	assert.Contains(t, doc.Comps.SecuritySchemes, "BearerAuth")
+
+	for path, method := range map[string]string{
+		"/v1alpha/check":                                       "post",
+		"/v1alpha/check/batch":                                 "post",
+		"/v1alpha/lookup/resources":                            "post",
+		"/v1alpha/lookup/subjects":                             "post",
+		"/v1alpha/orgs/{orgId}/licenses":                       "get",
+		"/v1alpha/orgs/{orgId}/licenses/{serviceId}":           "get",
+		"/v1alpha/orgs/{orgId}/licenses/{serviceId}/seats":     "get",
+		"/v1alpha/orgs/{orgId}/licenses/{serviceId}/reconcile": "post",
+		"/v1alpha/orgs/{orgId}/users/{subjectId}/seats":        "delete",
+		"/v1alpha/relationships":                               "post",
+	} {
+		assert.Contains(t, doc.Paths[path], method, "Missing %s %s", method, path)
+	}
+	assert.Contains(t, doc.Paths["/v1alpha/orgs/{orgId}/licenses"], "post")
+	assert.Contains(t, doc.Paths["/v1alpha/orgs/{orgId}/licenses/{serviceId}"], "post")
+	assert.Contains(t, doc.Paths["/v1alpha/orgs/{orgId}/licenses/{serviceId}"], "put")
+	assert.Contains(t, doc.Paths["/v1alpha/orgs/{orgId}/licenses/{serviceId}"], "delete")
+	assert.Contains(t, doc.Paths["/v1alpha/relationships"], "delete")
+	assert.Contains(t, doc.Paths["/v1alpha/relationships"], "get")
+
+	for _, ref := range findRefs(raw.String()) {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		assert.Contains(t, doc.Comps.Schemas, name, "Unresolved reference %s", ref)
+	}
+}
+
+func TestOpenAPISpecRejectsOtherMethods(t *testing.T) {
+	t.Parallel()
+	resp := runRequest(post("/openapi.json", "", ""))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func findRefs(doc string) []string {
+	refs := make([]string, 0)
+	for _, part := range strings.Split(doc, `"$ref":"`)[1:] {
+		refs = append(refs, part[:strings.Index(part, `"`)])
+	}
+	return refs
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/golang/glog"
@@ -16,16 +17,22 @@ import (
 
 // Server serves a HTTP api based on the generated grpc gateway code
 type Server struct {
-	ServerConfig       *api.ServerConfig
-	GrpcCheckService   core.CheckPermissionServer
-	GrpcLicenseService core.LicenseServiceServer
+	ServerConfig            *api.ServerConfig
+	GrpcCheckService        core.CheckPermissionServer
+	GrpcLicenseService      core.LicenseServiceServer
+	GrpcRelationshipService core.RelationshipServiceServer
 }
 
 // Serve starts serving
 func (s *Server) Serve(wait *sync.WaitGroup) error {
 	defer wait.Done()
 
-	mux, err := createMultiplexer(s.GrpcCheckService, s.GrpcLicenseService)
+	forwardedHeaders := make([]string, 0)
+	for _, source := range s.ServerConfig.IdentitySources {
+		forwardedHeaders = append(forwardedHeaders, source.Header)
+	}
+
+	mux, err := createMultiplexer(s.GrpcCheckService, s.GrpcLicenseService, s.GrpcRelationshipService, s.ServerConfig.CORSConfig, forwardedHeaders...)
 	if err != nil {
 		glog.Errorf("Error creating multiplexer: %s", err)
 		return err
@@ -68,6 +75,11 @@ func (s *Server) SetSeatRef(ss core.LicenseServiceServer) {
 	s.GrpcLicenseService = ss
 }
 
+// SetRelationshipRef sets the reference to the grpc RelationshipService
+func (s *Server) SetRelationshipRef(rs core.RelationshipServiceServer) {
+	s.GrpcRelationshipService = rs
+}
+
 // NewServer creates a new Server object to use.
 func NewServer(c api.ServerConfig) *Server {
 	return &Server{
@@ -80,8 +92,16 @@ func (s *Server) GetName() string {
 	return "grpcweb"
 }
 
-func createMultiplexer(h1 core.CheckPermissionServer, h2 core.LicenseServiceServer) (http.Handler, error) {
-	mux := runtime.NewServeMux()
+// createMultiplexer creates the gateway handler, which also serves the OpenAPI document at /openapi.json. Besides the gateway's default headers, the given headers are forwarded to the grpc services as metadata, ex: identity headers.
+func createMultiplexer(h1 core.CheckPermissionServer, h2 core.LicenseServiceServer, h3 core.RelationshipServiceServer, corsConfig api.CORSConfig, forwardedHeaders ...string) (http.Handler, error) {
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+		for _, header := range forwardedHeaders {
+			if strings.EqualFold(key, header) {
+				return strings.ToLower(header), true
+			}
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}))
 
 	if err := core.RegisterCheckPermissionHandlerServer(context.Background(), mux, h1); err != nil {
 		return nil, err
@@ -91,19 +111,45 @@ func createMultiplexer(h1 core.CheckPermissionServer, h2 core.LicenseServiceServ
 		return nil, err
 	}
 
-	chain := createChain(logMiddleware, corsMiddleware).then(mux)
+	if err := core.RegisterRelationshipServiceHandlerServer(context.Background(), mux, h3); err != nil {
+		return nil, err
+	}
+
+	spec, err := newOpenAPIHandler(api.OpenAPISpec)
+	if err != nil {
+		return nil, err
+	}
+
+	root := http.NewServeMux()
+	root.Handle(openAPIPath, spec)
+	root.Handle("/", mux)
+
+	chain := createChain(logMiddleware, corsMiddleware(corsConfig, forwardedHeaders)).then(root)
 
 	return chain, nil
 }
 
-func corsMiddleware(h http.Handler) http.Handler {
-	return cors.New(cors.Options{
-		AllowedMethods:   []string{http.MethodHead, http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete, http.MethodOptions},
-		AllowedHeaders:   []string{"Accept", "ResponseType", "Content-Length", "Accept-Encoding", "Authorization", "Content-Type"},
-		AllowCredentials: true,
+// corsMiddleware answers preflight requests and sets the CORS headers of cross-origin requests. Without allowed origins, no origin is allowed.
+func corsMiddleware(c api.CORSConfig, identityHeaders []string) middleware {
+	options := cors.Options{
+		AllowedOrigins:   c.AllowedOrigins,
+		AllowedMethods:   c.AllowedMethods,
+		AllowedHeaders:   c.AllowedHeaders,
+		AllowCredentials: c.AllowCredentials,
 		MaxAge:           300,
 		Debug:            true,
-	}).Handler(h)
+	}
+	if len(options.AllowedOrigins) == 0 {
+		options.AllowOriginFunc = func(string) bool { return false }
+	}
+	if len(options.AllowedMethods) == 0 {
+		options.AllowedMethods = []string{http.MethodHead, http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete}
+	}
+	if len(options.AllowedHeaders) == 0 {
+		options.AllowedHeaders = append([]string{"Accept", "ResponseType", "Content-Length", "Accept-Encoding", "Authorization", "Content-Type"}, identityHeaders...)
+	}
+
+	return cors.New(options).Handler
 }
 
 func logMiddleware(h http.Handler) http.Handler {
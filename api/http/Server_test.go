@@ -1,6 +1,7 @@
 package http
 
 import (
+	"authz/api"
 	"authz/api/grpc"
 	"authz/application"
 	"authz/domain"
@@ -11,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kinbiko/jsonassert"
 	"github.com/stretchr/testify/assert"
@@ -38,7 +40,7 @@ func TestCheckReturnsTrueWhenUserAuthorized(t *testing.T) {
 	resp := runRequest(post("/v1alpha/check", "system",
 		`{"subject": "okay", "operation": "op", "resourcetype": "Feature", "resourceid": "smarts"}`))
 
-	assertJSONResponse(t, resp, 200, `{"result": %t, "description": ""}`, true)
+	assertJSONResponse(t, resp, 200, `{"result": %t, "description": "", "obligations": []}`, true)
 }
 
 func TestCheckReturnsFalseWhenUserNotAuthorized(t *testing.T) {
@@ -46,7 +48,7 @@ func TestCheckReturnsFalseWhenUserNotAuthorized(t *testing.T) {
 	resp := runRequest(post("/v1alpha/check", "system",
 		`{"subject": "bad", "operation": "op", "resourcetype": "Feature", "resourceid": "smarts"}`))
 
-	assertJSONResponse(t, resp, 200, `{"result": %t, "description": ""}`, false)
+	assertJSONResponse(t, resp, 200, `{"result": %t, "description": "", "obligations": []}`, false)
 }
 
 func TestAssignLicenseReturnsSuccess(t *testing.T) {
@@ -81,7 +83,7 @@ func TestGrantedLicenseAllowsUse(t *testing.T) {
 	resp := runRequestWithServer(post("/v1alpha/check", "system",
 		`{"subject": "okay", "operation": "use", "resourcetype": "service", "resourceid": "smarts"}`), srv)
 
-	assertJSONResponse(t, resp, 200, `{"result": %t, "description": ""}`, false)
+	assertJSONResponse(t, resp, 200, `{"result": %t, "description": "", "obligations": []}`, false)
 
 	//Grant a license
 	resp = runRequestWithServer(post("/v1alpha/orgs/aspian/licenses/smarts", "okay",
@@ -97,7 +99,36 @@ func TestGrantedLicenseAllowsUse(t *testing.T) {
 	resp = runRequestWithServer(post("/v1alpha/check", "system",
 		`{"subject": "okay", "operation": "use", "resourcetype": "service", "resourceid": "smarts"}`), srv)
 
-	assertJSONResponse(t, resp, 200, `{"result": %t, "description": ""}`, true)
+	assertJSONResponse(t, resp, 200, `{"result": %t, "description": "", "obligations": []}`, true)
+}
+
+func TestGrantedLicenseAllowsUseWithCheckCache(t *testing.T) {
+	t.Parallel()
+	srv := createTestServer()
+	cache := application.NewTTLCheckCache(time.Minute)
+	srv.AccessAppService.SetCache(cache)
+	srv.LicenseAppService.SetCheckCache(cache)
+
+	//The deny is cached
+	resp := runRequestWithServer(post("/v1alpha/check", "system",
+		`{"subject": "okay", "operation": "use", "resourcetype": "service", "resourceid": "smarts"}`), srv)
+
+	assertJSONResponse(t, resp, 200, `{"result": %t, "description": "", "obligations": []}`, false)
+
+	//Granting a license invalidates the cache
+	resp = runRequestWithServer(post("/v1alpha/orgs/aspian/licenses/smarts", "okay",
+		`{
+			"assign": [
+			  "okay"
+			]
+		  }`), srv)
+
+	assertJSONResponse(t, resp, 200, `{}`)
+
+	resp = runRequestWithServer(post("/v1alpha/check", "system",
+		`{"subject": "okay", "operation": "use", "resourcetype": "service", "resourceid": "smarts"}`), srv)
+
+	assertJSONResponse(t, resp, 200, `{"result": %t, "description": "", "obligations": []}`, true)
 }
 
 func TestCors_NotImplementedMethod(t *testing.T) {
@@ -138,6 +169,48 @@ func TestCors_AllowAllOrigins(t *testing.T) {
 	assertJSONResponse(t, resp, 200, `{}`)
 }
 
+func TestCorsPreflightAllowsConfiguredOrigin(t *testing.T) {
+	t.Parallel()
+	req := httptest.NewRequest(http.MethodOptions, "/v1alpha/orgs/aspian/licenses/smarts", nil)
+	req.Header.Set("Origin", "https://console.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "Authorization, Content-Type")
+
+	resp := runRequest(req)
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://console.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.MethodPost, resp.Header.Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization, Content-Type", resp.Header.Get("Access-Control-Allow-Headers"))
+}
+
+func TestCorsCrossOriginGetAllowsOnlyConfiguredOrigin(t *testing.T) {
+	t.Parallel()
+	allowed := get("/v1alpha/orgs/aspian/licenses/smarts", "token")
+	allowed.Header.Set("Origin", "https://console.example.com")
+	other := get("/v1alpha/orgs/aspian/licenses/smarts", "token")
+	other.Header.Set("Origin", "https://evil.example.com")
+
+	resp := runRequest(allowed)
+	assert.Equal(t, "https://console.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assertJSONResponse(t, resp, 200, `{"seatsAvailable":20, "seatsTotal": 20}`)
+
+	resp = runRequest(other)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestCorsAllowsNoOriginByDefault(t *testing.T) {
+	t.Parallel()
+	handler := corsMiddleware(api.CORSConfig{}, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	req := httptest.NewRequest(http.MethodGet, "/v1alpha/orgs/aspian/licenses/smarts", nil)
+	req.Header.Set("Origin", "https://console.example.com")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Result().Header.Get("Access-Control-Allow-Origin"))
+}
+
 func TestGrantedLicenseAffectsCountsAndDetails(t *testing.T) {
 	t.Parallel()
 	srv := createTestServer()
@@ -159,9 +232,9 @@ func TestGrantedLicenseAffectsCountsAndDetails(t *testing.T) {
 	resp = runRequestWithServer(get("/v1alpha/orgs/aspian/licenses/smarts", "token"), srv)
 	assertJSONResponse(t, resp, 200, `{"seatsAvailable":19, "seatsTotal": 20}`)
 	resp = runRequestWithServer(get("/v1alpha/orgs/aspian/licenses/smarts/seats", "token"), srv)
-	assertJSONResponse(t, resp, 200, `{"users": [{"assigned":true,"displayName":"Okay User","id":"okay"}]}`)
+	assertJSONResponse(t, resp, 200, `{"users": [{"assigned":true,"displayName":"Okay User","id":"okay","reclaimable":false}]}`)
 	resp = runRequestWithServer(get("/v1alpha/orgs/aspian/licenses/smarts/seats?filter=assignable", "token"), srv)
-	assertJSONResponse(t, resp, 200, `{"users":[{"assigned":false,"displayName":"Bad User","id":"bad"}]}`)
+	assertJSONResponse(t, resp, 200, `{"users":[{"assigned":false,"displayName":"Bad User","id":"bad","reclaimable":false}]}`)
 }
 
 func post(uri string, token string, body string) *http.Request {
@@ -190,11 +263,12 @@ func createTestServer() *grpc.Server {
 	return &grpc.Server{
 		AccessAppService:  application.NewAccessAppService(&accessRepo, principalRepo),
 		LicenseAppService: application.NewLicenseAppService(&accessRepo, &licenseRepo, principalRepo),
+		ServerConfig:      &api.ServerConfig{InsecureDevAuth: true},
 	}
 }
 
 func runRequestWithServer(req *http.Request, srv *grpc.Server) *http.Response {
-	mux, _ := createMultiplexer(srv, srv)
+	mux, _ := createMultiplexer(srv, srv, srv, api.CORSConfig{AllowedOrigins: []string{"https://console.example.com"}})
 
 	rec := httptest.NewRecorder()
 	mux.ServeHTTP(rec, req)
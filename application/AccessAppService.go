@@ -6,15 +6,33 @@ import (
 	"authz/domain/contracts"
 	"authz/domain/services"
 	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
 )
 
 // AccessAppService the handler for permission related endpoints.
 type AccessAppService struct {
 	accessRepo    *contracts.AccessRepository
 	principalRepo contracts.PrincipalRepository
+	cache         CheckCache
+	metrics       CheckMetrics
+	decisionLog   contracts.DecisionAuditLog
+	allowSample   float64
+	sample        func() float64
+	auditOp       string
 	ctx           context.Context
 }
 
+// CheckMetrics counts the decisions and errors of permission checks, ex: to watch the share of denies per operation
+type CheckMetrics interface {
+	// ObserveDecision counts one decision of a check of the operation on a resource of the type
+	ObserveDecision(operation string, resourceType string, allowed bool)
+	// ObserveCheckError counts one check that failed with the error
+	ObserveCheckError(err error)
+}
+
 // CheckRequest is an actual request to check for permissions.
 type CheckRequest struct {
 	Requestor    string
@@ -24,6 +42,31 @@ type CheckRequest struct {
 	Operation    string
 }
 
+// CheckAllRequest is a request to check several operations of one subject on one resource.
+type CheckAllRequest struct {
+	Requestor    string
+	Subject      string
+	ResourceType string
+	ResourceID   string
+	Operations   []string
+}
+
+// LookupResourcesRequest is a request for all resources of a type on which a subject can perform an operation.
+type LookupResourcesRequest struct {
+	Requestor    string
+	Subject      string
+	ResourceType string
+	Operation    string
+}
+
+// LookupSubjectsRequest is a request for all subjects that can perform an operation on a resource.
+type LookupSubjectsRequest struct {
+	Requestor    string
+	ResourceType string
+	ResourceID   string
+	Operation    string
+}
+
 // NewAccessAppService returns a new instance of the permissionhandler.
 func NewAccessAppService(accessRepo *contracts.AccessRepository, principalRepo contracts.PrincipalRepository) *AccessAppService {
 	return &AccessAppService{
@@ -33,8 +76,47 @@ func NewAccessAppService(accessRepo *contracts.AccessRepository, principalRepo c
 	}
 }
 
+// SetCache sets the cache used for check results. A nil cache disables caching.
+func (p *AccessAppService) SetCache(cache CheckCache) {
+	p.cache = cache
+}
+
+// SetMetrics sets the metrics check decisions and errors are counted in. Nil metrics count nothing.
+func (p *AccessAppService) SetMetrics(metrics CheckMetrics) {
+	p.metrics = metrics
+}
+
+// SetAuditOperation sets the operation on a resource requestors must be allowed to list all subjects with access to it, ex: "audit". Empty denies all requestors.
+func (p *AccessAppService) SetAuditOperation(operation string) {
+	p.auditOp = operation
+}
+
+// SetDecisionAuditLog sets the audit log checked decisions are recorded in. Denies are always recorded, allows only at the given sample rate, ex: 0.01 records about 1% of them. A nil audit log records nothing.
+func (p *AccessAppService) SetDecisionAuditLog(decisionLog contracts.DecisionAuditLog, allowSampleRate float64) {
+	p.decisionLog = decisionLog
+	p.allowSample = allowSampleRate
+	if p.sample == nil {
+		p.sample = rand.Float64
+	}
+}
+
 // Check calls the domainservice using a CheckEvent and can be used with every server impl if wanted.
 func (p *AccessAppService) Check(req CheckRequest) (domain.AccessDecision, error) {
+	return p.CheckWithContext(context.Background(), req)
+}
+
+// CheckWithContext works like Check, but aborts the check when the given context is cancelled or its deadline is exceeded.
+func (p *AccessAppService) CheckWithContext(ctx context.Context, req CheckRequest) (domain.AccessDecision, error) {
+	decision, err := p.check(ctx, req)
+	p.observeCheck(req, decision, err)
+	return decision, err
+}
+
+func (p *AccessAppService) check(ctx context.Context, req CheckRequest) (domain.AccessDecision, error) {
+	if decision, ok := p.cachedDecision(req); ok {
+		return decision, nil
+	}
+
 	event := domain.CheckEvent{
 		SubjectID: domain.SubjectID(req.Subject),
 		Operation: req.Operation,
@@ -45,5 +127,201 @@ func (p *AccessAppService) Check(req CheckRequest) (domain.AccessDecision, error
 
 	checkResult := services.NewAccessService(*p.accessRepo)
 
-	return checkResult.Check(event)
+	decision, err := checkResult.CheckWithContext(ctx, event)
+	if err != nil {
+		return decision, err
+	}
+
+	p.cacheDecision(req, decision)
+	return decision, nil
+}
+
+// cachedDecision returns the cached decision of the request, if there is one. Anonymous requests are never answered from the cache, they always go to the domain service to get rejected.
+func (p *AccessAppService) cachedDecision(req CheckRequest) (domain.AccessDecision, bool) {
+	if p.cache == nil || !domain.SubjectID(req.Requestor).HasIdentity() {
+		return domain.AccessDecision{}, false
+	}
+
+	return p.cache.Get(checkCacheKeyOf(req))
+}
+
+// cacheDecision caches the decision of the request, if there is a cache
+func (p *AccessAppService) cacheDecision(req CheckRequest, decision domain.AccessDecision) {
+	if p.cache == nil || !domain.SubjectID(req.Requestor).HasIdentity() {
+		return
+	}
+
+	p.cache.Set(checkCacheKeyOf(req), decision)
+}
+
+func checkCacheKeyOf(req CheckRequest) CheckCacheKey {
+	return CheckCacheKey{Subject: req.Subject, Operation: req.Operation, ResourceType: req.ResourceType, ResourceID: req.ResourceID}
+}
+
+// observeCheck counts the decision or error of a check in the metrics and records the decision in the decision audit log. Every check passes here, whether single or batched.
+func (p *AccessAppService) observeCheck(req CheckRequest, decision domain.AccessDecision, err error) {
+	if p.metrics != nil {
+		if err != nil {
+			p.metrics.ObserveCheckError(err)
+		} else {
+			p.metrics.ObserveDecision(req.Operation, req.ResourceType, decision.IsAllowed())
+		}
+	}
+	if err == nil {
+		p.recordDecision(req, decision)
+	}
+}
+
+// recordDecision records the decision in the decision audit log, if any. Allowed decisions are sampled.
+func (p *AccessAppService) recordDecision(req CheckRequest, decision domain.AccessDecision) {
+	if p.decisionLog == nil {
+		return
+	}
+
+	if decision.IsAllowed() && (p.allowSample <= 0 || p.sample() >= p.allowSample) {
+		return
+	}
+
+	p.decisionLog.RecordAccessDecision(domain.AccessAuditEvent{
+		Time:      time.Now(),
+		Requestor: domain.SubjectID(req.Requestor),
+		Subject:   domain.SubjectID(req.Subject),
+		Operation: req.Operation,
+		Resource:  domain.Resource{Type: req.ResourceType, ID: req.ResourceID},
+		Decision:  decision,
+	})
+}
+
+// CheckWithSubjectState works like CheckWithContext, but if access is denied it also looks up whether the subject is disabled or unknown, to explain the denial.
+// Allowed decisions are returned without looking up the subject, so this costs an extra lookup on denials only. Use CheckWithContext if the subject state is not needed.
+func (p *AccessAppService) CheckWithSubjectState(ctx context.Context, req CheckRequest) (domain.SubjectAccessDecision, error) {
+	decision, err := p.CheckWithContext(ctx, req)
+	if err != nil || decision.IsAllowed() {
+		return domain.SubjectAccessDecision{AccessDecision: decision}, err
+	}
+
+	result := domain.SubjectAccessDecision{AccessDecision: decision}
+	principal, err := p.principalRepo.GetByID(domain.SubjectID(req.Subject))
+	switch {
+	case errors.Is(err, domain.ErrPrincipalNotFound):
+		result.SubjectUnknown = true
+	case err != nil:
+		return domain.SubjectAccessDecision{}, err
+	default:
+		result.SubjectDisabled = principal.Disabled
+	}
+
+	return result, nil
+}
+
+// CheckBatch calls the domainservice using one CheckEvent per request and returns the results and the errors of the failed checks in the same order as the requests.
+// Cached decisions are used like for single checks, only the other requests are checked in bulk. The error is only set if the batch cannot be processed at all, see services.AccessService.CheckBulk.
+func (p *AccessAppService) CheckBatch(ctx context.Context, requestor string, reqs []CheckRequest) ([]domain.AccessDecision, []error, error) {
+	reqs = append([]CheckRequest(nil), reqs...) //set the requestor without modifying the caller's requests
+	decisions := make([]domain.AccessDecision, len(reqs))
+	errs := make([]error, len(reqs))
+	events := make([]domain.CheckEvent, 0, len(reqs))
+	uncached := make([]int, 0, len(reqs)) //position of each event in the batch
+	for i, req := range reqs {
+		reqs[i].Requestor = requestor
+		if decision, ok := p.cachedDecision(reqs[i]); ok {
+			decisions[i] = decision
+			continue
+		}
+
+		event := domain.CheckEvent{
+			SubjectID: domain.SubjectID(req.Subject),
+			Operation: req.Operation,
+			Resource:  domain.Resource{Type: req.ResourceType, ID: req.ResourceID},
+		}
+		event.Requestor = domain.SubjectID(requestor)
+		events = append(events, event)
+		uncached = append(uncached, i)
+	}
+
+	if len(events) > 0 {
+		checkResult := services.NewAccessService(*p.accessRepo)
+
+		checked, checkErrs, err := checkResult.CheckBulk(ctx, domain.SubjectID(requestor), events)
+		if err != nil {
+			for _, req := range reqs {
+				p.observeCheck(req, domain.AccessDecision{}, err)
+			}
+			return nil, nil, err
+		}
+
+		for j, i := range uncached {
+			decisions[i], errs[i] = checked[j], checkErrs[j]
+			if errs[i] == nil {
+				p.cacheDecision(reqs[i], decisions[i])
+			}
+		}
+	}
+
+	for i, req := range reqs {
+		p.observeCheck(req, decisions[i], errs[i])
+	}
+
+	return decisions, errs, nil
+}
+
+// CheckAll checks all operations of the request using the bulk check and returns the decisions keyed by operation. It fails if any of the checks fails.
+func (p *AccessAppService) CheckAll(ctx context.Context, req CheckAllRequest) (map[string]domain.AccessDecision, error) {
+	reqs := make([]CheckRequest, len(req.Operations))
+	for i, operation := range req.Operations {
+		reqs[i] = CheckRequest{
+			Requestor:    req.Requestor,
+			Subject:      req.Subject,
+			ResourceType: req.ResourceType,
+			ResourceID:   req.ResourceID,
+			Operation:    operation,
+		}
+	}
+
+	decisions, errs, err := p.CheckBatch(ctx, req.Requestor, reqs)
+	if err != nil {
+		return nil, err
+	}
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("checking %s failed: %w", req.Operations[i], err)
+		}
+	}
+
+	result := make(map[string]domain.AccessDecision, len(req.Operations))
+	for i, operation := range req.Operations {
+		result[operation] = decisions[i]
+	}
+
+	return result, nil
+}
+
+// LookupResources calls the domainservice using a LookupResourcesEvent and returns the resources the subject can access.
+func (p *AccessAppService) LookupResources(ctx context.Context, req LookupResourcesRequest) ([]domain.Resource, error) {
+	event := domain.LookupResourcesEvent{
+		SubjectID:    domain.SubjectID(req.Subject),
+		Operation:    req.Operation,
+		ResourceType: req.ResourceType,
+	}
+
+	event.Requestor = domain.SubjectID(req.Requestor)
+
+	lookupResult := services.NewAccessService(*p.accessRepo)
+
+	return lookupResult.LookupResources(ctx, event)
+}
+
+// LookupSubjects calls the domainservice using a LookupSubjectsEvent and returns the subjects with access to the resource.
+func (p *AccessAppService) LookupSubjects(ctx context.Context, req LookupSubjectsRequest) ([]domain.SubjectID, error) {
+	event := domain.LookupSubjectsEvent{
+		Operation: req.Operation,
+		Resource:  domain.Resource{Type: req.ResourceType, ID: req.ResourceID},
+	}
+
+	event.Requestor = domain.SubjectID(req.Requestor)
+
+	lookupResult := services.NewAccessService(*p.accessRepo)
+	lookupResult.SetAuditOperation(p.auditOp)
+
+	return lookupResult.LookupSubjects(ctx, event)
 }
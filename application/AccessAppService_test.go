@@ -0,0 +1,190 @@
+package application
+
+import (
+	"authz/domain"
+	"authz/domain/contracts"
+	"authz/infrastructure/repository/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAllReturnsDecisionPerOperation(t *testing.T) {
+	var accessRepo contracts.AccessRepository = &mock.StubAccessRepository{
+		Data:          map[domain.SubjectID]bool{"system": true, "okay": true},
+		LicensedSeats: map[string]map[domain.SubjectID]bool{},
+	}
+	svc := NewAccessAppService(&accessRepo, &mock.StubPrincipalRepository{})
+
+	result, err := svc.CheckAll(context.Background(), CheckAllRequest{
+		Requestor:    "system",
+		Subject:      "okay",
+		ResourceType: "service",
+		ResourceID:   "smarts",
+		Operations:   []string{"read", "use"},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result["read"].IsAllowed(), "Should have been allowed to read.")
+	assert.False(t, result["use"].IsAllowed(), "Should not have been allowed to use without license.")
+}
+
+func TestCheckWithSubjectStateExplainsDenials(t *testing.T) {
+	var accessRepo contracts.AccessRepository = &mock.StubAccessRepository{
+		Data: map[domain.SubjectID]bool{"system": true, "okay": true, "bad": false, "former": false},
+	}
+	principalRepo := &mock.StubPrincipalRepository{
+		Principals: map[domain.SubjectID]domain.Principal{
+			"okay":   domain.NewPrincipal("okay", "Okay User", "o1"),
+			"bad":    domain.NewPrincipal("bad", "Bad User", "o1"),
+			"former": {ID: "former", DisplayName: "Former User", OrgID: "o1", Disabled: true},
+		},
+		RejectUnknown: true,
+	}
+	svc := NewAccessAppService(&accessRepo, principalRepo)
+
+	for subject, expected := range map[string]domain.SubjectAccessDecision{
+		"okay":    {AccessDecision: domain.NewAccessDecision(true)},
+		"bad":     {AccessDecision: domain.NewAccessDecision(false)},
+		"former":  {AccessDecision: domain.NewAccessDecision(false), SubjectDisabled: true},
+		"unknown": {AccessDecision: domain.NewAccessDecision(false), SubjectUnknown: true},
+	} {
+		result, err := svc.CheckWithSubjectState(context.Background(), CheckRequest{
+			Requestor:    "system",
+			Subject:      subject,
+			ResourceType: "service",
+			ResourceID:   "smarts",
+			Operation:    "read",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, "Unexpected result for %s", subject)
+	}
+}
+
+func TestCheckRecordsDeniesAndSampledAllows(t *testing.T) {
+	var accessRepo contracts.AccessRepository = &mock.StubAccessRepository{
+		Data: map[domain.SubjectID]bool{"system": true, "okay": true, "bad": false},
+	}
+	svc := NewAccessAppService(&accessRepo, &mock.StubPrincipalRepository{})
+	log := &recordingDecisionLog{}
+	svc.SetDecisionAuditLog(log, 0.5)
+	samples := []float64{0.7, 0.2}
+	svc.sample = func() float64 {
+		s := samples[0]
+		samples = samples[1:]
+		return s
+	}
+
+	for _, subject := range []string{"okay", "bad", "okay"} {
+		_, err := svc.Check(CheckRequest{
+			Requestor:    "system",
+			Subject:      subject,
+			ResourceType: "service",
+			ResourceID:   "smarts",
+			Operation:    "read",
+		})
+		assert.NoError(t, err)
+	}
+
+	if assert.Len(t, log.events, 2) {
+		assert.Equal(t, domain.SubjectID("bad"), log.events[0].Subject)
+		assert.False(t, log.events[0].Decision.IsAllowed())
+		assert.Equal(t, domain.SubjectID("okay"), log.events[1].Subject)
+		assert.True(t, log.events[1].Decision.IsAllowed())
+		assert.Equal(t, domain.SubjectID("system"), log.events[1].Requestor)
+		assert.Equal(t, "read", log.events[1].Operation)
+		assert.Equal(t, domain.Resource{Type: "service", ID: "smarts"}, log.events[1].Resource)
+	}
+}
+
+func TestCheckRecordsNoAllowsWithoutSampleRate(t *testing.T) {
+	var accessRepo contracts.AccessRepository = &mock.StubAccessRepository{
+		Data: map[domain.SubjectID]bool{"system": true, "okay": true},
+	}
+	svc := NewAccessAppService(&accessRepo, &mock.StubPrincipalRepository{})
+	log := &recordingDecisionLog{}
+	svc.SetDecisionAuditLog(log, 0)
+
+	_, err := svc.Check(CheckRequest{Requestor: "system", Subject: "okay", ResourceType: "service", ResourceID: "smarts", Operation: "read"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, log.events)
+}
+
+func TestCheckBatchAndCheckAllRecordDecisions(t *testing.T) {
+	var accessRepo contracts.AccessRepository = &mock.StubAccessRepository{
+		Data: map[domain.SubjectID]bool{"system": true, "okay": true, "bad": false},
+	}
+	svc := NewAccessAppService(&accessRepo, &mock.StubPrincipalRepository{})
+	log := &recordingDecisionLog{}
+	svc.SetDecisionAuditLog(log, 0)
+
+	_, _, err := svc.CheckBatch(context.Background(), "system", []CheckRequest{
+		{Subject: "okay", ResourceType: "service", ResourceID: "smarts", Operation: "read"},
+		{Subject: "bad", ResourceType: "service", ResourceID: "smarts", Operation: "read"},
+	})
+	assert.NoError(t, err)
+	_, err = svc.CheckAll(context.Background(), CheckAllRequest{Requestor: "system", Subject: "bad", ResourceType: "service", ResourceID: "smarts", Operations: []string{"read", "write"}})
+	assert.NoError(t, err)
+
+	if assert.Len(t, log.events, 3, "Should have recorded the denies only.") {
+		assert.Equal(t, domain.SubjectID("bad"), log.events[0].Subject)
+		assert.Equal(t, domain.SubjectID("system"), log.events[0].Requestor)
+		assert.Equal(t, "read", log.events[1].Operation)
+		assert.Equal(t, "write", log.events[2].Operation)
+	}
+}
+
+func TestCheckBatchUsesCacheAndCountsMetrics(t *testing.T) {
+	stub := &mock.StubAccessRepository{Data: map[domain.SubjectID]bool{"system": true, "okay": true, "bad": false}}
+	var accessRepo contracts.AccessRepository = stub
+	svc := NewAccessAppService(&accessRepo, &mock.StubPrincipalRepository{})
+	svc.SetCache(NewTTLCheckCache(time.Minute))
+	metrics := &countingCheckMetrics{}
+	svc.SetMetrics(metrics)
+	reqs := []CheckRequest{
+		{Subject: "okay", ResourceType: "service", ResourceID: "smarts", Operation: "read"},
+		{Subject: "bad", ResourceType: "service", ResourceID: "smarts", Operation: "read"},
+	}
+
+	_, _, err := svc.CheckBatch(context.Background(), "system", reqs)
+	assert.NoError(t, err)
+	stub.Data["okay"] = false //only visible to checks that are not answered from the cache
+	decisions, _, err := svc.CheckBatch(context.Background(), "system", reqs)
+	assert.NoError(t, err)
+	result, err := svc.CheckAll(context.Background(), CheckAllRequest{Requestor: "system", Subject: "okay", ResourceType: "service", ResourceID: "smarts", Operations: []string{"read"}})
+	assert.NoError(t, err)
+
+	assert.True(t, decisions[0].IsAllowed(), "Should have used the cached decision.")
+	assert.True(t, result["read"].IsAllowed(), "Should have used the cached decision.")
+	assert.Empty(t, reqs[0].Requestor, "Should not have modified the requests.")
+	assert.Equal(t, 3, metrics.allowed)
+	assert.Equal(t, 2, metrics.denied)
+}
+
+type countingCheckMetrics struct {
+	allowed, denied, errors int
+}
+
+func (m *countingCheckMetrics) ObserveDecision(_ string, _ string, allowed bool) {
+	if allowed {
+		m.allowed++
+	} else {
+		m.denied++
+	}
+}
+
+func (m *countingCheckMetrics) ObserveCheckError(error) {
+	m.errors++
+}
+
+type recordingDecisionLog struct {
+	events []domain.AccessAuditEvent
+}
+
+func (l *recordingDecisionLog) RecordAccessDecision(evt domain.AccessAuditEvent) {
+	l.events = append(l.events, evt)
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"authz/domain"
+	"sync"
+	"time"
+)
+
+// CheckCacheKey identifies a cached access decision
+type CheckCacheKey struct {
+	Subject      string
+	Operation    string
+	ResourceType string
+	ResourceID   string
+}
+
+// CheckCache caches the results of permission checks. Both allow and deny decisions are cached.
+type CheckCache interface {
+	// Get returns the cached decision for the given key and true, or false if nothing (valid) is cached
+	Get(key CheckCacheKey) (domain.AccessDecision, bool)
+	// Set caches the decision for the given key
+	Set(key CheckCacheKey, decision domain.AccessDecision)
+	// InvalidateOrg removes all decisions that may be affected by seat changes in the given organization
+	InvalidateOrg(orgID string)
+}
+
+// TTLCheckCache is an in-memory CheckCache whose entries expire after a fixed duration.
+// The TTL bounds how long a decision can be stale, ex: when a seat is granted by another instance of the service.
+type TTLCheckCache struct {
+	ttl       time.Duration
+	entries   map[CheckCacheKey]ttlCheckCacheEntry
+	lastSweep time.Time
+	lock      sync.Mutex
+	now       func() time.Time
+}
+
+type ttlCheckCacheEntry struct {
+	decision domain.AccessDecision
+	expires  time.Time
+}
+
+// NewTTLCheckCache constructs a new TTLCheckCache with the given TTL
+func NewTTLCheckCache(ttl time.Duration) *TTLCheckCache {
+	return &TTLCheckCache{
+		ttl:     ttl,
+		entries: map[CheckCacheKey]ttlCheckCacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached decision for the given key and true, or false if nothing (valid) is cached
+func (c *TTLCheckCache) Get(key CheckCacheKey) (domain.AccessDecision, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return domain.AccessDecision{}, false
+	}
+
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return domain.AccessDecision{}, false
+	}
+
+	return entry.decision, true
+}
+
+// Set caches the decision for the given key
+func (c *TTLCheckCache) Set(key CheckCacheKey, decision domain.AccessDecision) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	if now.Sub(c.lastSweep) > c.ttl { //Drop expired entries from time to time so the map does not grow unbounded
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	c.entries[key] = ttlCheckCacheEntry{decision: decision, expires: now.Add(c.ttl)}
+}
+
+// InvalidateOrg removes all cached decisions. Resources are not reliably tied to an organization (ex: services), so all entries are dropped to be safe.
+func (c *TTLCheckCache) InvalidateOrg(_ string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = map[CheckCacheKey]ttlCheckCacheEntry{}
+}
@@ -0,0 +1,37 @@
+package application
+
+import (
+	"authz/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTLCheckCacheReturnsCachedDecisionUntilExpired(t *testing.T) {
+	now := time.Now()
+	cache := NewTTLCheckCache(time.Second)
+	cache.now = func() time.Time { return now }
+	key := CheckCacheKey{Subject: "okay", Operation: "use", ResourceType: "service", ResourceID: "smarts"}
+
+	cache.Set(key, domain.NewAccessDecision(false))
+
+	decision, ok := cache.Get(key)
+	assert.True(t, ok, "Should have been cached.")
+	assert.False(t, decision.IsAllowed())
+
+	now = now.Add(time.Second)
+	_, ok = cache.Get(key)
+	assert.False(t, ok, "Should have expired.")
+}
+
+func TestTTLCheckCacheInvalidateOrgDropsDecisions(t *testing.T) {
+	cache := NewTTLCheckCache(time.Minute)
+	key := CheckCacheKey{Subject: "okay", Operation: "use", ResourceType: "service", ResourceID: "smarts"}
+
+	cache.Set(key, domain.NewAccessDecision(false))
+	cache.InvalidateOrg("aspian")
+
+	_, ok := cache.Get(key)
+	assert.False(t, ok, "Should have been invalidated.")
+}
@@ -5,6 +5,12 @@ import (
 	"authz/domain/contracts"
 	"authz/domain/services"
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
 )
 
 // LicenseAppService the handler for seat related endpoints.
@@ -12,9 +18,29 @@ type LicenseAppService struct {
 	accessRepo    *contracts.AccessRepository
 	seatRepo      *contracts.SeatLicenseRepository
 	principalRepo contracts.PrincipalRepository
+	checkCache    CheckCache
+	auditLog      contracts.AuditLog
+	serviceRepo   contracts.ServiceRepository
+	verifyMembers bool
+	manageOp      string
+	viewOp        string
+	adminOp       string
+	opsLog        contracts.OperationsLog
+	warnAbove     float64 //seat utilization above which assignments are warned about in opsLog
 	ctx           context.Context
 }
 
+// SeatUsageMetrics records the seat usage of licenses, see ExportSeatUsage
+type SeatUsageMetrics interface {
+	// ObserveSeatUsage records the seats in use and max seats of the license as last read
+	ObserveSeatUsage(lic domain.License)
+	// ObserveSeatUsageError counts one failed read of the seat usage of the license
+	ObserveSeatUsageError(orgID string, serviceID string, err error)
+}
+
+// defaultSeatUsageInterval is the interval ExportSeatUsage reads the seat usage at if none is given
+const defaultSeatUsageInterval = time.Minute
+
 // GetSeatAssignmentRequest represents a request to get the users assigned seats on a license
 type GetSeatAssignmentRequest struct {
 	Requestor    string
@@ -22,8 +48,22 @@ type GetSeatAssignmentRequest struct {
 	ServiceID    string
 	IncludeUsers bool
 	Assigned     bool
+	SortBy       SeatSortField //empty sorts by ID
+	Descending   bool
+	// OnlyReclaimable returns only the assigned seats held by disabled subjects, ex: former employees, whose seats can be reclaimed. Requires Assigned.
+	OnlyReclaimable bool
 }
 
+// SeatSortField is the field the users of a GetSeatAssignmentRequest are sorted by. Ties are broken by ID, so the order is stable between requests.
+type SeatSortField string
+
+const (
+	// SortByID sorts users by their ID
+	SortByID SeatSortField = "id"
+	// SortByName sorts users by their display name, ignoring case. The names are resolved even if IncludeUsers is false.
+	SortByName SeatSortField = "name"
+)
+
 // ModifySeatAssignmentRequest represents a request to assign and/or unassign seat licenses
 type ModifySeatAssignmentRequest struct {
 	Requestor string
@@ -40,6 +80,73 @@ type GetSeatAssignmentCountsRequest struct {
 	ServiceID string
 }
 
+// GetOrgSeatSummaryRequest represents a request to get the seat usage across all licenses of an organization
+type GetOrgSeatSummaryRequest struct {
+	Requestor string
+	OrgID     string
+}
+
+// ReconcileSeatsRequest represents a request to compare the recorded seat usage of a license with its seat assignments, and optionally repair it
+type ReconcileSeatsRequest struct {
+	Requestor string
+	OrgID     string
+	ServiceID string
+	Repair    bool
+}
+
+// BulkAssignSeatsRequest represents a request to assign seats of a license to a large number of subjects
+type BulkAssignSeatsRequest struct {
+	Requestor string
+	OrgID     string
+	ServiceID string
+	Subjects  []string
+}
+
+// UnassignAllForSubjectRequest represents a request to remove the seats of a subject in all services of an organization
+type UnassignAllForSubjectRequest struct {
+	Requestor string
+	OrgID     string
+	SubjectID string
+}
+
+// ReclaimDisabledSeatsRequest represents a request to remove the seats of a license held by disabled or deleted subjects
+type ReclaimDisabledSeatsRequest struct {
+	Requestor string
+	OrgID     string
+	ServiceID string
+}
+
+// CreateLicenseRequest represents a request to provision a new license for an organization and service
+type CreateLicenseRequest struct {
+	Requestor string
+	OrgID     string
+	ServiceID string
+	MaxSeats  int
+}
+
+// DeleteLicenseRequest represents a request to delete a license and all its seat assignments
+type DeleteLicenseRequest struct {
+	Requestor string
+	OrgID     string
+	ServiceID string
+}
+
+// SetMaxSeatsRequest represents a request to change the seat limit of a license
+type SetMaxSeatsRequest struct {
+	Requestor string
+	OrgID     string
+	ServiceID string
+	MaxSeats  int
+}
+
+// CheckOrAssignRequest represents a request to allow a subject to access a license, assigning it a seat just in time if one is available
+type CheckOrAssignRequest struct {
+	Requestor string
+	OrgID     string
+	ServiceID string
+	Subject   string
+}
+
 // NewLicenseAppService ctor.
 func NewLicenseAppService(accessRepo *contracts.AccessRepository, seatRepo *contracts.SeatLicenseRepository, principalRepo contracts.PrincipalRepository) *LicenseAppService {
 	return &LicenseAppService{
@@ -50,8 +157,50 @@ func NewLicenseAppService(accessRepo *contracts.AccessRepository, seatRepo *cont
 	}
 }
 
+// SetCheckCache sets the cache of check results that is invalidated when seats are modified. A nil cache is ignored.
+func (s *LicenseAppService) SetCheckCache(cache CheckCache) {
+	s.checkCache = cache
+}
+
+// SetAuditLog sets the audit log seat assignments and unassignments are recorded in. A nil audit log records nothing.
+func (s *LicenseAppService) SetAuditLog(auditLog contracts.AuditLog) {
+	s.auditLog = auditLog
+}
+
+// SetServiceRepository sets the repository the display metadata of services is resolved from. A nil repository leaves services with only their ID.
+func (s *LicenseAppService) SetServiceRepository(serviceRepo contracts.ServiceRepository) {
+	s.serviceRepo = serviceRepo
+}
+
+// SetVerifyMembership sets whether ModifySeats rejects assignments of subjects that are not members of the organization. See services.SeatLicenseService.SetVerifyMembership.
+func (s *LicenseAppService) SetVerifyMembership(verify bool) {
+	s.verifyMembers = verify
+}
+
+// SetManageOperation sets the operation on the organization, ex: "manage_licenses", that requestors must be allowed to assign and unassign its seats. Empty allows all authenticated requestors.
+func (s *LicenseAppService) SetManageOperation(operation string) {
+	s.manageOp = operation
+}
+
+// SetViewOperation sets the operation on the organization, ex: "view_licenses", that requestors must be allowed to read its licenses and seats. Empty allows all authenticated requestors.
+func (s *LicenseAppService) SetViewOperation(operation string) {
+	s.viewOp = operation
+}
+
+// SetAdminOperation sets the operation on the organization, ex: "administer_licenses", that requestors must be allowed to create, delete, resize and reconcile its licenses. Empty denies all requestors.
+func (s *LicenseAppService) SetAdminOperation(operation string) {
+	s.adminOp = operation
+}
+
+// SetUtilizationWarning sets the log that is warned when assigning seats brings the utilization (in use / max seats) of a license above the threshold, ex: 0.9 for 90%.
+// A nil log or a threshold of 0 suppresses the warnings.
+func (s *LicenseAppService) SetUtilizationWarning(opsLog contracts.OperationsLog, threshold float64) {
+	s.opsLog = opsLog
+	s.warnAbove = threshold
+}
+
 // GetSeatAssignmentCounts gets the seat limit and current allocation for a license
-func (s *LicenseAppService) GetSeatAssignmentCounts(req GetSeatAssignmentCountsRequest) (limit int, available int, err error) {
+func (s *LicenseAppService) GetSeatAssignmentCounts(ctx context.Context, req GetSeatAssignmentCountsRequest) (limit int, available int, err error) {
 	evt := domain.GetLicenseEvent{
 		OrgID:     req.OrgID,
 		ServiceID: req.ServiceID,
@@ -59,9 +208,7 @@ func (s *LicenseAppService) GetSeatAssignmentCounts(req GetSeatAssignmentCountsR
 
 	evt.Requestor = domain.SubjectID(req.Requestor)
 
-	seatsService := services.NewSeatLicenseService(*s.seatRepo, *s.accessRepo)
-
-	lic, err := seatsService.GetLicense(evt)
+	lic, err := s.newSeatLicenseService().GetLicense(ctx, evt)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -73,7 +220,7 @@ func (s *LicenseAppService) GetSeatAssignmentCounts(req GetSeatAssignmentCountsR
 }
 
 // GetSeatAssignments gets the subjects assigned to seats in a license
-func (s *LicenseAppService) GetSeatAssignments(req GetSeatAssignmentRequest) ([]domain.Principal, error) {
+func (s *LicenseAppService) GetSeatAssignments(ctx context.Context, req GetSeatAssignmentRequest) ([]domain.Principal, error) {
 	evt := domain.GetLicenseEvent{
 		OrgID:     req.OrgID,
 		ServiceID: req.ServiceID,
@@ -81,38 +228,260 @@ func (s *LicenseAppService) GetSeatAssignments(req GetSeatAssignmentRequest) ([]
 
 	evt.Requestor = domain.SubjectID(req.Requestor)
 
-	seatService := services.NewSeatLicenseService(*s.seatRepo, *s.accessRepo)
-
-	assigned, err := seatService.GetAssignedSeats(evt)
-	if err != nil {
-		return nil, err
+	if req.OnlyReclaimable && !req.Assigned {
+		return nil, fmt.Errorf("%w: only assigned seats can be reclaimed", domain.ErrInvalidRequest)
 	}
 
+	seatService := s.newSeatLicenseService()
+
 	var resultIds []domain.SubjectID
+	var err error
 	if req.Assigned {
-		resultIds = assigned
+		resultIds, err = seatService.GetAssignedSeats(ctx, evt)
 	} else {
-		allUsers, err := s.principalRepo.GetByOrgID(req.OrgID)
+		resultIds, err = seatService.GetAssignableSeats(ctx, evt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var principals []domain.Principal
+	if req.IncludeUsers || req.SortBy == SortByName || req.OnlyReclaimable {
+		principals, err = s.principalRepo.GetByIDs(resultIds)
 		if err != nil {
 			return nil, err
 		}
+	} else {
+		principals = make([]domain.Principal, len(resultIds))
+		for i, id := range resultIds {
+			principals[i] = domain.Principal{ID: id}
+		}
+	}
 
-		resultIds = subtract(allUsers, assigned)
+	if req.OnlyReclaimable {
+		principals = disabledPrincipals(principals)
 	}
 
-	if req.IncludeUsers {
-		return s.principalRepo.GetByIDs(resultIds)
+	if err := sortPrincipals(principals, req.SortBy, req.Descending); err != nil {
+		return nil, err
 	}
 
-	principals := make([]domain.Principal, len(resultIds))
-	for i, id := range resultIds {
-		principals[i] = domain.Principal{ID: id}
+	if !req.IncludeUsers {
+		for i := range principals {
+			principals[i] = domain.Principal{ID: principals[i].ID, Disabled: principals[i].Disabled}
+		}
 	}
 	return principals, nil
 }
 
+// disabledPrincipals returns the disabled ones of the given principals, in the same order
+func disabledPrincipals(principals []domain.Principal) []domain.Principal {
+	disabled := make([]domain.Principal, 0)
+	for _, p := range principals {
+		if p.Disabled {
+			disabled = append(disabled, p)
+		}
+	}
+	return disabled
+}
+
+// sortPrincipals sorts the principals by the given field, breaking ties by ID. domain.ErrInvalidRequest is returned for unknown fields.
+func sortPrincipals(principals []domain.Principal, sortBy SeatSortField, descending bool) error {
+	var less func(a, b domain.Principal) bool
+	switch sortBy {
+	case "", SortByID:
+		less = func(a, b domain.Principal) bool { return a.ID < b.ID }
+	case SortByName:
+		less = func(a, b domain.Principal) bool {
+			nameA, nameB := strings.ToLower(a.DisplayName), strings.ToLower(b.DisplayName)
+			if nameA != nameB {
+				return nameA < nameB
+			}
+			return a.ID < b.ID
+		}
+	default:
+		return fmt.Errorf("%w: unknown sort field %q, expected %q or %q", domain.ErrInvalidRequest, sortBy, SortByID, SortByName)
+	}
+
+	sort.SliceStable(principals, func(i, j int) bool {
+		if descending {
+			return less(principals[j], principals[i])
+		}
+		return less(principals[i], principals[j])
+	})
+	return nil
+}
+
+// GetOrgSeatSummary gets the seat usage per service and in total of all licenses of an organization
+func (s *LicenseAppService) GetOrgSeatSummary(ctx context.Context, req GetOrgSeatSummaryRequest) (*domain.OrgSeatSummary, error) {
+	evt := domain.GetOrgSeatSummaryEvent{
+		Requestor: domain.SubjectID(req.Requestor),
+		OrgID:     req.OrgID,
+	}
+
+	seatService := s.newSeatLicenseService()
+
+	return seatService.GetOrgSeatSummary(ctx, evt)
+}
+
+// ReconcileSeats reports discrepancies between the recorded seat usage of a license and its seat assignments, including seats held by non-members, and optionally repairs them
+func (s *LicenseAppService) ReconcileSeats(ctx context.Context, req ReconcileSeatsRequest) (*domain.SeatReconciliation, error) {
+	evt := domain.ReconcileSeatsEvent{
+		Requestor: domain.SubjectID(req.Requestor),
+		OrgID:     req.OrgID,
+		ServiceID: req.ServiceID,
+		Repair:    req.Repair,
+	}
+
+	seatService := s.newSeatLicenseService()
+
+	result, err := seatService.ReconcileSeats(ctx, evt)
+	if s.checkCache != nil && req.Repair { //Also on error, as seats may have been removed
+		s.checkCache.InvalidateOrg(req.OrgID)
+	}
+
+	return result, err
+}
+
+// BulkAssignSeats assigns seats to a large number of subjects in batches, skipping subjects that already have a seat and stopping once the license has no seats left or the context ends
+func (s *LicenseAppService) BulkAssignSeats(ctx context.Context, req BulkAssignSeatsRequest) (*domain.BulkAssignSummary, error) {
+	evt := domain.BulkAssignSeatsEvent{
+		Org:     domain.Organization{ID: req.OrgID},
+		Service: domain.Service{ID: req.ServiceID},
+	}
+
+	evt.Requestor = domain.SubjectID(req.Requestor)
+
+	evt.Subjects = make([]domain.SubjectID, len(req.Subjects))
+	for i, id := range req.Subjects {
+		evt.Subjects[i] = domain.SubjectID(id)
+	}
+
+	seatService := s.newSeatLicenseService()
+
+	summary, err := seatService.BulkAssignSeats(ctx, evt)
+	if s.checkCache != nil { //Also on error, as some batches may have been saved
+		s.checkCache.InvalidateOrg(req.OrgID)
+	}
+	if summary != nil && summary.Assigned > 0 {
+		s.warnOnHighUtilization(ctx, req.OrgID, req.ServiceID)
+	}
+
+	return summary, err
+}
+
+// ReclaimDisabledSeats removes the seats of a license held by disabled or deleted subjects in one transaction and returns them, the number of freed seats is their count. Nothing to reclaim is not an error.
+func (s *LicenseAppService) ReclaimDisabledSeats(ctx context.Context, req ReclaimDisabledSeatsRequest) ([]domain.SubjectID, error) {
+	evt := domain.ReclaimDisabledSeatsEvent{
+		Org:     domain.Organization{ID: req.OrgID},
+		Service: domain.Service{ID: req.ServiceID},
+	}
+
+	evt.Requestor = domain.SubjectID(req.Requestor)
+
+	seatService := s.newSeatLicenseService()
+
+	reclaimed, err := seatService.ReclaimDisabledSeats(ctx, evt)
+	if s.checkCache != nil && len(reclaimed) > 0 {
+		s.checkCache.InvalidateOrg(req.OrgID)
+	}
+
+	return reclaimed, err
+}
+
+// CreateLicense provisions a new license with the given seat limit and no assigned seats. Creating a license that already exists fails.
+func (s *LicenseAppService) CreateLicense(ctx context.Context, req CreateLicenseRequest) error {
+	evt := domain.CreateLicenseEvent{
+		Org:      domain.Organization{ID: req.OrgID},
+		Service:  domain.Service{ID: req.ServiceID},
+		MaxSeats: req.MaxSeats,
+	}
+
+	evt.Requestor = domain.SubjectID(req.Requestor)
+
+	seatService := s.newSeatLicenseService()
+
+	return seatService.CreateLicense(ctx, evt)
+}
+
+// DeleteLicense deletes a license and all its seat assignments, ex: when the organization cancels the service, and returns the number of seats released. Deleting a missing license is not an error.
+func (s *LicenseAppService) DeleteLicense(ctx context.Context, req DeleteLicenseRequest) (int, error) {
+	evt := domain.DeleteLicenseEvent{
+		Org:     domain.Organization{ID: req.OrgID},
+		Service: domain.Service{ID: req.ServiceID},
+	}
+
+	evt.Requestor = domain.SubjectID(req.Requestor)
+
+	seatService := s.newSeatLicenseService()
+
+	released, err := seatService.DeleteLicense(ctx, evt)
+	if s.checkCache != nil && (err != nil || released > 0) { //Also on error, as some seats may have been released
+		s.checkCache.InvalidateOrg(req.OrgID)
+	}
+
+	return released, err
+}
+
+// SetMaxSeats changes the seat limit of a license, ex: when the organization upgrades its plan. The limit cannot be set below the number of seats in use.
+func (s *LicenseAppService) SetMaxSeats(ctx context.Context, req SetMaxSeatsRequest) error {
+	evt := domain.SetMaxSeatsEvent{
+		Org:      domain.Organization{ID: req.OrgID},
+		Service:  domain.Service{ID: req.ServiceID},
+		MaxSeats: req.MaxSeats,
+	}
+
+	evt.Requestor = domain.SubjectID(req.Requestor)
+
+	seatService := s.newSeatLicenseService()
+
+	return seatService.SetMaxSeats(ctx, evt)
+}
+
+// UnassignAllForSubject removes the seats of a subject in every service of an organization, ex: when offboarding an employee, and returns the services it held a seat for. A subject without seats is not an error.
+func (s *LicenseAppService) UnassignAllForSubject(ctx context.Context, req UnassignAllForSubjectRequest) ([]domain.Service, error) {
+	evt := domain.UnassignAllForSubjectEvent{
+		OrgID:   req.OrgID,
+		Subject: domain.SubjectID(req.SubjectID),
+	}
+
+	evt.Requestor = domain.SubjectID(req.Requestor)
+
+	seatService := s.newSeatLicenseService()
+
+	unassigned, err := seatService.UnassignAllForSubject(ctx, evt)
+	if s.checkCache != nil && len(unassigned) > 0 {
+		s.checkCache.InvalidateOrg(req.OrgID)
+	}
+
+	return unassigned, err
+}
+
+// CheckOrAssign allows the subject to access the license if it holds a seat, or assigns it a seat if one is available. The decision tells which branch was taken.
+func (s *LicenseAppService) CheckOrAssign(ctx context.Context, req CheckOrAssignRequest) (*domain.SeatGrantDecision, error) {
+	evt := domain.CheckOrAssignSeatEvent{
+		Org:     domain.Organization{ID: req.OrgID},
+		Service: domain.Service{ID: req.ServiceID},
+		Subject: domain.SubjectID(req.Subject),
+	}
+
+	evt.Requestor = domain.SubjectID(req.Requestor)
+
+	seatService := s.newSeatLicenseService()
+
+	decision, err := seatService.CheckOrAssignSeat(ctx, evt)
+	if err == nil && decision.Outcome == domain.SeatGrantAssigned {
+		if s.checkCache != nil {
+			s.checkCache.InvalidateOrg(req.OrgID)
+		}
+		s.warnOnHighUtilization(ctx, req.OrgID, req.ServiceID)
+	}
+
+	return decision, err
+}
+
 // ModifySeats TODO
-func (s *LicenseAppService) ModifySeats(req ModifySeatAssignmentRequest) error {
+func (s *LicenseAppService) ModifySeats(ctx context.Context, req ModifySeatAssignmentRequest) error {
 	evt := domain.ModifySeatAssignmentEvent{
 		Org:     domain.Organization{ID: req.OrgID},
 		Service: domain.Service{ID: req.ServiceID},
@@ -130,26 +499,111 @@ func (s *LicenseAppService) ModifySeats(req ModifySeatAssignmentRequest) error {
 		evt.UnAssign[i] = domain.SubjectID(id)
 	}
 
-	seatService := services.NewSeatLicenseService(*s.seatRepo, *s.accessRepo)
+	seatService := s.newSeatLicenseService()
+
+	err := seatService.ModifySeats(ctx, evt)
+	if s.checkCache != nil { //Also on error, as the modification may have been partially saved
+		s.checkCache.InvalidateOrg(req.OrgID)
+	}
+	if err == nil && len(req.Assign) > 0 {
+		s.warnOnHighUtilization(ctx, req.OrgID, req.ServiceID)
+	}
 
-	return seatService.ModifySeats(evt)
+	return err
 }
 
-func subtract(first []domain.SubjectID, second []domain.SubjectID) []domain.SubjectID { //Move to a SubjectSet or something?
-	subtrahend := map[domain.SubjectID]interface{}{} //idiomatic set
-	for _, id := range second {
-		subtrahend[id] = struct{}{}
+// ExportSeatUsage reads the seat usage of the given licenses, as <org ID>/<service ID>, into the metrics right away and then every interval (0 uses one minute), until the context is cancelled.
+// The licenses are read in a background goroutine. Failed reads are recorded and logged, and the license is read again at the next interval. Malformed license IDs are rejected before anything is read.
+func (s *LicenseAppService) ExportSeatUsage(ctx context.Context, licenseIDs []string, interval time.Duration, metrics SeatUsageMetrics) error {
+	licenses := make([]domain.License, len(licenseIDs))
+	for i, id := range licenseIDs {
+		orgID, serviceID, err := domain.ParseLicenseResourceID(id)
+		if err != nil {
+			return err
+		}
+		licenses[i] = domain.License{OrgID: orgID, ServiceID: serviceID}
+	}
+	if interval <= 0 {
+		interval = defaultSeatUsageInterval
 	}
 
-	result := make([]domain.SubjectID, 0, len(first))
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
 
-	for _, id := range first {
-		if _, ok := subtrahend[id]; ok {
-			continue
+		for {
+			for _, lic := range licenses {
+				s.readSeatUsage(ctx, lic.OrgID, lic.ServiceID, interval, metrics)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
 		}
+	}()
+
+	return nil
+}
+
+// readSeatUsage reads the license into the metrics, bounded by the given timeout so a slow store cannot stall the export of the other licenses
+func (s *LicenseAppService) readSeatUsage(ctx context.Context, orgID string, serviceID string, timeout time.Duration, metrics SeatUsageMetrics) {
+	readCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	lic, err := (*s.seatRepo).GetLicense(readCtx, orgID, serviceID)
+	if err != nil && ctx.Err() != nil {
+		return //Cancelled when the export stops, not a failed read
+	}
+	if err != nil {
+		glog.Warningf("Could not read license %s to export its seat usage: %v", domain.LicenseResourceID(orgID, serviceID), err)
+		metrics.ObserveSeatUsageError(orgID, serviceID, err)
+		return
+	}
+
+	metrics.ObserveSeatUsage(*lic)
+}
+
+// newSeatLicenseService creates the domain service for one request, with the optional collaborators and settings of this application service
+func (s *LicenseAppService) newSeatLicenseService() *services.SeatLicenseService {
+	seatService := services.NewSeatLicenseService(*s.seatRepo, *s.accessRepo, s.principalRepo)
+	seatService.SetAuditLog(s.auditLog)
+	seatService.SetServiceRepository(s.serviceRepo)
+	seatService.SetVerifyMembership(s.verifyMembers)
+	seatService.SetManageOperation(s.manageOp)
+	seatService.SetViewOperation(s.viewOp)
+	seatService.SetAdminOperation(s.adminOp)
+	return seatService
+}
+
+// warnOnHighUtilization warns the operations log if the seat utilization of the license exceeds the threshold. Failing to read the license is only logged, as the seats are already assigned.
+func (s *LicenseAppService) warnOnHighUtilization(ctx context.Context, orgID string, serviceID string) {
+	if s.opsLog == nil || s.warnAbove <= 0 {
+		return
+	}
+
+	lic, err := (*s.seatRepo).GetLicense(ctx, orgID, serviceID)
+	if err != nil {
+		glog.Warningf("Could not read license %s to check its seat utilization: %v", domain.LicenseResourceID(orgID, serviceID), err)
+		return
+	}
+	if lic.MaxSeats <= 0 {
+		return
+	}
 
-		result = append(result, id)
+	utilization := float64(lic.InUse) / float64(lic.MaxSeats)
+	if utilization <= s.warnAbove {
+		return
 	}
 
-	return result
+	s.opsLog.WarnSeatUtilization(domain.SeatUtilizationWarning{
+		Time:        time.Now().UTC(),
+		OrgID:       orgID,
+		ServiceID:   serviceID,
+		InUse:       lic.InUse,
+		MaxSeats:    lic.MaxSeats,
+		Utilization: utilization,
+		Threshold:   s.warnAbove,
+	})
 }
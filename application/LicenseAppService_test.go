@@ -0,0 +1,267 @@
+package application
+
+import (
+	"authz/domain"
+	"authz/domain/contracts"
+	"authz/infrastructure/repository/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModifySeatsWarnsAboveUtilizationThreshold(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 4))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	svc := NewLicenseAppService(&accessRepo, &seatRepo, &mock.StubPrincipalRepository{})
+	opsLog := &recordingOperationsLog{}
+	svc.SetUtilizationWarning(opsLog, 0.5)
+
+	assert.NoError(t, svc.ModifySeats(context.Background(), ModifySeatAssignmentRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", Assign: []string{"u1", "u2"}}))
+	assert.Empty(t, opsLog.warnings, "Should not have warned at the threshold.")
+
+	assert.NoError(t, svc.ModifySeats(context.Background(), ModifySeatAssignmentRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", Assign: []string{"u3"}}))
+	if assert.Len(t, opsLog.warnings, 1) {
+		assert.Equal(t, "o1", opsLog.warnings[0].OrgID)
+		assert.Equal(t, "smarts", opsLog.warnings[0].ServiceID)
+		assert.Equal(t, 0.75, opsLog.warnings[0].Utilization)
+	}
+}
+
+func TestModifySeatsDoesNotWarnWhenSuppressed(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 1))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	svc := NewLicenseAppService(&accessRepo, &seatRepo, &mock.StubPrincipalRepository{})
+	opsLog := &recordingOperationsLog{}
+	svc.SetUtilizationWarning(opsLog, 0)
+
+	assert.NoError(t, svc.ModifySeats(context.Background(), ModifySeatAssignmentRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", Assign: []string{"u1"}}))
+
+	assert.Empty(t, opsLog.warnings)
+}
+
+func TestGetSeatAssignmentsSortsUsers(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 10))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	principals := &mock.StubPrincipalRepository{Principals: map[domain.SubjectID]domain.Principal{
+		"u1": domain.NewPrincipal("u1", "carol", "o1"),
+		"u2": domain.NewPrincipal("u2", "Alice", "o1"),
+		"u3": domain.NewPrincipal("u3", "bob", "o1"),
+	}}
+	svc := NewLicenseAppService(&accessRepo, &seatRepo, principals)
+	assert.NoError(t, svc.ModifySeats(context.Background(), ModifySeatAssignmentRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", Assign: []string{"u3", "u1", "u2"}}))
+
+	for _, testcase := range []struct {
+		sortBy     SeatSortField
+		descending bool
+		expected   []domain.SubjectID
+	}{
+		{sortBy: "", expected: []domain.SubjectID{"u1", "u2", "u3"}},
+		{sortBy: SortByID, descending: true, expected: []domain.SubjectID{"u3", "u2", "u1"}},
+		{sortBy: SortByName, expected: []domain.SubjectID{"u2", "u3", "u1"}},
+	} {
+		result, err := svc.GetSeatAssignments(context.Background(), GetSeatAssignmentRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", Assigned: true, SortBy: testcase.sortBy, Descending: testcase.descending})
+
+		assert.NoError(t, err)
+		ids := make([]domain.SubjectID, len(result))
+		for i, p := range result {
+			ids[i] = p.ID
+			assert.Empty(t, p.DisplayName, "Users should not have been included.")
+		}
+		assert.Equal(t, testcase.expected, ids, "Unexpected order for sort by %q", testcase.sortBy)
+	}
+
+	_, err := svc.GetSeatAssignments(context.Background(), GetSeatAssignmentRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", Assigned: true, SortBy: "assigned_at"})
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+}
+
+func TestGetSeatAssignmentsReturnsOnlyReclaimableSeats(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 10))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	principals := &mock.StubPrincipalRepository{Principals: map[domain.SubjectID]domain.Principal{
+		"u1": domain.NewPrincipal("u1", "carol", "o1"),
+		"u2": domain.NewPrincipal("u2", "Alice", "o1"),
+		"u3": domain.NewPrincipal("u3", "bob", "o1"),
+	}}
+	svc := NewLicenseAppService(&accessRepo, &seatRepo, principals)
+	assert.NoError(t, svc.ModifySeats(context.Background(), ModifySeatAssignmentRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", Assign: []string{"u1", "u2", "u3"}}))
+	principals.Principals["u3"] = domain.Principal{ID: "u3", DisplayName: "bob", OrgID: "o1", Disabled: true} //Left after being assigned a seat
+
+	result, err := svc.GetSeatAssignments(context.Background(), GetSeatAssignmentRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", Assigned: true, OnlyReclaimable: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Principal{{ID: "u3", Disabled: true}}, result)
+
+	_, err = svc.GetSeatAssignments(context.Background(), GetSeatAssignmentRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", Assigned: false, OnlyReclaimable: true})
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+}
+
+func TestSetMaxSeats(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"u1", "u2", "u3"}, "o1", domain.Service{ID: "smarts"}))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	svc := NewLicenseAppService(&accessRepo, &seatRepo, &mock.StubPrincipalRepository{})
+	svc.SetAdminOperation("administer_licenses")
+	store.GrantOrgOperation("o1", "administer_licenses", "system")
+
+	for _, testcase := range []struct {
+		name     string
+		maxSeats int
+		expected int
+		err      error
+	}{
+		{name: "increase", maxSeats: 20, expected: 20},
+		{name: "decrease above in use", maxSeats: 5, expected: 5},
+		{name: "decrease to in use", maxSeats: 3, expected: 3},
+		{name: "decrease below in use", maxSeats: 2, expected: 3, err: domain.ErrSeatLimitBelowInUse},
+		{name: "negative", maxSeats: -1, expected: 3, err: domain.ErrInvalidRequest},
+	} {
+		err := svc.SetMaxSeats(context.Background(), SetMaxSeatsRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", MaxSeats: testcase.maxSeats})
+
+		if testcase.err != nil {
+			assert.ErrorIs(t, err, testcase.err, testcase.name)
+		} else {
+			assert.NoError(t, err, testcase.name)
+		}
+		lic, err := store.GetLicense(context.Background(), "o1", "smarts")
+		assert.NoError(t, err)
+		assert.Equal(t, testcase.expected, lic.MaxSeats, testcase.name)
+		assert.Equal(t, 3, lic.InUse, testcase.name)
+	}
+
+	err := svc.SetMaxSeats(context.Background(), SetMaxSeatsRequest{Requestor: "system", OrgID: "o1", ServiceID: "unknown", MaxSeats: 5})
+	assert.ErrorIs(t, err, domain.ErrLicenseNotFound)
+	err = svc.SetMaxSeats(context.Background(), SetMaxSeatsRequest{OrgID: "o1", ServiceID: "smarts", MaxSeats: 5})
+	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
+	err = svc.SetMaxSeats(context.Background(), SetMaxSeatsRequest{Requestor: "u1", OrgID: "o1", ServiceID: "smarts", MaxSeats: 50})
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+	lic, err := store.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, lic.MaxSeats, "A denied requestor should not have changed the seat limit.")
+}
+
+func TestCreateLicense(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	svc := NewLicenseAppService(&accessRepo, &seatRepo, &mock.StubPrincipalRepository{})
+	svc.SetAdminOperation("administer_licenses")
+	store.GrantOrgOperation("o1", "administer_licenses", "system")
+
+	err := svc.CreateLicense(context.Background(), CreateLicenseRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", MaxSeats: 10})
+
+	assert.NoError(t, err)
+	lic, err := store.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewLicense("o1", "smarts", 10, 0), lic)
+
+	err = svc.CreateLicense(context.Background(), CreateLicenseRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts", MaxSeats: 20})
+	assert.ErrorIs(t, err, domain.ErrLicenseAlreadyExists)
+	err = svc.CreateLicense(context.Background(), CreateLicenseRequest{Requestor: "system", OrgID: "o1", ServiceID: "", MaxSeats: 5})
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+	err = svc.CreateLicense(context.Background(), CreateLicenseRequest{Requestor: "system", OrgID: "o1", ServiceID: "other", MaxSeats: -1})
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+	err = svc.CreateLicense(context.Background(), CreateLicenseRequest{OrgID: "o2", ServiceID: "smarts", MaxSeats: 5})
+	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
+	err = svc.CreateLicense(context.Background(), CreateLicenseRequest{Requestor: "system", OrgID: "o2", ServiceID: "smarts", MaxSeats: 5})
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized, "The requestor should only administer the licenses of o1.")
+	_, err = store.GetLicense(context.Background(), "o2", "smarts")
+	assert.ErrorIs(t, err, domain.ErrLicenseNotFound)
+}
+
+func TestDeleteLicenseReleasesSeatsAndIsIdempotent(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"u1", "u2"}, "o1", domain.Service{ID: "smarts"}))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	svc := NewLicenseAppService(&accessRepo, &seatRepo, &mock.StubPrincipalRepository{})
+	svc.SetAdminOperation("administer_licenses")
+	store.GrantOrgOperation("o1", "administer_licenses", "system")
+
+	released, err := svc.DeleteLicense(context.Background(), DeleteLicenseRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, released)
+	_, err = store.GetLicense(context.Background(), "o1", "smarts")
+	assert.ErrorIs(t, err, domain.ErrLicenseNotFound)
+	decision, err := store.CheckAccess(context.Background(), "u1", "access", domain.Resource{Type: "license", ID: "o1/smarts"})
+	assert.NoError(t, err)
+	assert.False(t, decision.IsAllowed(), "No seats should remain.")
+
+	released, err = svc.DeleteLicense(context.Background(), DeleteLicenseRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts"})
+	assert.NoError(t, err, "Deleting again should not be an error.")
+	assert.Zero(t, released)
+}
+
+func TestDeleteLicenseErrorsWhenNotAuthorized(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"u1"}, "o1", domain.Service{ID: "smarts"}))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	svc := NewLicenseAppService(&accessRepo, &seatRepo, &mock.StubPrincipalRepository{})
+	svc.SetAdminOperation("administer_licenses")
+	store.GrantOrgOperation("o2", "administer_licenses", "system")
+
+	released, err := svc.DeleteLicense(context.Background(), DeleteLicenseRequest{Requestor: "system", OrgID: "o1", ServiceID: "smarts"})
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+	assert.Zero(t, released)
+	lic, err := store.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lic.InUse, "The seats should have been kept.")
+}
+
+func TestExportSeatUsageRecordsLicensesAndErrors(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"u1", "u2"}, "o1", domain.Service{ID: "smarts"}))
+	var accessRepo contracts.AccessRepository = store
+	var seatRepo contracts.SeatLicenseRepository = store
+	svc := NewLicenseAppService(&accessRepo, &seatRepo, &mock.StubPrincipalRepository{})
+	metrics := &recordingSeatUsageMetrics{observed: make(chan domain.License, 10), failed: make(chan error, 10)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := svc.ExportSeatUsage(ctx, []string{"o1/smarts", "o2/smarts"}, time.Hour, metrics)
+
+	assert.NoError(t, err)
+	assert.Equal(t, *domain.NewLicense("o1", "smarts", 10, 2), <-metrics.observed)
+	assert.ErrorIs(t, <-metrics.failed, domain.ErrLicenseNotFound, "The export should have continued after the first license.")
+
+	err = svc.ExportSeatUsage(ctx, []string{"smarts"}, time.Hour, metrics)
+	assert.ErrorIs(t, err, domain.ErrInvalidResourceID)
+}
+
+type recordingSeatUsageMetrics struct {
+	observed chan domain.License
+	failed   chan error
+}
+
+func (r *recordingSeatUsageMetrics) ObserveSeatUsage(lic domain.License) {
+	r.observed <- lic
+}
+
+func (r *recordingSeatUsageMetrics) ObserveSeatUsageError(_ string, _ string, err error) {
+	r.failed <- err
+}
+
+type recordingOperationsLog struct {
+	warnings []domain.SeatUtilizationWarning
+}
+
+func (r *recordingOperationsLog) WarnSeatUtilization(evt domain.SeatUtilizationWarning) {
+	r.warnings = append(r.warnings, evt)
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"authz/domain"
+	"authz/domain/contracts"
+	"authz/domain/services"
+	"context"
+)
+
+// RelationshipAppService the handler for endpoints managing raw relationships, ex: group memberships.
+type RelationshipAppService struct {
+	relationshipRepo contracts.RelationshipRepository
+	accessRepo       *contracts.AccessRepository
+	adminOp          string
+}
+
+// RelationshipRequest represents a request to write or delete one relationship, ex: user u1 as member of group g1
+type RelationshipRequest struct {
+	Requestor    string
+	ResourceType string
+	ResourceID   string
+	Relation     string
+	SubjectType  string
+	SubjectID    string
+}
+
+// ReadRelationshipsRequest represents a request to list one page of the relationships of a resource type, optionally of one resource and relation
+type ReadRelationshipsRequest struct {
+	Requestor    string
+	ResourceType string
+	ResourceID   string
+	Relation     string
+	Cursor       string
+	Limit        int
+}
+
+// NewRelationshipAppService ctor.
+func NewRelationshipAppService(relationshipRepo contracts.RelationshipRepository, accessRepo *contracts.AccessRepository) *RelationshipAppService {
+	return &RelationshipAppService{
+		relationshipRepo: relationshipRepo,
+		accessRepo:       accessRepo,
+	}
+}
+
+// SetAdminOperation sets the operation on the endpoint:relationships resource requestors must be allowed to manage relationships. See services.RelationshipService.SetAdminOperation.
+func (s *RelationshipAppService) SetAdminOperation(operation string) {
+	s.adminOp = operation
+}
+
+// WriteRelationship creates the relationship of the request. Cached check decisions are not invalidated, they expire with the check cache TTL.
+func (s *RelationshipAppService) WriteRelationship(ctx context.Context, req RelationshipRequest) error {
+	return s.newRelationshipService().WriteRelationship(ctx, modifyRelationshipEventOf(req))
+}
+
+// DeleteRelationship removes the relationship of the request. Cached check decisions are not invalidated, they expire with the check cache TTL.
+func (s *RelationshipAppService) DeleteRelationship(ctx context.Context, req RelationshipRequest) error {
+	return s.newRelationshipService().DeleteRelationship(ctx, modifyRelationshipEventOf(req))
+}
+
+// ReadRelationships lists one page of the relationships matching the request. The NextCursor of the page reads the following one.
+func (s *RelationshipAppService) ReadRelationships(ctx context.Context, req ReadRelationshipsRequest) (*domain.RelationshipPage, error) {
+	evt := domain.ReadRelationshipsEvent{
+		Filter: domain.RelationshipFilter{
+			ResourceType: req.ResourceType,
+			ResourceID:   req.ResourceID,
+			Relation:     req.Relation,
+		},
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+	}
+
+	evt.Requestor = domain.SubjectID(req.Requestor)
+
+	return s.newRelationshipService().ReadRelationships(ctx, evt)
+}
+
+func (s *RelationshipAppService) newRelationshipService() *services.RelationshipService {
+	relationshipService := services.NewRelationshipService(s.relationshipRepo, *s.accessRepo)
+	relationshipService.SetAdminOperation(s.adminOp)
+	return relationshipService
+}
+
+func modifyRelationshipEventOf(req RelationshipRequest) domain.ModifyRelationshipEvent {
+	evt := domain.ModifyRelationshipEvent{
+		Relationship: domain.Relationship{
+			Resource: domain.Resource{Type: req.ResourceType, ID: req.ResourceID},
+			Relation: req.Relation,
+			Subject:  domain.Resource{Type: req.SubjectType, ID: req.SubjectID},
+		},
+	}
+
+	evt.Requestor = domain.SubjectID(req.Requestor)
+	return evt
+}
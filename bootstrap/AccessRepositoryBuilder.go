@@ -6,6 +6,8 @@ import (
 	"authz/domain/contracts"
 	"authz/infrastructure/repository/authzed"
 	"authz/infrastructure/repository/mock"
+
+	"google.golang.org/grpc/keepalive"
 )
 
 // AccessRepositoryBuilder is the builder containing the config for building technical implementations of the server
@@ -31,14 +33,49 @@ func (e *AccessRepositoryBuilder) Build() (contracts.AccessRepository, error) {
 	case "stub":
 		return &mock.StubAccessRepository{Data: getMockData(), LicensedSeats: map[string]map[domain.SubjectID]bool{}, Licenses: getMockLicenseData()}, nil
 	case "spicedb":
-		spicedb := &authzed.SpiceDbAccessRepository{}
-		spicedb.NewConnection(config.Endpoint, config.AuthToken, true, config.UseTLS)
+		spicedb, err := authzed.NewSpiceDbAccessRepositoryFromConfig(authzed.SpiceDbConfig{
+			Endpoint:             config.Endpoint,
+			PresharedKey:         config.AuthToken,
+			UseTLS:               config.UseTLS,
+			CACertPath:           config.CACertPath,
+			IsBlocking:           true,
+			Keepalive:            getKeepaliveParams(config.Keepalive),
+			Retry:                getRetryPolicy(config.RetryJitter),
+			RequestTimeout:       config.RequestTimeout,
+			MaxConcurrentChecks:  e.config.MaxConcurrentChecks,
+			StrictChecks:         e.config.StrictChecks,
+			AllowedRelationships: config.AllowedRelationships,
+		})
+		if err != nil {
+			return nil, err
+		}
 		return spicedb, nil
 	default:
 		return &mock.StubAccessRepository{Data: getMockData(), LicensedSeats: map[string]map[domain.SubjectID]bool{}, Licenses: getMockLicenseData()}, nil
 	}
 }
 
+func getRetryPolicy(jitter string) authzed.RetryPolicy {
+	policy := authzed.DefaultRetryPolicy
+	if jitter != "" {
+		policy.Jitter = authzed.JitterStrategy(jitter)
+	}
+	return policy
+}
+
+func getKeepaliveParams(config api.KeepaliveConfig) keepalive.ClientParameters {
+	params := authzed.DefaultKeepalive
+	if config.Time > 0 {
+		params.Time = config.Time
+	}
+	if config.Timeout > 0 {
+		params.Timeout = config.Timeout
+	}
+	params.PermitWithoutStream = config.PermitWithoutStream
+
+	return params
+}
+
 func getMockData() map[domain.SubjectID]bool {
 	return map[domain.SubjectID]bool{
 		"token": true,
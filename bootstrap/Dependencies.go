@@ -0,0 +1,75 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Dependency is an external system the service needs to be reachable to serve requests, ex: SpiceDB
+type Dependency interface {
+	Ping(ctx context.Context) error
+}
+
+// dependencyRetryInitialBackoff and dependencyRetryMaxBackoff bound the wait between two dependency checks at startup
+const (
+	dependencyRetryInitialBackoff = 500 * time.Millisecond
+	dependencyRetryMaxBackoff     = 30 * time.Second
+)
+
+// CheckDependencies pings all given dependencies and returns an error listing all unreachable ones, or nil if all are reachable
+func CheckDependencies(ctx context.Context, deps ...Dependency) error {
+	failures := make([]string, 0)
+	for _, dep := range deps {
+		if err := dep.Ping(ctx); err != nil {
+			failures = append(failures, fmt.Sprintf("%T: %v", dep, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("unreachable dependencies: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// waitForDependencies checks the dependencies with exponential backoff until all are reachable, then marks the service as SERVING.
+// The servers listen in the meantime, so a dependency coming up after the service does not require a restart.
+func waitForDependencies(ctx context.Context, healthSrv *health.Server, deps ...Dependency) {
+	backoff := dependencyRetryInitialBackoff
+	for {
+		err := CheckDependencies(ctx, deps...)
+		if err == nil {
+			healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+			glog.Info("All dependencies reachable - serving")
+			return
+		}
+
+		glog.Warningf("Dependency check failed, retrying in %s: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > dependencyRetryMaxBackoff {
+			backoff = dependencyRetryMaxBackoff
+		}
+	}
+}
+
+// dependenciesOf returns the given objects that are dependencies, ex: repositories connected to external systems
+func dependenciesOf(candidates ...interface{}) []Dependency {
+	deps := make([]Dependency, 0)
+	for _, c := range candidates {
+		if dep, ok := c.(Dependency); ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubDependency struct {
+	err error
+}
+
+func (d stubDependency) Ping(_ context.Context) error {
+	return d.err
+}
+
+func TestCheckDependenciesAggregatesFailures(t *testing.T) {
+	t.Parallel()
+
+	err := CheckDependencies(context.Background(), stubDependency{}, stubDependency{err: errors.New("unreachable")}, stubDependency{err: errors.New("timeout")})
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unreachable")
+		assert.Contains(t, err.Error(), "timeout")
+	}
+}
+
+func TestCheckDependenciesSucceedsIfAllReachable(t *testing.T) {
+	t.Parallel()
+
+	err := CheckDependencies(context.Background(), stubDependency{}, stubDependency{})
+
+	assert.NoError(t, err)
+}
@@ -8,7 +8,9 @@ import (
 
 // PrincipalRepositoryBuilder constructs a PrincipalRepository based on the given configuration
 type PrincipalRepositoryBuilder struct {
-	store string
+	store           string
+	rejectAnonymous bool
+	rejectUnknown   bool
 }
 
 // NewPrincipalRepositoryBuilder constructs a new PrincipalRepositoryBuilder
@@ -22,13 +24,25 @@ func (b *PrincipalRepositoryBuilder) WithStore(store string) *PrincipalRepositor
 	return b
 }
 
+// WithRejectAnonymous specifies whether looking up the anonymous principal (empty ID) fails instead of returning an anonymous principal
+func (b *PrincipalRepositoryBuilder) WithRejectAnonymous(reject bool) *PrincipalRepositoryBuilder {
+	b.rejectAnonymous = reject
+	return b
+}
+
+// WithRejectUnknown specifies whether looking up an unknown principal fails instead of creating it
+func (b *PrincipalRepositoryBuilder) WithRejectUnknown(reject bool) *PrincipalRepositoryBuilder {
+	b.rejectUnknown = reject
+	return b
+}
+
 // Build constructs the repository
 func (b *PrincipalRepositoryBuilder) Build() contracts.PrincipalRepository {
 	switch b.store {
 	case "stub":
-		return &mock.StubPrincipalRepository{Principals: getMockPrincipalData(), DefaultOrg: "o1"}
+		return &mock.StubPrincipalRepository{Principals: getMockPrincipalData(), DefaultOrg: "o1", RejectAnonymous: b.rejectAnonymous, RejectUnknown: b.rejectUnknown}
 	default:
-		return &mock.StubPrincipalRepository{Principals: getMockPrincipalData(), DefaultOrg: "o1"}
+		return &mock.StubPrincipalRepository{Principals: getMockPrincipalData(), DefaultOrg: "o1", RejectAnonymous: b.rejectAnonymous, RejectUnknown: b.rejectUnknown}
 	}
 }
 
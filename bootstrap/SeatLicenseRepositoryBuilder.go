@@ -34,8 +34,11 @@ func (b *SeatLicenseRepositoryBuilder) Build() contracts.SeatLicenseRepository {
 	config := b.config.StoreConfig
 	switch config.Store {
 	case "spicedb":
+		if spicedb, ok := b.stub.(*authzed.SpiceDbAccessRepository); ok { //Reuse the existing connection instead of dialing a second one
+			return spicedb
+		}
 		spicedb := authzed.SpiceDbAccessRepository{}
-		spicedb.NewConnection(config.Endpoint, config.AuthToken, true, config.UseTLS)
+		spicedb.NewConnectionWithKeepalive(config.Endpoint, config.AuthToken, true, config.UseTLS, getKeepaliveParams(config.Keepalive))
 		return &spicedb
 	case "stub":
 		return b.stub
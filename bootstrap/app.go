@@ -7,14 +7,24 @@ import (
 	"authz/api/http"
 	"authz/application"
 	"authz/domain/contracts"
+	"authz/infrastructure/audit"
+	"authz/infrastructure/logging"
+	"authz/infrastructure/repository/batch"
+	"authz/infrastructure/repository/cache"
+	"authz/infrastructure/repository/static"
+	"context"
 	"sync"
+	"time"
 
 	"github.com/golang/glog"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
-// Run configures and runs the actual bootstrap.
-func Run(endpoint string, token string, store string, useTLS bool) {
-	srv, webSrv := initialize(endpoint, token, store, useTLS)
+// Run configures and runs the actual bootstrap. The identity sources are given as <header>=<strategy>, empty uses grpc.DefaultIdentitySources.
+// The allowed relationships are the kinds of relationships that may be written and deleted directly, as <resource type>#<relation>@<subject type>, empty allows none.
+func Run(endpoint string, token string, store string, useTLS bool, insecureDevAuth bool, keepalive api.KeepaliveConfig, identitySources []string, allowedRelationships []string) {
+	srv, webSrv := initialize(endpoint, token, store, useTLS, insecureDevAuth, keepalive, identitySources, allowedRelationships)
 
 	wait := sync.WaitGroup{}
 
@@ -36,11 +46,38 @@ func Run(endpoint string, token string, store string, useTLS bool) {
 	wait.Wait()
 }
 
-func initialize(endpoint string, token string, store string, useTLS bool) (*grpc.Server, *http.Server) {
+func initialize(endpoint string, token string, store string, useTLS bool, insecureDevAuth bool, keepalive api.KeepaliveConfig, identitySources []string, allowedRelationships []string) (*grpc.Server, *http.Server) {
+	sources := grpc.DefaultIdentitySources
+	if len(identitySources) > 0 {
+		var err error
+		if sources, err = grpc.ParseIdentitySources(identitySources); err != nil {
+			glog.Fatal("Invalid identity sources: ", err)
+		}
+	}
+
 	srvCfg := api.ServerConfig{ //TODO: Discuss config.
-		GrpcPort:  "50051",
-		HTTPPort:  "8081",
-		HTTPSPort: "8443",
+		GrpcPort:                        "50051",
+		HTTPPort:                        "8081",
+		HTTPSPort:                       "8443",
+		MetricsPort:                     "9000",
+		CheckCacheTTL:                   3 * time.Second,
+		LicenseCacheTTL:                 5 * time.Second,
+		MaxRecvMsgSize:                  4 * 1024 * 1024,
+		MaxSendMsgSize:                  16 * 1024 * 1024,
+		MaxSeatChanges:                  1000,
+		MaxConcurrentChecks:             16,
+		VerifySeatMembership:            false,
+		SeatUtilizationWarningThreshold: 0.9,
+		AuditAccessDecisions:            true,
+		AllowedDecisionSampleRate:       0.01,
+		IdentitySources:                 sources, //also forwarded by the http gateway
+		InsecureDevAuth:                 insecureDevAuth,
+		RateLimit: api.RateLimitConfig{
+			Rate:           100,
+			Burst:          200,
+			AnonymousRate:  10,
+			AnonymousBurst: 20,
+		},
 		TLSConfig: api.TLSConfig{
 			CertPath: "/etc/tls/tls.crt",
 			CertName: "",
@@ -48,25 +85,69 @@ func initialize(endpoint string, token string, store string, useTLS bool) (*grpc
 			KeyName:  "",
 		},
 		StoreConfig: api.StoreConfig{
-			Store:     store,
-			Endpoint:  endpoint,
-			AuthToken: token,
-			UseTLS:    useTLS,
+			Store:                store,
+			Endpoint:             endpoint,
+			AuthToken:            token,
+			UseTLS:               useTLS,
+			RequestTimeout:       30 * time.Second,
+			Keepalive:            keepalive,
+			AllowedRelationships: allowedRelationships,
 		},
 	}
 
 	ar := getAccessRepository(&srvCfg)
 	sr := getSeatRepository(&srvCfg, ar)
+	if srvCfg.SeatWriteBatchWindow > 0 {
+		sr = batch.NewBatchingSeatLicenseRepository(sr, srvCfg.SeatWriteBatchWindow)
+	}
+	if srvCfg.LicenseCacheTTL > 0 {
+		sr = cache.NewCachingSeatLicenseRepository(sr, srvCfg.LicenseCacheTTL)
+	}
 	pr := getPrincipalRepository(store)
 
 	aas := application.NewAccessAppService(&ar, pr)
 	sas := application.NewLicenseAppService(&ar, &sr, pr)
+	sas.SetAuditLog(&audit.GlogAuditLog{})
+	aas.SetAuditOperation(srvCfg.AuditSubjectsOperation)
+	if srvCfg.AuditAccessDecisions {
+		aas.SetDecisionAuditLog(&audit.GlogAuditLog{}, srvCfg.AllowedDecisionSampleRate)
+	}
+	sas.SetVerifyMembership(srvCfg.VerifySeatMembership)
+	sas.SetManageOperation(srvCfg.ManageLicensesOperation)
+	sas.SetViewOperation(srvCfg.ViewLicensesOperation)
+	sas.SetAdminOperation(srvCfg.AdminLicensesOperation)
+	sas.SetUtilizationWarning(&logging.GlogOperationsLog{}, srvCfg.SeatUtilizationWarningThreshold)
+
+	if srvCfg.ServiceFilePath != "" {
+		services, err := static.LoadStaticServiceRepository(srvCfg.ServiceFilePath)
+		if err != nil {
+			glog.Fatal("Could not load service definitions: ", err)
+		}
+		sas.SetServiceRepository(services)
+	}
+
+	var ras *application.RelationshipAppService
+	if rr, ok := ar.(contracts.RelationshipRepository); ok {
+		ras = application.NewRelationshipAppService(rr, &ar)
+		ras.SetAdminOperation(srvCfg.AdminRelationshipsOperation)
+	}
+
+	if srvCfg.CheckCacheTTL > 0 {
+		checkCache := application.NewTTLCheckCache(srvCfg.CheckCacheTTL)
+		aas.SetCache(checkCache)
+		sas.SetCheckCache(checkCache)
+	}
 
 	srv := getGrpcServer(aas, sas, &srvCfg)
+	srv.RelationshipAppService = ras
+	srv.Health = health.NewServer()
+	srv.Health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	go waitForDependencies(context.Background(), srv.Health, dependenciesOf(ar, sr, pr)...)
 
 	webSrv := getHTTPServer(&srvCfg)
 	webSrv.SetCheckRef(srv)
 	webSrv.SetSeatRef(srv)
+	webSrv.SetRelationshipRef(srv)
 
 	return srv, webSrv
 }
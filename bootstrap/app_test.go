@@ -1,6 +1,7 @@
 package bootstrap
 
 import (
+	"authz/api"
 	core "authz/api/gen/v1alpha"
 	"authz/api/grpc"
 	"context"
@@ -97,7 +98,7 @@ func initializeGrpcServer(t *testing.T) *grpc.Server {
 	token, err := serialKey()
 	assert.NoError(t, err)
 
-	grpc, _ := initialize("localhost:"+port, token, "spicedb", false)
+	grpc, _ := initialize("localhost:"+port, token, "spicedb", false, true, api.KeepaliveConfig{}, nil, nil)
 
 	return grpc
 }
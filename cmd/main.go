@@ -2,10 +2,12 @@
 package main
 
 import (
+	"authz/api"
 	"authz/bootstrap"
 	"flag"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
@@ -36,6 +38,12 @@ func main() {
 	rootCmd.Flags().String("token", "", "token")
 	rootCmd.Flags().String("store", "stub", "stub or spicedb")
 	rootCmd.Flags().Bool("useTLS", false, "false for no tls (local dev) and true for TLS")
+	rootCmd.Flags().Bool("insecureDevAuth", false, "true to trust bearer tokens as principal IDs without validation (local dev only, refused with TLS)")
+	rootCmd.Flags().Duration("keepaliveTime", 0, "interval of keepalive pings to the store, 0 for the store's default (5m). Must not be lower than the store allows")
+	rootCmd.Flags().Duration("keepaliveTimeout", 0, "time to wait for a keepalive ping response before closing the connection, 0 for the store's default (20s)")
+	rootCmd.Flags().Bool("keepalivePermitWithoutStream", false, "true to send keepalive pings to the store without active streams. Only if the store allows it")
+	rootCmd.Flags().StringSlice("identitySources", nil, "headers to take the requestor identity from, tried in order, as <header>=<strategy> with strategy bearer or x-rh-identity. Default: x-rh-identity=x-rh-identity,grpcgateway-authorization=bearer,bearer-token=bearer")
+	rootCmd.Flags().StringSlice("allowedRelationships", nil, "kinds of relationships that may be written and deleted directly, as <resource type>#<relation>@<subject type>, ex: group#member@user. Licenses and seats cannot be allowed. Default: none")
 	if err := rootCmd.Execute(); err != nil {
 		glog.Fatalf("error running command: %v", err)
 	}
@@ -47,8 +55,17 @@ func serve(cmd *cobra.Command, _ []string) {
 	token := mustGetString("token", cmd.Flags())
 	store := nonEmptyStringFlag("store", cmd.Flags())
 	useTLS := mustGetBool("useTLS", cmd.Flags())
+	insecureDevAuth := mustGetBool("insecureDevAuth", cmd.Flags())
+	keepalive := api.KeepaliveConfig{
+		Time:                mustGetDuration("keepaliveTime", cmd.Flags()),
+		Timeout:             mustGetDuration("keepaliveTimeout", cmd.Flags()),
+		PermitWithoutStream: mustGetBool("keepalivePermitWithoutStream", cmd.Flags()),
+	}
+
+	identitySources := mustGetStringSlice("identitySources", cmd.Flags())
+	allowedRelationships := mustGetStringSlice("allowedRelationships", cmd.Flags())
 
-	bootstrap.Run(endpoint, token, store, useTLS)
+	bootstrap.Run(endpoint, token, store, useTLS, insecureDevAuth, keepalive, identitySources, allowedRelationships)
 }
 
 // nonEmptyStringFlag attempts to get a non-empty string flag from the provided flag set or panic
@@ -85,3 +102,19 @@ func mustGetBool(flagName string, flags *pflag.FlagSet) bool {
 	}
 	return flagVal
 }
+
+func mustGetDuration(flagName string, flags *pflag.FlagSet) time.Duration {
+	flagVal, err := flags.GetDuration(flagName)
+	if err != nil {
+		glog.Fatalf(notFoundMessage(flagName, err))
+	}
+	return flagVal
+}
+
+func mustGetStringSlice(flagName string, flags *pflag.FlagSet) []string {
+	flagVal, err := flags.GetStringSlice(flagName)
+	if err != nil {
+		glog.Fatalf(notFoundMessage(flagName, err))
+	}
+	return flagVal
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// AccessAuditEvent records an authorization decision: who asked whether a subject may perform an operation on a resource, and the decision
+type AccessAuditEvent struct {
+	Time      time.Time
+	Requestor SubjectID
+	Subject   SubjectID
+	Operation string
+	Resource  Resource
+	Decision  AccessDecision
+}
@@ -1,5 +1,52 @@
 // Package domain holds the domain logic, see README.md for more.
 package domain
 
-// AccessDecision is an example of a value object (if I understand it right)
-type AccessDecision bool
+import (
+	"fmt"
+	"strings"
+)
+
+// AccessDecision is an example of a value object (if I understand it right). It holds whether access is allowed, and optionally why and under which obligations.
+type AccessDecision struct {
+	// Allowed is true if access is granted
+	Allowed bool
+	// Reason optionally explains the decision, ex: why access was denied
+	Reason string
+	// Obligations optionally lists conditions the caller must fulfill when access is allowed, ex: "log" or "read-only"
+	Obligations []string
+}
+
+// NewAccessDecision converts a plain allow/deny result into an AccessDecision without reason or obligations. This eases migration from the former bool-based decision.
+func NewAccessDecision(allowed bool) AccessDecision {
+	return AccessDecision{Allowed: allowed}
+}
+
+// Allow constructs a decision that grants access under the given obligations, if any
+func Allow(obligations ...string) AccessDecision {
+	return AccessDecision{Allowed: true, Obligations: obligations}
+}
+
+// Deny constructs a decision that denies access for the given reason
+func Deny(reason string) AccessDecision {
+	return AccessDecision{Allowed: false, Reason: reason}
+}
+
+// IsAllowed returns true if access is granted, keeping the semantics of the former bool-based decision
+func (d AccessDecision) IsAllowed() bool {
+	return d.Allowed
+}
+
+// String renders the decision as "Allowed" or "Denied" for logs and messages, followed by the reason and obligations, if any, ex: "Denied: not a member of the org"
+func (d AccessDecision) String() string {
+	result := "Denied"
+	if d.Allowed {
+		result = "Allowed"
+	}
+	if d.Reason != "" {
+		result = fmt.Sprintf("%s: %s", result, d.Reason)
+	}
+	if len(d.Obligations) > 0 {
+		result = fmt.Sprintf("%s (obligations: %s)", result, strings.Join(d.Obligations, ", "))
+	}
+	return result
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessDecisionStringRendersOutcome(t *testing.T) {
+	assert.Equal(t, "Allowed", NewAccessDecision(true).String())
+	assert.Equal(t, "Denied", NewAccessDecision(false).String())
+	assert.Equal(t, "Denied: not a member of the org", Deny("not a member of the org").String())
+	assert.Equal(t, "Allowed (obligations: log, read-only)", Allow("log", "read-only").String())
+}
+
+func TestAccessDecisionFormatsAsString(t *testing.T) {
+	assert.Equal(t, "decision: Allowed", fmt.Sprintf("decision: %v", Allow()))
+}
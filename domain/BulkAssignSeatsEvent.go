@@ -0,0 +1,9 @@
+package domain
+
+// BulkAssignSeatsEvent represents a request to assign seats of a license to a large number of subjects, ex: when onboarding a whole department
+type BulkAssignSeatsEvent struct {
+	Request
+	Org      Organization
+	Service  Service
+	Subjects []SubjectID
+}
@@ -0,0 +1,15 @@
+package domain
+
+// BulkAssignSummary reports the outcome of a bulk seat assignment
+type BulkAssignSummary struct {
+	// Assigned is the number of subjects that were assigned a seat
+	Assigned int
+	// SkippedAlreadyAssigned is the number of subjects that already had a seat, including duplicates in the request
+	SkippedAlreadyAssigned int
+	// SkippedLimit is the number of subjects that were not assigned because the license had no seats left
+	SkippedLimit int
+	// Failed is the number of subjects whose assignment failed, ex: because they are not members of the organization
+	Failed int
+	// LimitReached is true if the license ran out of seats before all subjects were assigned
+	LimitReached bool
+}
@@ -0,0 +1,9 @@
+package domain
+
+// CheckOrAssignSeatEvent represents a request to allow a subject to access a license, assigning it a seat just in time if it has none and one is available
+type CheckOrAssignSeatEvent struct {
+	Request
+	Org     Organization
+	Service Service
+	Subject SubjectID
+}
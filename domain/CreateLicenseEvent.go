@@ -0,0 +1,9 @@
+package domain
+
+// CreateLicenseEvent represents a request to provision a new license with the given seat limit for an organization and service
+type CreateLicenseEvent struct {
+	Request
+	Org      Organization
+	Service  Service
+	MaxSeats int
+}
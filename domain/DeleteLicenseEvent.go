@@ -0,0 +1,8 @@
+package domain
+
+// DeleteLicenseEvent represents a request to tear down the license of an organization for a service and all its seat assignments, ex: when the service is cancelled
+type DeleteLicenseEvent struct {
+	Request
+	Org     Organization
+	Service Service
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrNotAuthorized is returned when the identity invoking the API does not have permission to invoke that operation.
 var ErrNotAuthorized = errors.New("NotAuthorized")
@@ -8,5 +11,89 @@ var ErrNotAuthorized = errors.New("NotAuthorized")
 // ErrNotAuthenticated is returned when anonymously invoking an endpoint that requires an identity
 var ErrNotAuthenticated = errors.New("NotAuthenticated")
 
+// ErrPrincipalNotFound is returned when a principal ID is unknown to the principal repository.
+var ErrPrincipalNotFound = errors.New("PrincipalNotFound")
+
+// ErrLicenseNotFound is returned when there is no license for the given organization and service.
+var ErrLicenseNotFound = errors.New("LicenseNotFound")
+
+// ErrLicenseAlreadyExists is returned when creating a license for an organization and service that already hold one.
+var ErrLicenseAlreadyExists = errors.New("LicenseAlreadyExists")
+
+// ErrResourceNotFound is returned by strict permission checks when the resource to check does not exist.
+var ErrResourceNotFound = errors.New("ResourceNotFound")
+
+// ErrSeatLimitExceeded is returned when assigning seats would exceed the max seats of the license. See SeatLimitExceededError.
+var ErrSeatLimitExceeded = errors.New("SeatLimitExceeded")
+
+// SeatLimitExceededError reports the seats of a license that has too few seats left for an assignment. It matches ErrSeatLimitExceeded with errors.Is.
+type SeatLimitExceededError struct {
+	LicenseID string
+	MaxSeats  int
+	InUse     int
+	Requested int //the number of seats that were to be assigned
+}
+
+func (e *SeatLimitExceededError) Error() string {
+	return fmt.Sprintf("%s: %d of %d seats of license %s are in use, %d more cannot be assigned", ErrSeatLimitExceeded, e.InUse, e.MaxSeats, e.LicenseID, e.Requested)
+}
+
+// Unwrap returns ErrSeatLimitExceeded
+func (e *SeatLimitExceededError) Unwrap() error {
+	return ErrSeatLimitExceeded
+}
+
+// ErrSeatLimitBelowInUse is returned when the max seats of a license would be set below the number of seats in use. See SeatLimitBelowInUseError.
+var ErrSeatLimitBelowInUse = errors.New("SeatLimitBelowInUse")
+
+// SeatLimitBelowInUseError reports the seats in use of a license whose max seats were to be set below them. It matches ErrSeatLimitBelowInUse with errors.Is.
+type SeatLimitBelowInUseError struct {
+	LicenseID string
+	MaxSeats  int //the rejected new limit
+	InUse     int
+}
+
+func (e *SeatLimitBelowInUseError) Error() string {
+	return fmt.Sprintf("%s: %d seats of license %s are in use, the limit cannot be set to %d", ErrSeatLimitBelowInUse, e.InUse, e.LicenseID, e.MaxSeats)
+}
+
+// Unwrap returns ErrSeatLimitBelowInUse
+func (e *SeatLimitBelowInUseError) Unwrap() error {
+	return ErrSeatLimitBelowInUse
+}
+
+// ErrSubjectNotMember is returned when a seat is to be assigned to a subject that is not a member of the organization. See NotMemberError.
+var ErrSubjectNotMember = errors.New("SubjectNotMember")
+
+// NotMemberError lists the subjects that are not members of the organization. It matches ErrSubjectNotMember with errors.Is.
+type NotMemberError struct {
+	OrgID      string
+	SubjectIDs []SubjectID
+}
+
+func (e *NotMemberError) Error() string {
+	return fmt.Sprintf("%s: subjects %v are not members of organization %s", ErrSubjectNotMember, e.SubjectIDs, e.OrgID)
+}
+
+// Unwrap returns ErrSubjectNotMember
+func (e *NotMemberError) Unwrap() error {
+	return ErrSubjectNotMember
+}
+
+// ErrRelationshipNotAllowed is returned when writing or deleting a relationship whose resource type, relation and subject type are not allowed to be managed directly.
+var ErrRelationshipNotAllowed = errors.New("RelationshipNotAllowed")
+
 // ErrInvalidRequest is returned when some part of the request is incompatible with another part.
 var ErrInvalidRequest = errors.New("InvalidRequest")
+
+// ErrInvalidResourceID is returned when a resource ID does not have the structure required by its resource type.
+var ErrInvalidResourceID = errors.New("InvalidResourceID")
+
+// ErrCheckTooComplex is returned when a permission check exceeds the maximum depth of the permission graph the authorization store evaluates, ex: because of a recursive relation.
+var ErrCheckTooComplex = errors.New("CheckTooComplex")
+
+// ErrTooManyResults is returned when a lookup matches more resources or subjects than the authorization store returns at once. The lookup has to be narrowed down.
+var ErrTooManyResults = errors.New("TooManyResults")
+
+// ErrSchemaNotInitialized is returned when the authorization store has no (or an incomplete) schema, ex: because it was never applied to a fresh store.
+var ErrSchemaNotInitialized = errors.New("SchemaNotInitialized")
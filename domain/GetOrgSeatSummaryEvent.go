@@ -0,0 +1,7 @@
+package domain
+
+// GetOrgSeatSummaryEvent represents a request for the seat usage of all licenses of an organization
+type GetOrgSeatSummaryEvent struct {
+	Requestor SubjectID
+	OrgID     string
+}
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 // License represents a license purchased by an org for a service
 type License struct {
 	OrgID     string
@@ -22,3 +27,19 @@ func NewLicense(orgID string, serviceID string, maxSeats int, assigned int) *Lic
 func (l *License) GetAvailableSeats() int {
 	return l.MaxSeats - l.InUse
 }
+
+// LicenseResourceID builds the composite resource ID of a license, of the form <orgID>/<serviceID>.
+// Scoping the ID by org keeps the licenses of different orgs for the same service apart, and lets the service of each license found for an org be read from its ID.
+func LicenseResourceID(orgID string, serviceID string) string {
+	return fmt.Sprintf("%s/%s", orgID, serviceID)
+}
+
+// ParseLicenseResourceID splits a composite license resource ID of the form <orgID>/<serviceID>. ErrInvalidResourceID is returned if it does not consist of exactly two non-empty segments.
+func ParseLicenseResourceID(id string) (orgID string, serviceID string, err error) {
+	segments := strings.Split(id, "/")
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", fmt.Errorf("%w: license resource ID %q must be of the form <orgID>/<serviceID>", ErrInvalidResourceID, id)
+	}
+
+	return segments[0], segments[1], nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLicenseResourceIDSplitsOrgAndService(t *testing.T) {
+	orgID, serviceID, err := ParseLicenseResourceID("o1/smarts")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "o1", orgID)
+	assert.Equal(t, "smarts", serviceID)
+}
+
+func TestParseLicenseResourceIDRejectsMalformedIDs(t *testing.T) {
+	for _, id := range []string{"smarts", "o1/smarts/extra", "/smarts", "o1/", ""} {
+		_, _, err := ParseLicenseResourceID(id)
+
+		assert.ErrorIs(t, err, ErrInvalidResourceID, "Should have rejected %q", id)
+	}
+}
+
+func TestLicenseResourceIDRoundTrip(t *testing.T) {
+	orgID, serviceID, err := ParseLicenseResourceID(LicenseResourceID("o1", "smarts"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "o1", orgID)
+	assert.Equal(t, "smarts", serviceID)
+}
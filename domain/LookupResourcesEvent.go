@@ -0,0 +1,13 @@
+package domain
+
+// LookupResourcesEvent contains the parameters to request all resources of a type on which a subject can perform an operation
+type LookupResourcesEvent struct {
+	//The common request parameters
+	Request
+	//The operation that would be performed
+	Operation string
+	//The candidate subject who would perform the operation
+	SubjectID SubjectID
+	//The type of the resources to look up
+	ResourceType string
+}
@@ -0,0 +1,11 @@
+package domain
+
+// LookupSubjectsEvent contains the parameters to request all subjects that can perform an operation on a resource
+type LookupSubjectsEvent struct {
+	//The common request parameters
+	Request
+	//The operation that would be performed
+	Operation string
+	//The resource on which the operation would be performed
+	Resource Resource
+}
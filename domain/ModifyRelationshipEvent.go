@@ -0,0 +1,7 @@
+package domain
+
+// ModifyRelationshipEvent represents a request to write or delete one relationship directly, ex: a group membership
+type ModifyRelationshipEvent struct {
+	Request
+	Relationship Relationship
+}
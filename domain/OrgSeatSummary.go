@@ -0,0 +1,41 @@
+package domain
+
+// ServiceSeatUsage is the seat usage of the license of an organization for one service
+type ServiceSeatUsage struct {
+	Service   Service
+	MaxSeats  int
+	InUse     int
+	Available int
+}
+
+// OrgSeatSummary aggregates the seat usage of the licenses of an organization across its services
+type OrgSeatSummary struct {
+	OrgID     string
+	Services  []ServiceSeatUsage
+	MaxSeats  int
+	InUse     int
+	Available int
+}
+
+// NewOrgSeatSummary constructs the OrgSeatSummary of the given licenses of an organization, in the order given. The services only have their ID set.
+func NewOrgSeatSummary(orgID string, licenses []License) *OrgSeatSummary {
+	summary := &OrgSeatSummary{
+		OrgID:    orgID,
+		Services: make([]ServiceSeatUsage, len(licenses)),
+	}
+
+	for i, lic := range licenses {
+		usage := ServiceSeatUsage{
+			Service:   Service{ID: lic.ServiceID},
+			MaxSeats:  lic.MaxSeats,
+			InUse:     lic.InUse,
+			Available: lic.GetAvailableSeats(),
+		}
+		summary.Services[i] = usage
+		summary.MaxSeats += usage.MaxSeats
+		summary.InUse += usage.InUse
+		summary.Available += usage.Available
+	}
+
+	return summary
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrgSeatSummaryAddsUpServices(t *testing.T) {
+	summary := NewOrgSeatSummary("o1", []License{
+		*NewLicense("o1", "smarts", 10, 4),
+		*NewLicense("o1", "other", 5, 5),
+	})
+
+	assert.Equal(t, []ServiceSeatUsage{
+		{Service: Service{ID: "smarts"}, MaxSeats: 10, InUse: 4, Available: 6},
+		{Service: Service{ID: "other"}, MaxSeats: 5, InUse: 5, Available: 0},
+	}, summary.Services)
+	assert.Equal(t, 15, summary.MaxSeats)
+	assert.Equal(t, 9, summary.InUse)
+	assert.Equal(t, 6, summary.Available)
+}
@@ -6,6 +6,8 @@ type Principal struct {
 	ID          SubjectID
 	DisplayName string
 	OrgID       string
+	// Disabled is true if the principal is deactivated, ex: a former employee. Principals are enabled by default.
+	Disabled bool
 }
 
 // IsAnonymous returns true if this Principal has no identity information and returns false if this Principal represents a specific identity
@@ -0,0 +1,25 @@
+package domain
+
+// RelationshipFilter selects relationships by resource type and optionally by resource ID and relation
+type RelationshipFilter struct {
+	ResourceType string
+	ResourceID   string //empty matches all resources of the type
+	Relation     string //empty matches all relations
+}
+
+// ReadRelationshipsEvent represents a request to list the raw relationships matching a filter, one page at a time
+type ReadRelationshipsEvent struct {
+	Request
+	Filter RelationshipFilter
+	// Cursor is the NextCursor of the previous page, empty reads the first page
+	Cursor string
+	// Limit is the maximum number of relationships per page, 0 uses the default
+	Limit int
+}
+
+// RelationshipPage is one page of relationships
+type RelationshipPage struct {
+	Relationships []Relationship
+	// NextCursor reads the following page, it is empty on the last page
+	NextCursor string
+}
@@ -0,0 +1,8 @@
+package domain
+
+// ReclaimDisabledSeatsEvent represents a request to remove the seats of a license held by subjects that are disabled or no longer exist, ex: former employees
+type ReclaimDisabledSeatsEvent struct {
+	Request
+	Org     Organization
+	Service Service
+}
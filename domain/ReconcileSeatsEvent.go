@@ -0,0 +1,10 @@
+package domain
+
+// ReconcileSeatsEvent represents a request to compare the recorded seat usage of a license with the actual seat assignments, and optionally repair it
+type ReconcileSeatsEvent struct {
+	Requestor SubjectID
+	OrgID     string
+	ServiceID string
+	// Repair removes seats of subjects that are not members of the organization and corrects the recorded number of seats in use
+	Repair bool
+}
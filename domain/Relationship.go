@@ -0,0 +1,15 @@
+package domain
+
+import "fmt"
+
+// Relationship relates a subject to a resource, ex: a user as member of a group
+type Relationship struct {
+	Resource Resource
+	Relation string
+	Subject  Resource
+}
+
+// String renders the relationship as <resource type>:<resource ID>#<relation>@<subject type>:<subject ID>
+func (r Relationship) String() string {
+	return fmt.Sprintf("%s:%s#%s@%s:%s", r.Resource.Type, r.Resource.ID, r.Relation, r.Subject.Type, r.Subject.ID)
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// SeatAuditAction is the kind of seat modification recorded in a SeatAuditEvent
+type SeatAuditAction string
+
+const (
+	// SeatAuditActionAssign records the assignment of seats
+	SeatAuditActionAssign SeatAuditAction = "assign"
+	// SeatAuditActionUnassign records the removal of seat assignments
+	SeatAuditActionUnassign SeatAuditAction = "unassign"
+)
+
+// SeatAuditResult is the outcome of a seat modification recorded in a SeatAuditEvent
+type SeatAuditResult string
+
+const (
+	// SeatAuditResultSuccess means the seats were modified
+	SeatAuditResultSuccess SeatAuditResult = "success"
+	// SeatAuditResultDenied means the requestor was not allowed to modify the seats
+	SeatAuditResultDenied SeatAuditResult = "denied"
+	// SeatAuditResultFailure means the modification failed, no seats were modified
+	SeatAuditResultFailure SeatAuditResult = "failure"
+	// SeatAuditResultSkipped means the modification was not attempted because a previous one of the same request failed
+	SeatAuditResultSkipped SeatAuditResult = "skipped"
+)
+
+// SeatAuditEvent records who assigned or unassigned which seats and with what outcome
+type SeatAuditEvent struct {
+	Time      time.Time
+	Requestor SubjectID
+	Action    SeatAuditAction
+	OrgID     string
+	ServiceID string
+	Subjects  []SubjectID
+	Result    SeatAuditResult
+	Error     string
+}
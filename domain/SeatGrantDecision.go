@@ -0,0 +1,19 @@
+package domain
+
+// SeatGrantOutcome is the branch a just-in-time seat grant took
+type SeatGrantOutcome string
+
+const (
+	// SeatGrantAlreadyAssigned means the subject already had a seat, nothing was assigned
+	SeatGrantAlreadyAssigned SeatGrantOutcome = "already_assigned"
+	// SeatGrantAssigned means the subject had no seat and was assigned one
+	SeatGrantAssigned SeatGrantOutcome = "assigned"
+	// SeatGrantNoSeatAvailable means the subject had no seat and the license had none left, access is denied
+	SeatGrantNoSeatAvailable SeatGrantOutcome = "no_seat_available"
+)
+
+// SeatGrantDecision is the access decision of a just-in-time seat grant together with the branch that led to it
+type SeatGrantDecision struct {
+	AccessDecision
+	Outcome SeatGrantOutcome
+}
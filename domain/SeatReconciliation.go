@@ -0,0 +1,22 @@
+package domain
+
+// SeatReconciliation reports the discrepancies between the recorded seat usage of a license and its actual seat assignments, and what was repaired
+type SeatReconciliation struct {
+	OrgID     string
+	ServiceID string
+	// RecordedInUse is the number of seats in use according to the license before reconciling
+	RecordedInUse int
+	// ActualInUse is the number of assigned seats before reconciling
+	ActualInUse int
+	// NonMembers are the subjects holding a seat without being a member of the organization, ex: deleted users
+	NonMembers []SubjectID
+	// Repaired is true if the seats of NonMembers were removed and the recorded number of seats in use was corrected
+	Repaired bool
+	// InUse is the number of seats in use after reconciling
+	InUse int
+}
+
+// HasDiscrepancies returns true if the recorded seat usage did not match the assignments or seats were held by non-members
+func (r SeatReconciliation) HasDiscrepancies() bool {
+	return r.RecordedInUse != r.ActualInUse || len(r.NonMembers) > 0
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// SeatUtilizationWarning reports a license whose seat utilization exceeds the warning threshold after seats were assigned, so more seats can be provisioned before it runs out
+type SeatUtilizationWarning struct {
+	Time      time.Time
+	OrgID     string
+	ServiceID string
+	InUse     int
+	MaxSeats  int
+	// Utilization is InUse divided by MaxSeats
+	Utilization float64
+	// Threshold is the utilization above which the warning is raised
+	Threshold float64
+}
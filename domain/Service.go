@@ -4,6 +4,10 @@ package domain
 type Service struct {
 	// ID is the unique name/id of the service
 	ID string
+	// DisplayName is the human-readable name of the service, if known
+	DisplayName string
+	// Description is a human-readable description of the service, if known
+	Description string
 }
 
 // AsResource converts the Service into a Resource that can be used for access checks
@@ -0,0 +1,9 @@
+package domain
+
+// SetMaxSeatsEvent represents a request to change the seat limit of a license, ex: when the organization upgrades its plan
+type SetMaxSeatsEvent struct {
+	Request
+	Org      Organization
+	Service  Service
+	MaxSeats int
+}
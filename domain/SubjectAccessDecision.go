@@ -0,0 +1,10 @@
+package domain
+
+// SubjectAccessDecision is an AccessDecision together with the state of the subject it was made for, so a missing grant can be told apart from a disabled or unknown subject
+type SubjectAccessDecision struct {
+	AccessDecision
+	// SubjectDisabled is true if access was denied and the subject is disabled
+	SubjectDisabled bool
+	// SubjectUnknown is true if access was denied and the subject is not known
+	SubjectUnknown bool
+}
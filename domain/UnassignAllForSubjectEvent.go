@@ -0,0 +1,8 @@
+package domain
+
+// UnassignAllForSubjectEvent represents a request to remove the seats of a subject in all services of an organization, ex: when an employee leaves
+type UnassignAllForSubjectEvent struct {
+	Request
+	OrgID   string
+	Subject SubjectID
+}
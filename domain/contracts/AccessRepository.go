@@ -4,10 +4,18 @@ package contracts
 
 import (
 	"authz/domain"
+	"context"
 )
 
 // AccessRepository - the contract for the access repository
 type AccessRepository interface {
-	CheckAccess(subjectID domain.SubjectID, operation string, resource domain.Resource) (domain.AccessDecision, error)
+	CheckAccess(ctx context.Context, subjectID domain.SubjectID, operation string, resource domain.Resource) (domain.AccessDecision, error)
+	// CheckAccessBulk checks each of the given events and returns the decisions and errors in the same order as the events.
+	// A failed check does not fail the others: errs[i] is the error of events[i], nil if it was checked.
+	CheckAccessBulk(ctx context.Context, events []domain.CheckEvent) (decisions []domain.AccessDecision, errs []error)
+	// LookupAccessibleResources returns all resources of the given type on which the subject can perform the operation
+	LookupAccessibleResources(ctx context.Context, subjectID domain.SubjectID, operation string, resourceType string) ([]domain.Resource, error)
+	// LookupAccessingSubjects returns all subjects that can perform the operation on the resource, following indirect grants as well
+	LookupAccessingSubjects(ctx context.Context, operation string, resource domain.Resource) ([]domain.SubjectID, error)
 	NewConnection(endpoint string, token string, isBlocking, useTLS bool) //TODO: Remove from interface.don't think it is needed here.
 }
@@ -0,0 +1,17 @@
+package contracts
+
+import (
+	"authz/domain"
+)
+
+// AuditLog is a contract that describes a sink for audit events, ex: a dedicated audit log
+type AuditLog interface {
+	// RecordSeatEvent records the given seat assignment or unassignment
+	RecordSeatEvent(evt domain.SeatAuditEvent)
+}
+
+// DecisionAuditLog is a contract that describes a sink for authorization decisions, ex: a dedicated audit log
+type DecisionAuditLog interface {
+	// RecordAccessDecision records the given authorization decision
+	RecordAccessDecision(evt domain.AccessAuditEvent)
+}
@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"authz/domain"
+)
+
+// OperationsLog is a contract that describes a sink for events operations should act on, ex: the application log
+type OperationsLog interface {
+	// WarnSeatUtilization logs that a license is running out of seats
+	WarnSeatUtilization(evt domain.SeatUtilizationWarning)
+}
@@ -0,0 +1,17 @@
+package contracts
+
+import (
+	"authz/domain"
+	"context"
+)
+
+// RelationshipRepository is a contract that describes low-level access to the relationships of the authorization store, for relationships other than seats, ex: group memberships
+type RelationshipRepository interface {
+	// WriteRelationship creates the relationship, writing an existing one again is not an error. domain.ErrRelationshipNotAllowed is returned if its kind is not allowed to be managed.
+	WriteRelationship(ctx context.Context, relationship domain.Relationship) error
+	// DeleteRelationship removes the relationship, deleting a missing one is not an error. domain.ErrRelationshipNotAllowed is returned if its kind is not allowed to be managed.
+	DeleteRelationship(ctx context.Context, relationship domain.Relationship) error
+	// ReadRelationships lists up to limit relationships matching the filter, starting after the given cursor. Pages read with the cursor of a previous page see the same revision of the store.
+	// A limit of 0 uses the default page size of the repository, a negative limit fails with domain.ErrInvalidRequest.
+	ReadRelationships(ctx context.Context, filter domain.RelationshipFilter, cursor string, limit int) (*domain.RelationshipPage, error)
+}
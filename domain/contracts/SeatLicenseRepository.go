@@ -2,18 +2,37 @@ package contracts
 
 import (
 	"authz/domain"
+	"context"
 )
 
 // SeatLicenseRepository is a contract that describes the required operations for accessing and manipulating per-seat license data
 type SeatLicenseRepository interface {
 	// AssignSeat assigns the given principal a seat for the given service
-	AssignSeat(subjectID domain.SubjectID, orgID string, svc domain.Service) error
+	AssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error
 	// UnAssignSeat removes the seat assignment for the given principal for the given service
-	UnAssignSeat(subjectID domain.SubjectID, orgID string, svc domain.Service) error
+	UnAssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error
+	// AssignSeats assigns the given principals seats for the given service. Either all or none are assigned.
+	AssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error
+	// UnAssignSeats removes the seat assignments for the given principals for the given service. Either all or none are removed.
+	UnAssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error
 	// GetLicense retrieves the stored license for the given organization and service, if any.
-	GetLicense(orgID string, serviceID string) (*domain.License, error)
+	GetLicense(ctx context.Context, orgID string, serviceID string) (*domain.License, error)
 	// GetAssigned retrieves the IDs of the subjects assigned seats in the current license
-	GetAssigned(orgID string, serviceID string) ([]domain.SubjectID, error)
+	GetAssigned(ctx context.Context, orgID string, serviceID string) ([]domain.SubjectID, error)
+	// RecountSeats replaces the recorded number of seats in use of the license by the number of actually assigned seats, and returns both numbers.
+	// It repairs drift, ex: from partial writes or manual edits of the store, and fails rather than miscount if the license is modified concurrently.
+	RecountSeats(ctx context.Context, orgID string, serviceID string) (recorded int, actual int, err error)
+	// CreateLicense creates a license with the given max seats and no assigned seats. It fails with domain.ErrLicenseAlreadyExists if the organization already holds a license for the service.
+	CreateLicense(ctx context.Context, orgID string, serviceID string, maxSeats int) error
+	// DeleteLicense deletes the license and all its seat assignments, and returns the number of seats released. Deleting a missing license is a no-op.
+	DeleteLicense(ctx context.Context, orgID string, serviceID string) (int, error)
+	// SetMaxSeats replaces the max seats of the license. It fails with domain.ErrSeatLimitBelowInUse if more seats are in use than the new limit allows,
+	// and rather than oversell the license if seats are assigned concurrently.
+	SetMaxSeats(ctx context.Context, orgID string, serviceID string, maxSeats int) error
+	// ListServices retrieves the services the given organization holds a license for
+	ListServices(ctx context.Context, orgID string) ([]domain.Service, error)
+	// ListAssignedServices retrieves the services of the given organization the given subject is assigned a seat for
+	ListAssignedServices(ctx context.Context, orgID string, subjectID domain.SubjectID) ([]domain.Service, error)
 }
 
 // TODO
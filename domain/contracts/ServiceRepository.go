@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"authz/domain"
+)
+
+// ServiceRepository is a contract that describes the required operations for accessing service metadata
+type ServiceRepository interface {
+	// GetByID retrieves the service with the given ID including its display metadata. Unknown services are returned with only their ID set. If any error occurs, it's returned.
+	GetByID(id string) (domain.Service, error)
+}
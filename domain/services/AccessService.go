@@ -4,32 +4,87 @@ package services
 import (
 	"authz/domain"
 	"authz/domain/contracts"
+	"context"
 )
 
 // AccessService is a domain service for abstract access management (ex: querying whether access has been granted.)
 type AccessService struct {
 	accessRepository contracts.AccessRepository
+	auditOperation   string
 }
 
 // NewAccessService constructs a new instance of the Access domain service
 func NewAccessService(accessRepository contracts.AccessRepository) AccessService {
-	return AccessService{accessRepository}
+	return AccessService{accessRepository: accessRepository}
+}
+
+// SetAuditOperation sets the operation on a resource requestors must be allowed to list all subjects with access to it. Without it, no requestor may.
+func (a *AccessService) SetAuditOperation(operation string) {
+	a.auditOperation = operation
 }
 
 // Check processes a CheckEvent and returns true or false if successful, otherwise error
 func (a AccessService) Check(req domain.CheckEvent) (domain.AccessDecision, error) {
+	return a.CheckWithContext(context.Background(), req)
+}
+
+// CheckWithContext processes a CheckEvent like Check, passing the given context on to the repository so deadlines and cancellation are honored
+func (a AccessService) CheckWithContext(ctx context.Context, req domain.CheckEvent) (domain.AccessDecision, error) {
 	if !req.Requestor.HasIdentity() {
-		return false, domain.ErrNotAuthenticated
+		return domain.AccessDecision{}, domain.ErrNotAuthenticated
 	}
 
 	accessResult, err := true, error(nil) //a.accessRepository.CheckAccess(req.Requestor, "call", model.Resource{Type: "endpoint", ID: "checkaccess"}) //TODO: implement actual meta-authz
 	if err != nil {
-		return false, err
+		return domain.AccessDecision{}, err
 	}
 
 	if !accessResult {
-		return false, domain.ErrNotAuthorized
+		return domain.AccessDecision{}, domain.ErrNotAuthorized
+	}
+
+	return a.accessRepository.CheckAccess(ctx, req.SubjectID, req.Operation, req.Resource)
+}
+
+// LookupResources processes a LookupResourcesEvent and returns the resources the subject can access, otherwise error
+func (a AccessService) LookupResources(ctx context.Context, req domain.LookupResourcesEvent) ([]domain.Resource, error) {
+	if !req.Requestor.HasIdentity() {
+		return nil, domain.ErrNotAuthenticated
+	}
+
+	return a.accessRepository.LookupAccessibleResources(ctx, req.SubjectID, req.Operation, req.ResourceType)
+}
+
+// LookupSubjects processes a LookupSubjectsEvent and returns the subjects with access to the resource, otherwise error.
+// This is meant for audits, so the requestor must be allowed the audit operation on the resource. See SetAuditOperation.
+func (a AccessService) LookupSubjects(ctx context.Context, req domain.LookupSubjectsEvent) ([]domain.SubjectID, error) {
+	if !req.Requestor.HasIdentity() {
+		return nil, domain.ErrNotAuthenticated
+	}
+
+	if a.auditOperation == "" {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	isAuditor, err := a.accessRepository.CheckAccess(ctx, req.Requestor, a.auditOperation, req.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAuditor.IsAllowed() {
+		return nil, domain.ErrNotAuthorized
+	}
+
+	return a.accessRepository.LookupAccessingSubjects(ctx, req.Operation, req.Resource)
+}
+
+// CheckBulk processes multiple CheckEvents at once and returns the decisions and the errors of the failed checks in the same order as the events.
+// The error is only set if the batch cannot be processed at all, ex: an unauthenticated requestor.
+func (a AccessService) CheckBulk(ctx context.Context, requestor domain.SubjectID, events []domain.CheckEvent) ([]domain.AccessDecision, []error, error) {
+	if !requestor.HasIdentity() {
+		return nil, nil, domain.ErrNotAuthenticated
 	}
 
-	return a.accessRepository.CheckAccess(req.SubjectID, req.Operation, req.Resource)
+	decisions, errs := a.accessRepository.CheckAccessBulk(ctx, events)
+	return decisions, errs, nil
 }
@@ -4,6 +4,8 @@ import (
 	"authz/domain"
 	"authz/domain/contracts"
 	"authz/infrastructure/repository/mock"
+	"context"
+	"errors"
 	"testing"
 )
 
@@ -35,7 +37,7 @@ func TestCheckReturnsTrueWhenStoreReturnsTrue(t *testing.T) {
 		t.Errorf("Expected a result, got error: %s", err)
 	}
 
-	if result != true {
+	if !result.IsAllowed() {
 		t.Errorf("Expected success, got fail.")
 	}
 }
@@ -53,11 +55,89 @@ func TestCheckReturnsFalseWhenStoreReturnsFalse(t *testing.T) {
 		t.Errorf("Expected a result, got error: %s", err)
 	}
 
-	if result != false {
+	if result.IsAllowed() {
 		t.Errorf("Expected fail, got success.")
 	}
 }
 
+func TestCheckBulkReturnsResultsInRequestOrder(t *testing.T) {
+	access := NewAccessService(mockAuthzRepository())
+	result, errs, err := access.CheckBulk(context.Background(), "system", []domain.CheckEvent{
+		objFromRequest("system", "okay", "check", "license", "seat"),
+		objFromRequest("system", "bad", "check", "license", "seat"),
+		objFromRequest("system", "okay", "check", "license", "seat"),
+	})
+
+	if err != nil {
+		t.Errorf("Expected a result, got error: %s", err)
+	}
+
+	if len(errs) != 3 || errs[0] != nil || errs[1] != nil || errs[2] != nil {
+		t.Errorf("Expected no check errors, got %v", errs)
+	}
+
+	if len(result) != 3 || !result[0].IsAllowed() || result[1].IsAllowed() || !result[2].IsAllowed() {
+		t.Errorf("Expected [true false true], got %v", result)
+	}
+}
+
+func TestCheckBulkReturnsErrorsPerEvent(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	if err := store.SeedLicense("aspian", "smarts", 10); err != nil {
+		t.Fatalf("Expected a license, got error: %s", err)
+	}
+	access := NewAccessService(store)
+
+	result, errs, err := access.CheckBulk(context.Background(), "system", []domain.CheckEvent{
+		objFromRequest("system", "okay", "access", "license", "aspian/smarts"),
+		objFromRequest("system", "okay", "access", "license", "aspian/smarts/extra"),
+		objFromRequest("system", "okay", "access", "license", "aspian/smarts"),
+	})
+
+	if err != nil {
+		t.Errorf("Expected results, got error: %s", err)
+	}
+
+	if len(result) != 3 || len(errs) != 3 || errs[0] != nil || !errors.Is(errs[1], domain.ErrInvalidResourceID) || errs[2] != nil {
+		t.Errorf("Expected only the second check to fail, got %v", errs)
+	}
+}
+
+func TestCheckBulkErrorsWhenNotAuthenticated(t *testing.T) {
+	access := NewAccessService(mockAuthzRepository())
+	_, _, err := access.CheckBulk(context.Background(), "", []domain.CheckEvent{
+		objFromRequest("", "okay", "check", "license", "seat"),
+	})
+
+	if err != domain.ErrNotAuthenticated {
+		t.Errorf("Expected authentication error, got: %v", err)
+	}
+}
+
+func TestLookupResourcesReturnsLicensedServices(t *testing.T) {
+	store := mockAuthzRepository()
+	err := store.(contracts.SeatLicenseRepository).AssignSeat(context.Background(), "okay", "aspian", domain.Service{ID: "smarts"})
+	if err != nil {
+		t.Errorf("Expected assignment, got error: %s", err)
+	}
+
+	access := NewAccessService(store)
+	result, err := access.LookupResources(context.Background(), domain.LookupResourcesEvent{
+		Request:      domain.Request{Requestor: "system"},
+		Operation:    "use",
+		SubjectID:    "okay",
+		ResourceType: "service",
+	})
+
+	if err != nil {
+		t.Errorf("Expected a result, got error: %s", err)
+	}
+
+	if len(result) != 1 || result[0] != (domain.Resource{Type: "service", ID: "smarts"}) {
+		t.Errorf("Expected [service:smarts], got %v", result)
+	}
+}
+
 func objFromRequest(requestorID string, subjectID string, operation string, resourceType string, resourceID string) domain.CheckEvent {
 	return domain.CheckEvent{
 		Request: domain.Request{
@@ -76,3 +156,30 @@ func mockAuthzRepository() contracts.AccessRepository {
 		"bad":    false,
 	}, LicensedSeats: map[string]map[domain.SubjectID]bool{}}
 }
+
+func TestLookupSubjectsErrorsWhenNotAuthorized(t *testing.T) {
+	access := NewAccessService(mockAuthzRepository())
+	access.SetAuditOperation("audit")
+	_, err := access.LookupSubjects(context.Background(), domain.LookupSubjectsEvent{
+		Request:   domain.Request{Requestor: "bad"},
+		Operation: "read",
+		Resource:  domain.Resource{Type: "service", ID: "smarts"},
+	})
+
+	if err != domain.ErrNotAuthorized {
+		t.Errorf("Expected authorization error, got: %v", err)
+	}
+}
+
+func TestLookupSubjectsErrorsWithoutAuditOperation(t *testing.T) {
+	access := NewAccessService(mockAuthzRepository())
+	_, err := access.LookupSubjects(context.Background(), domain.LookupSubjectsEvent{
+		Request:   domain.Request{Requestor: "system"},
+		Operation: "read",
+		Resource:  domain.Resource{Type: "service", ID: "smarts"},
+	})
+
+	if err != domain.ErrNotAuthorized {
+		t.Errorf("Expected authorization error, got: %v", err)
+	}
+}
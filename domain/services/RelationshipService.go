@@ -0,0 +1,112 @@
+package services
+
+import (
+	"authz/domain"
+	"authz/domain/contracts"
+	"context"
+	"fmt"
+)
+
+// DefaultRelationshipPageSize and MaxRelationshipPageSize bound the relationships returned per page by ReadRelationships
+const (
+	DefaultRelationshipPageSize = 100
+	MaxRelationshipPageSize     = 1000
+)
+
+// relationshipsEndpoint is the resource the admin operation is checked on. Raw relationships span all organizations, so no organization can authorize managing them.
+var relationshipsEndpoint = domain.Resource{Type: "endpoint", ID: "relationships"}
+
+// RelationshipService is a domain service for managing and inspecting the raw relationships of the authorization store, ex: group memberships or to troubleshoot seat discrepancies
+type RelationshipService struct {
+	relationships contracts.RelationshipRepository
+	authz         contracts.AccessRepository
+	// adminOperation is the operation on the relationships endpoint a requestor must be allowed to manage relationships, empty denies all requestors
+	adminOperation string
+}
+
+// NewRelationshipService constructs a new RelationshipService
+func NewRelationshipService(relationships contracts.RelationshipRepository, authz contracts.AccessRepository) *RelationshipService {
+	return &RelationshipService{relationships: relationships, authz: authz}
+}
+
+// SetAdminOperation sets the operation on the endpoint:relationships resource, ex: "administer", that requestors must be allowed to write, delete and read relationships. Without it, no requestor may.
+func (r *RelationshipService) SetAdminOperation(operation string) {
+	r.adminOperation = operation
+}
+
+// WriteRelationship creates the relationship of the event. It requires the admin operation, see SetAdminOperation, and the kind of relationship must be allowed by the repository.
+func (r *RelationshipService) WriteRelationship(ctx context.Context, evt domain.ModifyRelationshipEvent) error {
+	if err := r.validateModification(ctx, evt); err != nil {
+		return err
+	}
+
+	return r.relationships.WriteRelationship(ctx, evt.Relationship)
+}
+
+// DeleteRelationship removes the relationship of the event. It requires the admin operation, see SetAdminOperation, and the kind of relationship must be allowed by the repository.
+func (r *RelationshipService) DeleteRelationship(ctx context.Context, evt domain.ModifyRelationshipEvent) error {
+	if err := r.validateModification(ctx, evt); err != nil {
+		return err
+	}
+
+	return r.relationships.DeleteRelationship(ctx, evt.Relationship)
+}
+
+func (r *RelationshipService) validateModification(ctx context.Context, evt domain.ModifyRelationshipEvent) error {
+	if err := r.ensureRequestorIsAdmin(ctx, evt.Requestor); err != nil {
+		return err
+	}
+
+	rel := evt.Relationship
+	if rel.Resource.Type == "" || rel.Resource.ID == "" || rel.Relation == "" || rel.Subject.Type == "" || rel.Subject.ID == "" {
+		return fmt.Errorf("%w: the resource, relation and subject of a relationship are required", domain.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+// ReadRelationships lists one page of the relationships matching the filter of the event. It requires the admin operation, see SetAdminOperation.
+func (r *RelationshipService) ReadRelationships(ctx context.Context, evt domain.ReadRelationshipsEvent) (*domain.RelationshipPage, error) {
+	if err := r.ensureRequestorIsAdmin(ctx, evt.Requestor); err != nil {
+		return nil, err
+	}
+
+	if evt.Filter.ResourceType == "" {
+		return nil, fmt.Errorf("%w: a resource type is required to read relationships", domain.ErrInvalidRequest)
+	}
+
+	if evt.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidRequest)
+	}
+
+	limit := evt.Limit
+	if limit == 0 {
+		limit = DefaultRelationshipPageSize
+	}
+	if limit > MaxRelationshipPageSize {
+		limit = MaxRelationshipPageSize
+	}
+
+	return r.relationships.ReadRelationships(ctx, evt.Filter, evt.Cursor, limit)
+}
+
+func (r *RelationshipService) ensureRequestorIsAdmin(ctx context.Context, requestor domain.SubjectID) error {
+	if !requestor.HasIdentity() {
+		return domain.ErrNotAuthenticated
+	}
+
+	if r.adminOperation == "" {
+		return domain.ErrNotAuthorized
+	}
+
+	decision, err := r.authz.CheckAccess(ctx, requestor, r.adminOperation, relationshipsEndpoint)
+	if err != nil {
+		return err
+	}
+
+	if !decision.IsAllowed() {
+		return domain.ErrNotAuthorized
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"authz/domain"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadRelationshipsRequiresResourceType(t *testing.T) {
+	svc := NewRelationshipService(&stubRelationshipRepository{}, mockAuthzRepository())
+	svc.SetAdminOperation("read")
+
+	_, err := svc.ReadRelationships(context.Background(), domain.ReadRelationshipsEvent{Request: domain.Request{Requestor: "system"}})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+}
+
+func TestReadRelationshipsErrorsWhenNotAuthenticated(t *testing.T) {
+	svc := NewRelationshipService(&stubRelationshipRepository{}, mockAuthzRepository())
+	svc.SetAdminOperation("read")
+
+	_, err := svc.ReadRelationships(context.Background(), domain.ReadRelationshipsEvent{Filter: domain.RelationshipFilter{ResourceType: "license"}})
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
+}
+
+func TestReadRelationshipsErrorsWhenNotAuthorized(t *testing.T) {
+	repo := &stubRelationshipRepository{}
+	svc := NewRelationshipService(repo, mockAuthzRepository())
+	svc.SetAdminOperation("read")
+	evt := domain.ReadRelationshipsEvent{Request: domain.Request{Requestor: "bad"}, Filter: domain.RelationshipFilter{ResourceType: "license"}}
+
+	_, err := svc.ReadRelationships(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+	assert.Zero(t, repo.limit, "The relationships should not have been read.")
+}
+
+func TestReadRelationshipsErrorsWithoutAdminOperation(t *testing.T) {
+	svc := NewRelationshipService(&stubRelationshipRepository{}, mockAuthzRepository())
+	evt := domain.ReadRelationshipsEvent{Request: domain.Request{Requestor: "system"}, Filter: domain.RelationshipFilter{ResourceType: "license"}}
+
+	_, err := svc.ReadRelationships(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+}
+
+func TestReadRelationshipsBoundsPageSize(t *testing.T) {
+	repo := &stubRelationshipRepository{}
+	svc := NewRelationshipService(repo, mockAuthzRepository())
+	svc.SetAdminOperation("read")
+	evt := domain.ReadRelationshipsEvent{Request: domain.Request{Requestor: "system"}, Filter: domain.RelationshipFilter{ResourceType: "license"}}
+
+	_, err := svc.ReadRelationships(context.Background(), evt)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultRelationshipPageSize, repo.limit)
+
+	evt.Limit = 1000000
+	_, err = svc.ReadRelationships(context.Background(), evt)
+	assert.NoError(t, err)
+	assert.Equal(t, MaxRelationshipPageSize, repo.limit)
+}
+
+func TestWriteRelationshipWritesAsAdmin(t *testing.T) {
+	repo := &stubRelationshipRepository{}
+	svc := NewRelationshipService(repo, mockAuthzRepository())
+	svc.SetAdminOperation("administer")
+	evt := domain.ModifyRelationshipEvent{Request: domain.Request{Requestor: "system"}, Relationship: groupMembership()}
+
+	err := svc.WriteRelationship(context.Background(), evt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Relationship{groupMembership()}, repo.written)
+}
+
+func TestDeleteRelationshipDeletesAsAdmin(t *testing.T) {
+	repo := &stubRelationshipRepository{}
+	svc := NewRelationshipService(repo, mockAuthzRepository())
+	svc.SetAdminOperation("administer")
+	evt := domain.ModifyRelationshipEvent{Request: domain.Request{Requestor: "system"}, Relationship: groupMembership()}
+
+	err := svc.DeleteRelationship(context.Background(), evt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Relationship{groupMembership()}, repo.deleted)
+}
+
+func TestWriteRelationshipErrorsWhenNotAuthorized(t *testing.T) {
+	repo := &stubRelationshipRepository{}
+	svc := NewRelationshipService(repo, mockAuthzRepository())
+	svc.SetAdminOperation("administer")
+	evt := domain.ModifyRelationshipEvent{Request: domain.Request{Requestor: "bad"}, Relationship: groupMembership()}
+
+	err := svc.WriteRelationship(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+	assert.Empty(t, repo.written, "The relationship should not have been written.")
+}
+
+func TestDeleteRelationshipErrorsWithoutAdminOperation(t *testing.T) {
+	repo := &stubRelationshipRepository{}
+	svc := NewRelationshipService(repo, mockAuthzRepository())
+	evt := domain.ModifyRelationshipEvent{Request: domain.Request{Requestor: "system"}, Relationship: groupMembership()}
+
+	err := svc.DeleteRelationship(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+	assert.Empty(t, repo.deleted, "The relationship should not have been deleted.")
+}
+
+func TestWriteRelationshipRequiresAllParts(t *testing.T) {
+	svc := NewRelationshipService(&stubRelationshipRepository{}, mockAuthzRepository())
+	svc.SetAdminOperation("administer")
+	rel := groupMembership()
+	rel.Subject.ID = ""
+
+	err := svc.WriteRelationship(context.Background(), domain.ModifyRelationshipEvent{Request: domain.Request{Requestor: "system"}, Relationship: rel})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+}
+
+func groupMembership() domain.Relationship {
+	return domain.Relationship{
+		Resource: domain.Resource{Type: "group", ID: "g1"},
+		Relation: "member",
+		Subject:  domain.Resource{Type: "user", ID: "u1"},
+	}
+}
+
+func TestReadRelationshipsRejectsNegativeLimit(t *testing.T) {
+	repo := &stubRelationshipRepository{}
+	svc := NewRelationshipService(repo, mockAuthzRepository())
+	svc.SetAdminOperation("read")
+	evt := domain.ReadRelationshipsEvent{Request: domain.Request{Requestor: "system"}, Filter: domain.RelationshipFilter{ResourceType: "license"}, Limit: -1}
+
+	_, err := svc.ReadRelationships(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+	assert.Zero(t, repo.limit, "The relationships should not have been read.")
+}
+
+type stubRelationshipRepository struct {
+	limit   int
+	written []domain.Relationship
+	deleted []domain.Relationship
+}
+
+func (s *stubRelationshipRepository) WriteRelationship(_ context.Context, relationship domain.Relationship) error {
+	s.written = append(s.written, relationship)
+	return nil
+}
+
+func (s *stubRelationshipRepository) DeleteRelationship(_ context.Context, relationship domain.Relationship) error {
+	s.deleted = append(s.deleted, relationship)
+	return nil
+}
+
+func (s *stubRelationshipRepository) ReadRelationships(_ context.Context, _ domain.RelationshipFilter, _ string, limit int) (*domain.RelationshipPage, error) {
+	s.limit = limit
+	return &domain.RelationshipPage{}, nil
+}
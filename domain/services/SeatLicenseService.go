@@ -3,29 +3,67 @@ package services
 import (
 	"authz/domain"
 	"authz/domain/contracts"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
 )
 
 // SeatLicenseService performs operations related to per-seat licensing
 type SeatLicenseService struct {
-	seats contracts.SeatLicenseRepository
-	authz contracts.AccessRepository
+	seats      contracts.SeatLicenseRepository
+	authz      contracts.AccessRepository
+	principals contracts.PrincipalRepository
+	audit      contracts.AuditLog
+	services   contracts.ServiceRepository
+	// verifyMembership makes ModifySeats reject assignments of subjects that are not members of the organization
+	verifyMembership bool
+	// manageOperation is the operation on the organization a requestor must be allowed to manage its licenses, empty only requires an identity
+	manageOperation string
+	// viewOperation is the operation on the organization a requestor must be allowed to read its licenses and seats, empty only requires an identity
+	viewOperation string
+	// adminOperation is the operation on the organization a requestor must be allowed to create, delete, resize and reconcile its licenses, empty denies all requestors
+	adminOperation string
 }
 
+// maxConcurrentMembershipChecks limits the membership checks of one ModifySeats that are in flight at the same time
+const maxConcurrentMembershipChecks = 16
+
+// bulkAssignBatchSize is the number of seats BulkAssignSeats assigns in one transaction
+const bulkAssignBatchSize = 100
+
 // ModifySeats handles ModifySeatAssignmentEvents to assign and unassign seats
-func (l *SeatLicenseService) ModifySeats(evt domain.ModifySeatAssignmentEvent) error {
-	if err := l.ensureRequestorIsAuthorizedToManageLicenses(evt.Requestor); err != nil {
+func (l *SeatLicenseService) ModifySeats(ctx context.Context, evt domain.ModifySeatAssignmentEvent) error {
+	if err := l.ensureRequestorIsAuthorizedToManageLicenses(ctx, evt.Requestor, evt.Org.ID); err != nil {
+		l.recordSeatResult(evt, domain.SeatAuditActionUnassign, evt.UnAssign, err)
+		l.recordSeatResult(evt, domain.SeatAuditActionAssign, evt.Assign, err)
 		return err
 	}
 
-	//TODO: consistency? Atm, if an error occurs part-way through, this will partially save.
-	for _, principal := range evt.UnAssign {
-		if err := l.seats.UnAssignSeat(principal, evt.Org.ID, evt.Service); err != nil {
+	if l.verifyMembership && len(evt.Assign) > 0 {
+		if err := l.ensureSubjectsAreMembers(evt.Org.ID, evt.Assign); err != nil {
+			l.recordSeatEvent(evt, domain.SeatAuditActionUnassign, evt.UnAssign, domain.SeatAuditResultSkipped, nil)
+			l.recordSeatEvent(evt, domain.SeatAuditActionAssign, evt.Assign, domain.SeatAuditResultFailure, err)
+			return err
+		}
+	}
+
+	//TODO: consistency? Unassignments and assignments are each atomic, but if assigning fails, the unassignments are already saved.
+	if len(evt.UnAssign) > 0 {
+		err := l.seats.UnAssignSeats(ctx, evt.UnAssign, evt.Org.ID, evt.Service)
+		l.recordSeatResult(evt, domain.SeatAuditActionUnassign, evt.UnAssign, err)
+		if err != nil {
+			l.recordSeatEvent(evt, domain.SeatAuditActionAssign, evt.Assign, domain.SeatAuditResultSkipped, nil)
 			return err
 		}
 	}
 
-	for _, principal := range evt.Assign {
-		if err := l.seats.AssignSeat(principal, evt.Org.ID, evt.Service); err != nil {
+	if len(evt.Assign) > 0 {
+		err := l.seats.AssignSeats(ctx, evt.Assign, evt.Org.ID, evt.Service)
+		l.recordSeatResult(evt, domain.SeatAuditActionAssign, evt.Assign, err)
+		if err != nil {
 			return err
 		}
 	}
@@ -33,40 +71,581 @@ func (l *SeatLicenseService) ModifySeats(evt domain.ModifySeatAssignmentEvent) e
 	return nil
 }
 
+// BulkAssignSeats assigns seats to a large number of subjects in batches of bulkAssignBatchSize, each assigned atomically.
+// Subjects that already have a seat are skipped. Once the license runs out of seats, the remaining subjects are skipped and LimitReached is set.
+// A batch that fails is counted as failed and the next batch is assigned. If the context ends, the summary so far is returned with the error.
+func (l *SeatLicenseService) BulkAssignSeats(ctx context.Context, evt domain.BulkAssignSeatsEvent) (*domain.BulkAssignSummary, error) {
+	auditEvt := domain.ModifySeatAssignmentEvent{Request: evt.Request, Org: evt.Org, Service: evt.Service}
+	if err := l.ensureRequestorIsAuthorizedToManageLicenses(ctx, evt.Requestor, evt.Org.ID); err != nil {
+		l.recordSeatResult(auditEvt, domain.SeatAuditActionAssign, evt.Subjects, err)
+		return nil, err
+	}
+
+	assigned, err := l.seats.GetAssigned(ctx, evt.Org.ID, evt.Service.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[domain.SubjectID]bool, len(assigned)+len(evt.Subjects))
+	for _, subject := range assigned {
+		seen[subject] = true
+	}
+
+	summary := &domain.BulkAssignSummary{}
+	pending := make([]domain.SubjectID, 0, len(evt.Subjects))
+	for _, subject := range evt.Subjects {
+		if seen[subject] {
+			summary.SkippedAlreadyAssigned++
+			continue
+		}
+		seen[subject] = true
+		pending = append(pending, subject)
+	}
+
+	for len(pending) > 0 {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		size := bulkAssignBatchSize
+		if len(pending) < size {
+			size = len(pending)
+		}
+		batch := pending[:size]
+		pending = pending[size:]
+
+		if l.verifyMembership {
+			nonMembers, err := l.findNonMembers(evt.Org.ID, batch)
+			if err != nil {
+				return summary, err
+			}
+			if len(nonMembers) > 0 {
+				summary.Failed += len(nonMembers)
+				l.recordSeatEvent(auditEvt, domain.SeatAuditActionAssign, nonMembers, domain.SeatAuditResultFailure, &domain.NotMemberError{OrgID: evt.Org.ID, SubjectIDs: nonMembers})
+				batch = withoutSubjects(batch, nonMembers)
+			}
+		}
+
+		lic, err := l.seats.GetLicense(ctx, evt.Org.ID, evt.Service.ID)
+		if err != nil {
+			return summary, err
+		}
+
+		if available := lic.GetAvailableSeats(); len(batch) > available {
+			if available < 0 {
+				available = 0
+			}
+			skipped := append(append([]domain.SubjectID{}, batch[available:]...), pending...)
+			summary.LimitReached = true
+			summary.SkippedLimit += len(skipped)
+			l.recordSeatEvent(auditEvt, domain.SeatAuditActionAssign, skipped, domain.SeatAuditResultSkipped, nil)
+			batch = batch[:available]
+			pending = nil
+		}
+
+		if len(batch) == 0 {
+			continue
+		}
+
+		err = l.seats.AssignSeats(ctx, batch, evt.Org.ID, evt.Service)
+		if errors.Is(err, domain.ErrSeatLimitExceeded) { //Seats were assigned concurrently since the license was read
+			skipped := append(append([]domain.SubjectID{}, batch...), pending...)
+			summary.LimitReached = true
+			summary.SkippedLimit += len(skipped)
+			l.recordSeatEvent(auditEvt, domain.SeatAuditActionAssign, skipped, domain.SeatAuditResultSkipped, err)
+			break
+		}
+		l.recordSeatResult(auditEvt, domain.SeatAuditActionAssign, batch, err)
+		if err != nil {
+			summary.Failed += len(batch)
+			continue
+		}
+		summary.Assigned += len(batch)
+	}
+
+	return summary, nil
+}
+
+// UnassignAllForSubject removes the seats of the subject in every service of the organization and returns the services it held a seat for.
+// Services without a seat of the subject are left alone, so unassigning a subject that holds no seats is not an error. If an unassignment fails, the services unassigned so far are returned with the error.
+// As it spans all licenses of the organization, it requires the admin operation, see SetAdminOperation.
+func (l *SeatLicenseService) UnassignAllForSubject(ctx context.Context, evt domain.UnassignAllForSubjectEvent) ([]domain.Service, error) {
+	subjects := []domain.SubjectID{evt.Subject}
+	if err := l.ensureRequestorIsLicenseAdmin(ctx, evt.Requestor, evt.OrgID); err != nil {
+		l.recordSeatResult(domain.ModifySeatAssignmentEvent{Request: evt.Request, Org: domain.Organization{ID: evt.OrgID}}, domain.SeatAuditActionUnassign, subjects, err)
+		return nil, err
+	}
+
+	assignedServices, err := l.seats.ListAssignedServices(ctx, evt.OrgID, evt.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	unassigned := make([]domain.Service, 0, len(assignedServices))
+	for _, svc := range assignedServices {
+		auditEvt := domain.ModifySeatAssignmentEvent{Request: evt.Request, Org: domain.Organization{ID: evt.OrgID}, Service: svc, UnAssign: subjects}
+		err := l.seats.UnAssignSeats(ctx, subjects, evt.OrgID, svc)
+		l.recordSeatResult(auditEvt, domain.SeatAuditActionUnassign, subjects, err)
+		if err != nil {
+			return unassigned, err
+		}
+		unassigned = append(unassigned, svc)
+	}
+
+	return unassigned, nil
+}
+
+// ReclaimDisabledSeats removes the seats of the license held by subjects that are disabled or unknown to the principal repository, and returns the reclaimed subjects. Each of them frees one seat.
+// The seats are removed in one transaction. Reclaiming again finds nothing left to reclaim, which is not an error. If a seat is unassigned concurrently, the transaction fails and can be retried.
+func (l *SeatLicenseService) ReclaimDisabledSeats(ctx context.Context, evt domain.ReclaimDisabledSeatsEvent) ([]domain.SubjectID, error) {
+	if err := l.ensureRequestorIsAuthorizedToManageLicenses(ctx, evt.Requestor, evt.Org.ID); err != nil {
+		return nil, err
+	}
+
+	assigned, err := l.seats.GetAssigned(ctx, evt.Org.ID, evt.Service.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stale, err := l.findDisabled(assigned)
+	if err != nil || len(stale) == 0 {
+		return stale, err
+	}
+
+	auditEvt := domain.ModifySeatAssignmentEvent{Request: evt.Request, Org: evt.Org, Service: evt.Service, UnAssign: stale}
+	err = l.seats.UnAssignSeats(ctx, stale, evt.Org.ID, evt.Service)
+	l.recordSeatResult(auditEvt, domain.SeatAuditActionUnassign, stale, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return stale, nil
+}
+
+// CreateLicense provisions a new license with the given seat limit and no assigned seats. It fails with domain.ErrLicenseAlreadyExists if the organization already holds a license for the service.
+func (l *SeatLicenseService) CreateLicense(ctx context.Context, evt domain.CreateLicenseEvent) error {
+	if err := l.ensureRequestorIsLicenseAdmin(ctx, evt.Requestor, evt.Org.ID); err != nil {
+		return err
+	}
+
+	if evt.Org.ID == "" || evt.Service.ID == "" {
+		return fmt.Errorf("%w: an organization and a service are required to create a license", domain.ErrInvalidRequest)
+	}
+	if evt.MaxSeats < 0 {
+		return fmt.Errorf("%w: max seats must not be negative, got %d", domain.ErrInvalidRequest, evt.MaxSeats)
+	}
+
+	return l.seats.CreateLicense(ctx, evt.Org.ID, evt.Service.ID, evt.MaxSeats)
+}
+
+// DeleteLicense deletes the license and all its seat assignments, and returns the number of seats released. Deleting a license that does not exist is a no-op.
+func (l *SeatLicenseService) DeleteLicense(ctx context.Context, evt domain.DeleteLicenseEvent) (int, error) {
+	if err := l.ensureRequestorIsLicenseAdmin(ctx, evt.Requestor, evt.Org.ID); err != nil {
+		return 0, err
+	}
+
+	return l.seats.DeleteLicense(ctx, evt.Org.ID, evt.Service.ID)
+}
+
+// SetMaxSeats changes the seat limit of the license. A limit below the number of seats in use is rejected with domain.ErrSeatLimitBelowInUse, the seats have to be unassigned first.
+func (l *SeatLicenseService) SetMaxSeats(ctx context.Context, evt domain.SetMaxSeatsEvent) error {
+	if err := l.ensureRequestorIsLicenseAdmin(ctx, evt.Requestor, evt.Org.ID); err != nil {
+		return err
+	}
+
+	if evt.MaxSeats < 0 {
+		return fmt.Errorf("%w: max seats must not be negative, got %d", domain.ErrInvalidRequest, evt.MaxSeats)
+	}
+
+	return l.seats.SetMaxSeats(ctx, evt.Org.ID, evt.Service.ID, evt.MaxSeats)
+}
+
+// CheckOrAssignSeat allows the subject to access the license if it holds a seat. Otherwise, a seat is assigned if one is available and access is allowed, else it is denied.
+// The assignment is checked against the seat limit when written, so concurrent grants for the last seat cannot oversell the license: the losing grant is denied.
+func (l *SeatLicenseService) CheckOrAssignSeat(ctx context.Context, evt domain.CheckOrAssignSeatEvent) (*domain.SeatGrantDecision, error) {
+	subjects := []domain.SubjectID{evt.Subject}
+	auditEvt := domain.ModifySeatAssignmentEvent{Request: evt.Request, Org: evt.Org, Service: evt.Service, Assign: subjects}
+	if err := l.ensureRequestorIsAuthorizedToManageLicenses(ctx, evt.Requestor, evt.Org.ID); err != nil {
+		l.recordSeatResult(auditEvt, domain.SeatAuditActionAssign, subjects, err)
+		return nil, err
+	}
+
+	license := domain.Resource{Type: "license", ID: domain.LicenseResourceID(evt.Org.ID, evt.Service.ID)}
+	current, err := l.authz.CheckAccess(ctx, evt.Subject, "access", license)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsAllowed() {
+		return &domain.SeatGrantDecision{AccessDecision: current, Outcome: domain.SeatGrantAlreadyAssigned}, nil
+	}
+
+	noSeat := &domain.SeatGrantDecision{AccessDecision: domain.Deny("no seat available"), Outcome: domain.SeatGrantNoSeatAvailable}
+	lic, err := l.seats.GetLicense(ctx, evt.Org.ID, evt.Service.ID)
+	if err != nil {
+		return nil, err
+	}
+	if lic.GetAvailableSeats() <= 0 {
+		return noSeat, nil
+	}
+
+	if l.verifyMembership {
+		if err := l.ensureSubjectsAreMembers(evt.Org.ID, subjects); err != nil {
+			l.recordSeatEvent(auditEvt, domain.SeatAuditActionAssign, subjects, domain.SeatAuditResultFailure, err)
+			return nil, err
+		}
+	}
+
+	err = l.seats.AssignSeats(ctx, subjects, evt.Org.ID, evt.Service)
+	l.recordSeatResult(auditEvt, domain.SeatAuditActionAssign, subjects, err)
+	if errors.Is(err, domain.ErrSeatLimitExceeded) { //The last seats were taken since the license was read
+		return noSeat, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SeatGrantDecision{AccessDecision: domain.Allow(), Outcome: domain.SeatGrantAssigned}, nil
+}
+
 // GetLicense gets the License for the provided information
-func (l *SeatLicenseService) GetLicense(evt domain.GetLicenseEvent) (*domain.License, error) {
-	if err := l.ensureRequestorIsAuthorizedToManageLicenses(evt.Requestor); err != nil {
+func (l *SeatLicenseService) GetLicense(ctx context.Context, evt domain.GetLicenseEvent) (*domain.License, error) {
+	if err := l.ensureRequestorIsAuthorizedToViewLicenses(ctx, evt.Requestor, evt.OrgID); err != nil {
 		return nil, err
 	}
 
-	return l.seats.GetLicense(evt.OrgID, evt.ServiceID)
+	return l.seats.GetLicense(ctx, evt.OrgID, evt.ServiceID)
 }
 
 // GetAssignedSeats gets the subjects assigned to the given license
-func (l *SeatLicenseService) GetAssignedSeats(evt domain.GetLicenseEvent) ([]domain.SubjectID, error) {
-	if err := l.ensureRequestorIsAuthorizedToManageLicenses(evt.Requestor); err != nil {
+func (l *SeatLicenseService) GetAssignedSeats(ctx context.Context, evt domain.GetLicenseEvent) ([]domain.SubjectID, error) {
+	if err := l.ensureRequestorIsAuthorizedToViewLicenses(ctx, evt.Requestor, evt.OrgID); err != nil {
+		return nil, err
+	}
+
+	return l.seats.GetAssigned(ctx, evt.OrgID, evt.ServiceID)
+}
+
+// GetAssignableSeats gets the members of the organization that are not assigned a seat on the given license
+func (l *SeatLicenseService) GetAssignableSeats(ctx context.Context, evt domain.GetLicenseEvent) ([]domain.SubjectID, error) {
+	if err := l.ensureRequestorIsAuthorizedToViewLicenses(ctx, evt.Requestor, evt.OrgID); err != nil {
+		return nil, err
+	}
+
+	assigned, err := l.seats.GetAssigned(ctx, evt.OrgID, evt.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := l.principals.GetByOrgID(evt.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignedSet := make(map[domain.SubjectID]struct{}, len(assigned))
+	for _, id := range assigned {
+		assignedSet[id] = struct{}{}
+	}
+
+	//Filter in place, so large orgs do not need a second slice of (almost) the same size
+	assignable := members[:0]
+	for _, id := range members {
+		if _, ok := assignedSet[id]; !ok {
+			assignable = append(assignable, id)
+		}
+	}
+
+	return assignable, nil
+}
+
+// GetOrgSeatSummary gets the seat usage of all licenses of the organization, ordered by service ID. The view operation on the organization covers all of its licenses.
+// If a service repository is set, the services include their display metadata.
+// The licenses are read concurrently. Licenses removed after listing the organization's services are left out.
+func (l *SeatLicenseService) GetOrgSeatSummary(ctx context.Context, evt domain.GetOrgSeatSummaryEvent) (*domain.OrgSeatSummary, error) {
+	if err := l.ensureRequestorIsAuthorizedToViewLicenses(ctx, evt.Requestor, evt.OrgID); err != nil {
+		return nil, err
+	}
+
+	services, err := l.seats.ListServices(ctx, evt.OrgID)
+	if err != nil {
 		return nil, err
 	}
 
-	return l.seats.GetAssigned(evt.OrgID, evt.ServiceID)
+	licenses := make([]*domain.License, len(services))
+	errs := make([]error, len(services))
+	wg := sync.WaitGroup{}
+	for i, svc := range services {
+		wg.Add(1)
+		go func(i int, serviceID string) {
+			defer wg.Done()
+			licenses[i], errs[i] = l.seats.GetLicense(ctx, evt.OrgID, serviceID)
+		}(i, svc.ID)
+	}
+	wg.Wait()
+
+	found := make([]domain.License, 0, len(licenses))
+	for i, lic := range licenses {
+		if errors.Is(errs[i], domain.ErrLicenseNotFound) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		found = append(found, *lic)
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].ServiceID < found[j].ServiceID })
+	summary := domain.NewOrgSeatSummary(evt.OrgID, found)
+
+	if l.services != nil {
+		for i, usage := range summary.Services {
+			if summary.Services[i].Service, err = l.services.GetByID(usage.Service.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// ReconcileSeats compares the recorded seat usage of a license with its seat assignments and finds seats held by subjects that are not members of the organization.
+// With evt.Repair, the seats of non-members are removed and the recorded number of seats in use is corrected. It requires the admin operation, see SetAdminOperation.
+func (l *SeatLicenseService) ReconcileSeats(ctx context.Context, evt domain.ReconcileSeatsEvent) (*domain.SeatReconciliation, error) {
+	if err := l.ensureRequestorIsLicenseAdmin(ctx, evt.Requestor, evt.OrgID); err != nil {
+		return nil, err
+	}
+
+	lic, err := l.seats.GetLicense(ctx, evt.OrgID, evt.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned, err := l.seats.GetAssigned(ctx, evt.OrgID, evt.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	nonMembers, err := l.findNonMembers(evt.OrgID, assigned)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.SeatReconciliation{
+		OrgID:         evt.OrgID,
+		ServiceID:     evt.ServiceID,
+		RecordedInUse: lic.InUse,
+		ActualInUse:   len(assigned),
+		NonMembers:    nonMembers,
+		InUse:         lic.InUse,
+	}
+	if !evt.Repair || !result.HasDiscrepancies() {
+		return result, nil
+	}
+
+	if len(nonMembers) > 0 {
+		auditEvt := domain.ModifySeatAssignmentEvent{Org: domain.Organization{ID: evt.OrgID}, Service: domain.Service{ID: evt.ServiceID}, UnAssign: nonMembers}
+		auditEvt.Requestor = evt.Requestor
+		err := l.seats.UnAssignSeats(ctx, nonMembers, evt.OrgID, domain.Service{ID: evt.ServiceID})
+		l.recordSeatResult(auditEvt, domain.SeatAuditActionUnassign, nonMembers, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, result.InUse, err = l.seats.RecountSeats(ctx, evt.OrgID, evt.ServiceID); err != nil {
+		return nil, err
+	}
+	result.Repaired = true
+
+	return result, nil
 }
 
 // NewSeatLicenseService constructs a new SeatLicenseService
-func NewSeatLicenseService(seats contracts.SeatLicenseRepository, authz contracts.AccessRepository) *SeatLicenseService {
-	return &SeatLicenseService{seats: seats, authz: authz}
+func NewSeatLicenseService(seats contracts.SeatLicenseRepository, authz contracts.AccessRepository, principals contracts.PrincipalRepository) *SeatLicenseService {
+	return &SeatLicenseService{seats: seats, authz: authz, principals: principals}
+}
+
+// SetAuditLog sets the audit log seat assignments and unassignments are recorded in. A nil audit log records nothing.
+func (l *SeatLicenseService) SetAuditLog(audit contracts.AuditLog) {
+	l.audit = audit
+}
+
+// SetServiceRepository sets the repository the display metadata of services is resolved from. A nil repository leaves services with only their ID.
+func (l *SeatLicenseService) SetServiceRepository(services contracts.ServiceRepository) {
+	l.services = services
+}
+
+// SetVerifyMembership sets whether ModifySeats checks that all subjects to assign are members of the organization before writing anything.
+// Without it, seats can be assigned to any subject, which trusted internal callers that already know the members may prefer to save the checks.
+func (l *SeatLicenseService) SetVerifyMembership(verify bool) {
+	l.verifyMembership = verify
+}
+
+// SetManageOperation sets the operation on the organization, ex: "manage_licenses", that requestors must be allowed to assign or unassign seats.
+// Without it, any authenticated requestor may manage the licenses of any organization.
+func (l *SeatLicenseService) SetManageOperation(operation string) {
+	l.manageOperation = operation
 }
 
-func (l *SeatLicenseService) ensureRequestorIsAuthorizedToManageLicenses(requestor domain.SubjectID) error {
+// SetViewOperation sets the operation on the organization, ex: "view_licenses", that requestors must be allowed to read its licenses and who holds seats.
+// Without it, any authenticated requestor may read the licenses of any organization, ex: if authorization is handled in front of this service.
+func (l *SeatLicenseService) SetViewOperation(operation string) {
+	l.viewOperation = operation
+}
+
+// SetAdminOperation sets the operation on the organization, ex: "administer_licenses", that requestors must be allowed to create, delete, resize and reconcile its licenses.
+// Without it, no requestor may: unlike seats, the terms of a license are never left to any authenticated requestor.
+func (l *SeatLicenseService) SetAdminOperation(operation string) {
+	l.adminOperation = operation
+}
+
+// ensureSubjectsAreMembers returns a domain.NotMemberError listing all subjects that are not members of the organization, if any
+func (l *SeatLicenseService) ensureSubjectsAreMembers(orgID string, subjects []domain.SubjectID) error {
+	nonMembers, err := l.findNonMembers(orgID, subjects)
+	if err != nil {
+		return err
+	}
+
+	if len(nonMembers) > 0 {
+		return &domain.NotMemberError{OrgID: orgID, SubjectIDs: nonMembers}
+	}
+	return nil
+}
+
+// findNonMembers checks the membership of the subjects concurrently and returns the subjects that are not members of the organization
+func (l *SeatLicenseService) findNonMembers(orgID string, subjects []domain.SubjectID) ([]domain.SubjectID, error) {
+	isMember := make([]bool, len(subjects))
+	errs := make([]error, len(subjects))
+
+	limit := make(chan struct{}, maxConcurrentMembershipChecks)
+	wg := sync.WaitGroup{}
+	for i, subject := range subjects {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int, subject domain.SubjectID) {
+			defer func() { <-limit; wg.Done() }()
+			isMember[i], errs[i] = l.principals.IsMember(orgID, subject)
+		}(i, subject)
+	}
+	wg.Wait()
+
+	var nonMembers []domain.SubjectID
+	for i, subject := range subjects {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if !isMember[i] {
+			nonMembers = append(nonMembers, subject)
+		}
+	}
+
+	return nonMembers, nil
+}
+
+// findDisabled returns the subjects that are disabled or unknown to the principal repository
+func (l *SeatLicenseService) findDisabled(subjects []domain.SubjectID) ([]domain.SubjectID, error) {
+	disabled := make([]domain.SubjectID, 0)
+	for _, subject := range subjects {
+		principal, err := l.principals.GetByID(subject)
+		switch {
+		case errors.Is(err, domain.ErrPrincipalNotFound):
+			disabled = append(disabled, subject)
+		case err != nil:
+			return nil, err
+		case principal.Disabled:
+			disabled = append(disabled, subject)
+		}
+	}
+	return disabled, nil
+}
+
+// withoutSubjects returns the subjects that are not in excluded
+func withoutSubjects(subjects []domain.SubjectID, excluded []domain.SubjectID) []domain.SubjectID {
+	skip := make(map[domain.SubjectID]bool, len(excluded))
+	for _, subject := range excluded {
+		skip[subject] = true
+	}
+
+	result := make([]domain.SubjectID, 0, len(subjects))
+	for _, subject := range subjects {
+		if !skip[subject] {
+			result = append(result, subject)
+		}
+	}
+	return result
+}
+
+// recordSeatResult records the outcome of a seat action in the audit log, if any: a success without an error, denied if the requestor was not allowed to act, otherwise a failure
+func (l *SeatLicenseService) recordSeatResult(evt domain.ModifySeatAssignmentEvent, action domain.SeatAuditAction, subjects []domain.SubjectID, err error) {
+	result := domain.SeatAuditResultSuccess
+	switch {
+	case errors.Is(err, domain.ErrNotAuthenticated) || errors.Is(err, domain.ErrNotAuthorized):
+		result = domain.SeatAuditResultDenied
+	case err != nil:
+		result = domain.SeatAuditResultFailure
+	}
+
+	l.recordSeatEvent(evt, action, subjects, result, err)
+}
+
+func (l *SeatLicenseService) recordSeatEvent(evt domain.ModifySeatAssignmentEvent, action domain.SeatAuditAction, subjects []domain.SubjectID, result domain.SeatAuditResult, err error) {
+	if l.audit == nil || len(subjects) == 0 {
+		return
+	}
+
+	auditEvt := domain.SeatAuditEvent{
+		Time:      time.Now().UTC(),
+		Requestor: evt.Requestor,
+		Action:    action,
+		OrgID:     evt.Org.ID,
+		ServiceID: evt.Service.ID,
+		Subjects:  subjects,
+		Result:    result,
+	}
+	if err != nil {
+		auditEvt.Error = err.Error()
+	}
+
+	l.audit.RecordSeatEvent(auditEvt)
+}
+
+// ensureRequestorIsLicenseAdmin checks that the requestor may change the terms of the organization's licenses, ex: their seat limits. Without an admin operation, no requestor may.
+func (l *SeatLicenseService) ensureRequestorIsLicenseAdmin(ctx context.Context, requestor domain.SubjectID, orgID string) error {
 	if !requestor.HasIdentity() {
 		return domain.ErrNotAuthenticated
 	}
 
-	authz, err := true, error(nil) //l.authz.CheckAccess(requestor, "manage_license", org.AsResource()) //Maybe on a per-service basis? //TODO: implement meta-authz
+	if l.adminOperation == "" {
+		return domain.ErrNotAuthorized
+	}
+
+	return l.ensureRequestorIsAuthorizedOnOrg(ctx, requestor, l.adminOperation, orgID)
+}
+
+// ensureRequestorIsAuthorizedToManageLicenses checks that the requestor may perform the manage operation on the organization, if one is set
+func (l *SeatLicenseService) ensureRequestorIsAuthorizedToManageLicenses(ctx context.Context, requestor domain.SubjectID, orgID string) error {
+	return l.ensureRequestorIsAuthorizedOnOrg(ctx, requestor, l.manageOperation, orgID)
+}
+
+// ensureRequestorIsAuthorizedToViewLicenses checks that the requestor may perform the view operation on the organization, if one is set
+func (l *SeatLicenseService) ensureRequestorIsAuthorizedToViewLicenses(ctx context.Context, requestor domain.SubjectID, orgID string) error {
+	return l.ensureRequestorIsAuthorizedOnOrg(ctx, requestor, l.viewOperation, orgID)
+}
+
+func (l *SeatLicenseService) ensureRequestorIsAuthorizedOnOrg(ctx context.Context, requestor domain.SubjectID, operation string, orgID string) error {
+	if !requestor.HasIdentity() {
+		return domain.ErrNotAuthenticated
+	}
+
+	if operation == "" {
+		return nil //TODO: implement meta-authz in the schema and require it
+	}
+
+	decision, err := l.authz.CheckAccess(ctx, requestor, operation, domain.Organization{ID: orgID}.AsResource()) //Maybe on a per-service basis?
 	if err != nil {
 		return err
 	}
 
-	if !authz {
+	if !decision.IsAllowed() {
 		return domain.ErrNotAuthorized
 	}
 
@@ -3,6 +3,11 @@ package services
 import (
 	"authz/domain"
 	"authz/domain/contracts"
+	"authz/infrastructure/repository/mock"
+	"authz/infrastructure/repository/static"
+	"context"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,28 +20,43 @@ func TestLicensingModifySeatsErrorsWhenNotAuthenticated(t *testing.T) {
 		[]string{})
 
 	store := mockAuthzRepository()
-	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store)
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
 
-	err := lic.ModifySeats(req)
+	err := lic.ModifySeats(context.Background(), req)
 
 	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
 }
 
 func TestLicensingModifySeatsErrorsWhenNotAuthorized(t *testing.T) {
-	t.SkipNow()
 	req := modifyLicRequestFromVars("bad",
 		"aspian",
 		[]string{"okay"},
 		[]string{})
 
 	store := mockAuthzRepository()
-	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store)
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
+	lic.SetManageOperation("manage_licenses")
 
-	err := lic.ModifySeats(req)
+	err := lic.ModifySeats(context.Background(), req)
 
 	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
 }
 
+func TestLicensingModifySeatsSucceedsWhenAuthorized(t *testing.T) {
+	req := modifyLicRequestFromVars("okay",
+		"aspian",
+		[]string{"okay"},
+		[]string{})
+
+	store := mockAuthzRepository()
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
+	lic.SetManageOperation("manage_licenses")
+
+	err := lic.ModifySeats(context.Background(), req)
+
+	assert.NoError(t, err)
+}
+
 func TestLicensingAssignUnassignRoundTrip(t *testing.T) {
 	addReq := modifyLicRequestFromVars("okay",
 		"aspian",
@@ -44,30 +64,465 @@ func TestLicensingAssignUnassignRoundTrip(t *testing.T) {
 		[]string{})
 
 	store := mockAuthzRepository()
-	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store)
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
 
-	authz, err := store.CheckAccess(addReq.Assign[0], "use", addReq.Service.AsResource())
+	authz, err := store.CheckAccess(context.Background(), addReq.Assign[0], "use", addReq.Service.AsResource())
 	assert.NoError(t, err)
-	assert.False(t, bool(authz), "Should not have been authorized without license.")
+	assert.False(t, authz.IsAllowed(), "Should not have been authorized without license.")
 
-	err = lic.ModifySeats(addReq)
+	err = lic.ModifySeats(context.Background(), addReq)
 	assert.NoError(t, err)
 
-	authz, err = store.CheckAccess(addReq.Assign[0], "use", addReq.Service.AsResource())
+	authz, err = store.CheckAccess(context.Background(), addReq.Assign[0], "use", addReq.Service.AsResource())
 	assert.NoError(t, err)
-	assert.True(t, bool(authz), "Should have been authorized with license.")
+	assert.True(t, authz.IsAllowed(), "Should have been authorized with license.")
 
 	remReq := modifyLicRequestFromVars("okay",
 		"aspian",
 		[]string{},
 		[]string{"okay"})
 
-	err = lic.ModifySeats(remReq)
+	err = lic.ModifySeats(context.Background(), remReq)
+	assert.NoError(t, err)
+
+	authz, err = store.CheckAccess(context.Background(), remReq.UnAssign[0], "use", remReq.Service.AsResource())
+	assert.NoError(t, err)
+	assert.False(t, authz.IsAllowed(), "Should not have been authorized without license.")
+}
+
+func TestLicensingGetAssignableSeatsExcludesAssigned(t *testing.T) {
+	addReq := modifyLicRequestFromVars("okay",
+		"aspian",
+		[]string{"okay"},
+		[]string{})
+
+	store := mockAuthzRepository()
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
+
+	err := lic.ModifySeats(context.Background(), addReq)
+	assert.NoError(t, err)
+
+	assignable, err := lic.GetAssignableSeats(context.Background(), domain.GetLicenseEvent{Requestor: "okay", OrgID: "aspian", ServiceID: "smarts"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.SubjectID{"bad"}, assignable)
+}
+
+func TestLicensingGetOrgSeatSummaryAddsUpTheOrgsLicenses(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, store.SeedLicense("o1", "other", 5))
+	assert.NoError(t, store.SeedLicense("o2", "smarts", 100))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"u1", "u2"}, "o1", domain.Service{ID: "smarts"}))
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+
+	summary, err := lic.GetOrgSeatSummary(context.Background(), domain.GetOrgSeatSummaryEvent{Requestor: "okay", OrgID: "o1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ServiceSeatUsage{
+		{Service: domain.Service{ID: "other"}, MaxSeats: 5, InUse: 0, Available: 5},
+		{Service: domain.Service{ID: "smarts"}, MaxSeats: 10, InUse: 2, Available: 8},
+	}, summary.Services)
+	assert.Equal(t, 15, summary.MaxSeats)
+	assert.Equal(t, 2, summary.InUse)
+	assert.Equal(t, 13, summary.Available)
+}
+
+func TestLicensingGetOrgSeatSummaryIncludesServiceMetadata(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, store.SeedLicense("o1", "other", 5))
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	lic.SetServiceRepository(static.NewStaticServiceRepository([]domain.Service{{ID: "smarts", DisplayName: "Smarts", Description: "Smart management"}}))
+
+	summary, err := lic.GetOrgSeatSummary(context.Background(), domain.GetOrgSeatSummaryEvent{Requestor: "okay", OrgID: "o1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Service{ID: "other"}, summary.Services[0].Service)
+	assert.Equal(t, domain.Service{ID: "smarts", DisplayName: "Smarts", Description: "Smart management"}, summary.Services[1].Service)
+}
+
+func TestLicensingGetOrgSeatSummaryErrorsWhenNotAuthenticated(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+
+	_, err := lic.GetOrgSeatSummary(context.Background(), domain.GetOrgSeatSummaryEvent{Requestor: "", OrgID: "o1"})
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
+}
+
+func TestLicensingModifySeatsRejectsNonMembersWhenVerifyingMembership(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 10))
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	lic.SetVerifyMembership(true)
+
+	err := lic.ModifySeats(context.Background(), modifyLicRequestFromVars("okay", "aspian", []string{"okay", "system", "unknown"}, []string{}))
+
+	assert.ErrorIs(t, err, domain.ErrSubjectNotMember)
+	var notMember *domain.NotMemberError
+	if assert.ErrorAs(t, err, &notMember) {
+		assert.Equal(t, "aspian", notMember.OrgID)
+		assert.Equal(t, []domain.SubjectID{"system", "unknown"}, notMember.SubjectIDs)
+	}
+	assigned, err := store.GetAssigned(context.Background(), "aspian", "smarts")
+	assert.NoError(t, err)
+	assert.Empty(t, assigned, "No seat should have been assigned.")
+}
+
+func TestLicensingModifySeatsAssignsNonMembersWithoutVerifyingMembership(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 10))
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+
+	err := lic.ModifySeats(context.Background(), modifyLicRequestFromVars("okay", "aspian", []string{"okay", "system"}, []string{}))
+
+	assert.NoError(t, err)
+}
+
+func TestLicensingReconcileSeatsReportsNonMembers(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 10))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"okay", "ghost"}, "aspian", domain.Service{ID: "smarts"}))
+	store.GrantOrgOperation("aspian", "administer_licenses", "system")
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	lic.SetAdminOperation("administer_licenses")
+
+	result, err := lic.ReconcileSeats(context.Background(), domain.ReconcileSeatsEvent{Requestor: "system", OrgID: "aspian", ServiceID: "smarts"})
+
+	assert.NoError(t, err)
+	assert.True(t, result.HasDiscrepancies())
+	assert.Equal(t, []domain.SubjectID{"ghost"}, result.NonMembers)
+	assert.False(t, result.Repaired)
+	assert.Equal(t, 2, result.InUse)
+	assigned, err := store.GetAssigned(context.Background(), "aspian", "smarts")
+	assert.NoError(t, err)
+	assert.Len(t, assigned, 2, "Reporting should not have modified the seats.")
+}
+
+func TestLicensingReconcileSeatsRepairsNonMemberSeats(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 10))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"okay", "ghost"}, "aspian", domain.Service{ID: "smarts"}))
+	store.GrantOrgOperation("aspian", "administer_licenses", "system")
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	lic.SetAdminOperation("administer_licenses")
+	audit := &recordingAuditLog{}
+	lic.SetAuditLog(audit)
+
+	result, err := lic.ReconcileSeats(context.Background(), domain.ReconcileSeatsEvent{Requestor: "system", OrgID: "aspian", ServiceID: "smarts", Repair: true})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Repaired)
+	assert.Equal(t, 1, result.InUse)
+	assigned, err := store.GetAssigned(context.Background(), "aspian", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.SubjectID{"okay"}, assigned)
+	if assert.Len(t, audit.events, 1) {
+		assert.Equal(t, []domain.SubjectID{"ghost"}, audit.events[0].Subjects)
+	}
+}
+
+func TestLicensingReconcileSeatsErrorsWhenNotAuthorized(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 10))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"okay", "ghost"}, "aspian", domain.Service{ID: "smarts"}))
+	store.GrantOrgOperation("aspian", "manage_licenses", "bad")
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	lic.SetManageOperation("manage_licenses")
+	lic.SetAdminOperation("administer_licenses")
+
+	_, err := lic.ReconcileSeats(context.Background(), domain.ReconcileSeatsEvent{Requestor: "bad", OrgID: "aspian", ServiceID: "smarts", Repair: true})
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized, "Managing seats should not allow repairing them.")
+	assigned, err := store.GetAssigned(context.Background(), "aspian", "smarts")
+	assert.NoError(t, err)
+	assert.Len(t, assigned, 2, "No seats should have been removed.")
+}
+
+func TestLicensingBulkAssignSeatsStopsAtTheSeatLimit(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 150))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"u0"}, "aspian", domain.Service{ID: "smarts"}))
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+
+	subjects := []domain.SubjectID{"u0", "u1", "u1"}
+	for i := 2; i <= 200; i++ {
+		subjects = append(subjects, domain.SubjectID(fmt.Sprintf("u%d", i)))
+	}
+
+	summary, err := lic.BulkAssignSeats(context.Background(), domain.BulkAssignSeatsEvent{
+		Request:  domain.Request{Requestor: "okay"},
+		Org:      domain.Organization{ID: "aspian"},
+		Service:  domain.Service{ID: "smarts"},
+		Subjects: subjects,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.BulkAssignSummary{Assigned: 149, SkippedAlreadyAssigned: 2, SkippedLimit: 51, LimitReached: true}, *summary)
+	assigned, err := store.GetAssigned(context.Background(), "aspian", "smarts")
+	assert.NoError(t, err)
+	assert.Len(t, assigned, 150)
+}
+
+func TestLicensingBulkAssignSeatsCountsNonMembersAsFailed(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 10))
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	lic.SetVerifyMembership(true)
+
+	summary, err := lic.BulkAssignSeats(context.Background(), domain.BulkAssignSeatsEvent{
+		Request:  domain.Request{Requestor: "okay"},
+		Org:      domain.Organization{ID: "aspian"},
+		Service:  domain.Service{ID: "smarts"},
+		Subjects: []domain.SubjectID{"okay", "system", "bad"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.BulkAssignSummary{Assigned: 2, Failed: 1}, *summary)
+}
+
+func TestLicensingUnassignAllForSubjectRemovesSeatsInAllServices(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 10))
+	assert.NoError(t, store.SeedLicense("aspian", "other", 10))
+	assert.NoError(t, store.SeedLicense("aspian", "unused", 10))
+	assert.NoError(t, store.SeedLicense("o2", "smarts", 10))
+	for _, lic := range []struct{ org, service string }{{"aspian", "smarts"}, {"aspian", "other"}, {"o2", "smarts"}} {
+		assert.NoError(t, store.AssignSeat(context.Background(), "okay", lic.org, domain.Service{ID: lic.service}))
+	}
+	store.GrantOrgOperation("aspian", "administer_licenses", "system")
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	lic.SetAdminOperation("administer_licenses")
+	evt := domain.UnassignAllForSubjectEvent{Request: domain.Request{Requestor: "system"}, OrgID: "aspian", Subject: "okay"}
+
+	unassigned, err := lic.UnassignAllForSubject(context.Background(), evt)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.Service{{ID: "smarts"}, {ID: "other"}}, unassigned)
+	remaining, err := store.ListAssignedServices(context.Background(), "o2", "okay")
 	assert.NoError(t, err)
+	assert.Len(t, remaining, 1, "The seat in another org should have been kept.")
+
+	unassigned, err = lic.UnassignAllForSubject(context.Background(), evt)
+	assert.NoError(t, err, "Unassigning again should not be an error.")
+	assert.Empty(t, unassigned)
+}
+
+func TestLicensingUnassignAllForSubjectRequiresAdminOperation(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 10))
+	assert.NoError(t, store.AssignSeat(context.Background(), "okay", "aspian", domain.Service{ID: "smarts"}))
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	evt := domain.UnassignAllForSubjectEvent{Request: domain.Request{Requestor: "system"}, OrgID: "aspian", Subject: "okay"}
+
+	_, err := lic.UnassignAllForSubject(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+	remaining, err := store.ListAssignedServices(context.Background(), "aspian", "okay")
+	assert.NoError(t, err)
+	assert.Len(t, remaining, 1)
+}
 
-	authz, err = store.CheckAccess(remReq.UnAssign[0], "use", remReq.Service.AsResource())
+func TestLicensingReclaimDisabledSeatsRemovesSeatsOfDisabledAndDeletedSubjects(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 10))
+	assert.NoError(t, store.AssignSeats(context.Background(), []domain.SubjectID{"okay", "former", "deleted"}, "aspian", domain.Service{ID: "smarts"}))
+	principals := &mock.StubPrincipalRepository{
+		Principals: map[domain.SubjectID]domain.Principal{
+			"system": domain.NewPrincipal("system", "System User", "smarts"),
+			"okay":   domain.NewPrincipal("okay", "Okay User", "aspian"),
+			"former": {ID: "former", DisplayName: "Former User", OrgID: "aspian", Disabled: true},
+		},
+		RejectUnknown: true,
+	}
+	lic := NewSeatLicenseService(store, store, principals)
+	audit := &recordingAuditLog{}
+	lic.SetAuditLog(audit)
+	evt := domain.ReclaimDisabledSeatsEvent{Request: domain.Request{Requestor: "system"}, Org: domain.Organization{ID: "aspian"}, Service: domain.Service{ID: "smarts"}}
+
+	reclaimed, err := lic.ReclaimDisabledSeats(context.Background(), evt)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.SubjectID{"former", "deleted"}, reclaimed)
+	assigned, err := store.GetAssigned(context.Background(), "aspian", "smarts")
 	assert.NoError(t, err)
-	assert.False(t, bool(authz), "Should not have been authorized without license.")
+	assert.Equal(t, []domain.SubjectID{"okay"}, assigned)
+	if assert.Len(t, audit.events, 1) {
+		assert.Equal(t, domain.SeatAuditActionUnassign, audit.events[0].Action)
+		assert.Equal(t, domain.SeatAuditResultSuccess, audit.events[0].Result)
+	}
+
+	reclaimed, err = lic.ReclaimDisabledSeats(context.Background(), evt)
+	assert.NoError(t, err, "Reclaiming again should not be an error.")
+	assert.Empty(t, reclaimed)
+}
+
+func TestLicensingReclaimDisabledSeatsErrorsWhenNotAuthorized(t *testing.T) {
+	store := mockAuthzRepository()
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
+	lic.SetManageOperation("manage_licenses")
+	evt := domain.ReclaimDisabledSeatsEvent{Request: domain.Request{Requestor: "bad"}, Org: domain.Organization{ID: "aspian"}, Service: domain.Service{ID: "smarts"}}
+
+	_, err := lic.ReclaimDisabledSeats(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+}
+
+func TestLicensingSetMaxSeatsErrorsWhenNotAuthorized(t *testing.T) {
+	store := mockAuthzRepository()
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
+	lic.SetAdminOperation("administer_licenses")
+	evt := domain.SetMaxSeatsEvent{Request: domain.Request{Requestor: "bad"}, Org: domain.Organization{ID: "aspian"}, Service: domain.Service{ID: "smarts"}, MaxSeats: 100}
+
+	err := lic.SetMaxSeats(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+}
+
+func TestLicensingSetMaxSeatsErrorsWithoutAdminOperation(t *testing.T) {
+	store := mockAuthzRepository()
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
+	evt := domain.SetMaxSeatsEvent{Request: domain.Request{Requestor: "okay"}, Org: domain.Organization{ID: "aspian"}, Service: domain.Service{ID: "smarts"}, MaxSeats: 100}
+
+	err := lic.SetMaxSeats(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized, "Licenses should not be administered by any authenticated requestor.")
+}
+
+func TestLicensingCreateLicenseErrorsWhenNotAuthorized(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	store.GrantOrgOperation("aspian", "administer_licenses", "okay")
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	lic.SetAdminOperation("administer_licenses")
+	evt := domain.CreateLicenseEvent{Request: domain.Request{Requestor: "bad"}, Org: domain.Organization{ID: "aspian"}, Service: domain.Service{ID: "smarts"}, MaxSeats: 10}
+
+	err := lic.CreateLicense(context.Background(), evt)
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthorized)
+	_, err = store.GetLicense(context.Background(), "aspian", "smarts")
+	assert.ErrorIs(t, err, domain.ErrLicenseNotFound)
+}
+
+func TestLicensingCheckOrAssignSeatAssignsWhileSeatsAreAvailable(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 1))
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+	evt := func(subject domain.SubjectID) domain.CheckOrAssignSeatEvent {
+		return domain.CheckOrAssignSeatEvent{Request: domain.Request{Requestor: "system"}, Org: domain.Organization{ID: "aspian"}, Service: domain.Service{ID: "smarts"}, Subject: subject}
+	}
+
+	decision, err := lic.CheckOrAssignSeat(context.Background(), evt("okay"))
+	assert.NoError(t, err)
+	assert.True(t, decision.IsAllowed())
+	assert.Equal(t, domain.SeatGrantAssigned, decision.Outcome)
+
+	decision, err = lic.CheckOrAssignSeat(context.Background(), evt("okay"))
+	assert.NoError(t, err)
+	assert.True(t, decision.IsAllowed())
+	assert.Equal(t, domain.SeatGrantAlreadyAssigned, decision.Outcome)
+
+	decision, err = lic.CheckOrAssignSeat(context.Background(), evt("bad"))
+	assert.NoError(t, err)
+	assert.False(t, decision.IsAllowed())
+	assert.Equal(t, domain.SeatGrantNoSeatAvailable, decision.Outcome)
+}
+
+func TestLicensingCheckOrAssignSeatDoesNotOversellConcurrently(t *testing.T) {
+	store := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, store.SeedLicense("aspian", "smarts", 3))
+	lic := NewSeatLicenseService(store, store, mockPrincipalRepository())
+
+	decisions := make(chan *domain.SeatGrantDecision, 10)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(subject domain.SubjectID) {
+			defer wg.Done()
+			decision, err := lic.CheckOrAssignSeat(context.Background(), domain.CheckOrAssignSeatEvent{Request: domain.Request{Requestor: "system"}, Org: domain.Organization{ID: "aspian"}, Service: domain.Service{ID: "smarts"}, Subject: subject})
+			assert.NoError(t, err)
+			decisions <- decision
+		}(domain.SubjectID(fmt.Sprintf("u%d", i)))
+	}
+	wg.Wait()
+	close(decisions)
+
+	allowed := 0
+	for decision := range decisions {
+		if decision != nil && decision.IsAllowed() {
+			allowed++
+		}
+	}
+	assert.Equal(t, 3, allowed)
+	license, err := store.GetLicense(context.Background(), "aspian", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, license.InUse)
+}
+
+func TestLicensingModifySeatsRecordsAuditEvents(t *testing.T) {
+	req := modifyLicRequestFromVars("okay",
+		"aspian",
+		[]string{"okay"},
+		[]string{"bad"})
+
+	store := mockAuthzRepository()
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
+	audit := &recordingAuditLog{}
+	lic.SetAuditLog(audit)
+
+	err := lic.ModifySeats(context.Background(), req)
+	assert.NoError(t, err)
+
+	if assert.Len(t, audit.events, 2) {
+		assert.Equal(t, domain.SeatAuditActionUnassign, audit.events[0].Action)
+		assert.Equal(t, []domain.SubjectID{"bad"}, audit.events[0].Subjects)
+		assert.Equal(t, domain.SeatAuditActionAssign, audit.events[1].Action)
+		assert.Equal(t, []domain.SubjectID{"okay"}, audit.events[1].Subjects)
+		for _, evt := range audit.events {
+			assert.Equal(t, domain.SubjectID("okay"), evt.Requestor)
+			assert.Equal(t, "aspian", evt.OrgID)
+			assert.Equal(t, "smarts", evt.ServiceID)
+			assert.Equal(t, domain.SeatAuditResultSuccess, evt.Result)
+		}
+	}
+}
+
+func TestLicensingModifySeatsRecordsDeniedAuditEvent(t *testing.T) {
+	req := modifyLicRequestFromVars("",
+		"aspian",
+		[]string{"okay"},
+		[]string{})
+
+	store := mockAuthzRepository()
+	lic := NewSeatLicenseService(store.(contracts.SeatLicenseRepository), store, mockPrincipalRepository())
+	audit := &recordingAuditLog{}
+	lic.SetAuditLog(audit)
+
+	_ = lic.ModifySeats(context.Background(), req)
+
+	if assert.Len(t, audit.events, 1) {
+		assert.Equal(t, domain.SeatAuditActionAssign, audit.events[0].Action)
+		assert.Equal(t, domain.SeatAuditResultDenied, audit.events[0].Result)
+		assert.NotEmpty(t, audit.events[0].Error)
+	}
+}
+
+type recordingAuditLog struct {
+	events []domain.SeatAuditEvent
+}
+
+func (r *recordingAuditLog) RecordSeatEvent(evt domain.SeatAuditEvent) {
+	r.events = append(r.events, evt)
+}
+
+func mockPrincipalRepository() contracts.PrincipalRepository {
+	return &mock.StubPrincipalRepository{
+		Principals: map[domain.SubjectID]domain.Principal{
+			"system": domain.NewPrincipal("system", "System User", "smarts"),
+			"okay":   domain.NewPrincipal("okay", "Okay User", "aspian"),
+			"bad":    domain.NewPrincipal("bad", "Bad User", "aspian"),
+		},
+		DefaultOrg: "aspian",
+	}
 }
 
 func modifyLicRequestFromVars(requestorID string, subjectOrg string, assign []string, unassign []string) domain.ModifySeatAssignmentEvent {
@@ -0,0 +1,84 @@
+// Package audit implements audit logs
+package audit
+
+import (
+	"authz/domain"
+	"encoding/json"
+
+	"github.com/golang/glog"
+)
+
+// GlogAuditLog writes audit events and access decisions as JSON lines to glog, prefixed with "AUDIT" to be filtered into a dedicated audit log
+type GlogAuditLog struct{}
+
+type seatAuditRecord struct {
+	Time      string   `json:"time"`
+	Requestor string   `json:"requestor"`
+	Action    string   `json:"action"`
+	OrgID     string   `json:"org_id"`
+	ServiceID string   `json:"service_id"`
+	Subjects  []string `json:"subjects"`
+	Result    string   `json:"result"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// RecordSeatEvent writes the given seat assignment or unassignment event
+func (l *GlogAuditLog) RecordSeatEvent(evt domain.SeatAuditEvent) {
+	record := seatAuditRecord{
+		Time:      evt.Time.Format("2006-01-02T15:04:05.000Z07:00"),
+		Requestor: string(evt.Requestor),
+		Action:    string(evt.Action),
+		OrgID:     evt.OrgID,
+		ServiceID: evt.ServiceID,
+		Subjects:  make([]string, len(evt.Subjects)),
+		Result:    string(evt.Result),
+		Error:     evt.Error,
+	}
+	for i, s := range evt.Subjects {
+		record.Subjects[i] = string(s)
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		glog.Errorf("Failed to marshal audit event: %s", err)
+		return
+	}
+
+	glog.Infof("AUDIT %s", line)
+}
+
+type accessAuditRecord struct {
+	Time         string `json:"time"`
+	Requestor    string `json:"requestor"`
+	Subject      string `json:"subject"`
+	Operation    string `json:"operation"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	Result       string `json:"result"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// RecordAccessDecision writes the given authorization decision
+func (l *GlogAuditLog) RecordAccessDecision(evt domain.AccessAuditEvent) {
+	record := accessAuditRecord{
+		Time:         evt.Time.Format("2006-01-02T15:04:05.000Z07:00"),
+		Requestor:    string(evt.Requestor),
+		Subject:      string(evt.Subject),
+		Operation:    evt.Operation,
+		ResourceType: evt.Resource.Type,
+		ResourceID:   evt.Resource.ID,
+		Result:       "deny",
+		Reason:       evt.Decision.Reason,
+	}
+	if evt.Decision.IsAllowed() {
+		record.Result = "allow"
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		glog.Errorf("Failed to marshal audit event: %s", err)
+		return
+	}
+
+	glog.Infof("AUDIT %s", line)
+}
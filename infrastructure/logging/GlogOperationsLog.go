@@ -0,0 +1,43 @@
+// Package logging implements logs for operations
+package logging
+
+import (
+	"authz/domain"
+	"encoding/json"
+
+	"github.com/golang/glog"
+)
+
+// GlogOperationsLog writes operations events as JSON warnings to glog, prefixed with the kind of event to be filtered and alerted on
+type GlogOperationsLog struct{}
+
+type seatUtilizationRecord struct {
+	Time        string  `json:"time"`
+	OrgID       string  `json:"org_id"`
+	ServiceID   string  `json:"service_id"`
+	InUse       int     `json:"in_use"`
+	MaxSeats    int     `json:"max_seats"`
+	Utilization float64 `json:"utilization"`
+	Threshold   float64 `json:"threshold"`
+}
+
+// WarnSeatUtilization writes the given seat utilization warning
+func (l *GlogOperationsLog) WarnSeatUtilization(evt domain.SeatUtilizationWarning) {
+	record := seatUtilizationRecord{
+		Time:        evt.Time.Format("2006-01-02T15:04:05.000Z07:00"),
+		OrgID:       evt.OrgID,
+		ServiceID:   evt.ServiceID,
+		InUse:       evt.InUse,
+		MaxSeats:    evt.MaxSeats,
+		Utilization: evt.Utilization,
+		Threshold:   evt.Threshold,
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		glog.Errorf("Failed to marshal seat utilization warning: %s", err)
+		return
+	}
+
+	glog.Warningf("SEAT_UTILIZATION %s", line)
+}
@@ -0,0 +1,41 @@
+package authzed
+
+import (
+	"authz/domain"
+	"sync"
+)
+
+// DefaultMaxConcurrentChecks is the default number of checks of one bulk check that are in flight at the same time
+const DefaultMaxConcurrentChecks = 16
+
+// checkConcurrently runs the check of each event with at most limit checks in flight and returns the decisions and errors in the order of the events.
+// A limit of 0 or less runs all checks at once.
+func checkConcurrently(events []domain.CheckEvent, limit int, check func(domain.CheckEvent) (domain.AccessDecision, error)) ([]domain.AccessDecision, []error) {
+	results := make([]domain.AccessDecision, len(events))
+	errs := make([]error, len(events))
+
+	workers := limit
+	if workers <= 0 || workers > len(events) {
+		workers = len(events)
+	}
+
+	indexes := make(chan int)
+	wait := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for i := range indexes {
+				results[i], errs[i] = check(events[i])
+			}
+		}()
+	}
+
+	for i := range events {
+		indexes <- i
+	}
+	close(indexes)
+	wait.Wait()
+
+	return results, errs
+}
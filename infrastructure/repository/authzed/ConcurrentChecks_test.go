@@ -0,0 +1,55 @@
+package authzed
+
+import (
+	"authz/domain"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckConcurrentlyLimitsChecksInFlight(t *testing.T) {
+	events := make([]domain.CheckEvent, 500)
+	for i := range events {
+		events[i] = domain.CheckEvent{SubjectID: domain.SubjectID(fmt.Sprintf("u%d", i))}
+	}
+
+	var inFlight, maxInFlight int32
+	results, errs := checkConcurrently(events, 8, func(evt domain.CheckEvent) (domain.AccessDecision, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		return domain.Deny(string(evt.SubjectID)), nil
+	})
+
+	assert.LessOrEqual(t, maxInFlight, int32(8))
+	assert.Greater(t, maxInFlight, int32(1), "Checks should have run concurrently.")
+	for i := range events {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, fmt.Sprintf("u%d", i), results[i].Reason, "Results should be in the order of the events.")
+	}
+}
+
+func TestCheckConcurrentlyReturnsErrorsInOrder(t *testing.T) {
+	events := []domain.CheckEvent{{SubjectID: "ok"}, {SubjectID: "fail"}, {SubjectID: "ok"}}
+
+	_, errs := checkConcurrently(events, 0, func(evt domain.CheckEvent) (domain.AccessDecision, error) {
+		if evt.SubjectID == "fail" {
+			return domain.AccessDecision{}, fmt.Errorf("check failed")
+		}
+		return domain.Allow(), nil
+	})
+
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.NoError(t, errs[2])
+}
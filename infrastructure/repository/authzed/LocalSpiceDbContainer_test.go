@@ -0,0 +1,191 @@
+package authzed
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/ory/dockertest/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// LocalSpiceDbContainer is a SpiceDB test server running in docker. It is started without any data, tests set up their own relationships using fixtures.
+type LocalSpiceDbContainer struct {
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+	port     string
+	schema   string
+}
+
+// Relationship is a relationship to set up for a test, ex: {"license", "o1/smarts", "max", "max", "10"} for license:o1/smarts#max@max:10
+type Relationship struct {
+	ResourceType string
+	ResourceID   string
+	Relation     string
+	SubjectType  string
+	SubjectID    string
+}
+
+// CreateSpiceDbContainer starts a new SpiceDB test server and reads the schema to apply for each client from schema/spicedb_bootstrap.yaml
+func CreateSpiceDbContainer() (*LocalSpiceDbContainer, error) {
+	var (
+		_, b, _, _ = runtime.Caller(0)
+		basepath   = filepath.Dir(b)
+	)
+
+	schema, err := readSchema(path.Join(basepath, "../../../schema/spicedb_bootstrap.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := dockertest.NewPool("") // Empty string uses default docker env
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository:   "authzed/spicedb",
+		Tag:          "v1.17.0", // Replace this with an actual version
+		Cmd:          []string{"serve-testing"},
+		ExposedPorts: []string{"50051/tcp", "50052/tcp"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalSpiceDbContainer{
+		pool:     pool,
+		resource: resource,
+		port:     resource.GetPort("50051/tcp"),
+		schema:   schema,
+	}, nil
+}
+
+// Close stops and removes the container
+func (l *LocalSpiceDbContainer) Close() {
+	_ = l.pool.Purge(l.resource)
+}
+
+// NewClient creates a new SpiceDB client with random credentials, applies the schema and loads the given relationships. They are removed again when the test ends.
+//
+// The test server gives each set of a credentials its own isolated datastore
+// so that tests can be ran in parallel.
+func (l *LocalSpiceDbContainer) NewClient(t *testing.T, relationships []Relationship) *SpiceDbAccessRepository {
+	client := l.NewClientWithoutSchema(t)
+
+	if err := client.ApplySchema(l.schema); err != nil {
+		t.Fatalf("Failed to apply schema: %s", err)
+	}
+
+	if err := l.LoadFixture(client, relationships); err != nil {
+		t.Fatalf("Failed to load fixture: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if err := l.Reset(client); err != nil {
+			t.Errorf("Failed to reset fixture: %s", err)
+		}
+	})
+
+	return client
+}
+
+// NewClientWithoutSchema creates a new SpiceDB client with random credentials for an empty, isolated datastore without any schema. It skips the test if no container could be started.
+func (l *LocalSpiceDbContainer) NewClientWithoutSchema(t *testing.T) *SpiceDbAccessRepository {
+	if l == nil {
+		t.Skip("SpiceDB test container not available")
+	}
+
+	// Generate a random credential to isolate this client from any others.
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatalf("Failed to generate credentials: %s", err)
+	}
+	randomKey := base64.StdEncoding.EncodeToString(buf)
+
+	client := &SpiceDbAccessRepository{}
+	client.NewConnection("localhost:"+l.port, randomKey, true, false)
+
+	return client
+}
+
+// LoadFixture writes the given relationships
+func (l *LocalSpiceDbContainer) LoadFixture(client *SpiceDbAccessRepository, relationships []Relationship) error {
+	if len(relationships) == 0 {
+		return nil
+	}
+
+	updates := make([]*v1.RelationshipUpdate, len(relationships))
+	for i, r := range relationships {
+		subject, object := createSubjectObjectTuple(r.SubjectType, r.SubjectID, r.ResourceType, r.ResourceID)
+		updates[i] = &v1.RelationshipUpdate{Operation: v1.RelationshipUpdate_OPERATION_TOUCH, Relationship: &v1.Relationship{
+			Subject:  subject,
+			Resource: object,
+			Relation: r.Relation,
+		}}
+	}
+
+	_, err := client.client.WriteRelationships(context.Background(), &v1.WriteRelationshipsRequest{Updates: updates})
+	return err
+}
+
+// Reset deletes all relationships of the object types defined in the schema
+func (l *LocalSpiceDbContainer) Reset(client *SpiceDbAccessRepository) error {
+	for _, objectType := range definitionNames(l.schema) {
+		_, err := client.client.DeleteRelationships(context.Background(), &v1.DeleteRelationshipsRequest{
+			RelationshipFilter: &v1.RelationshipFilter{ResourceType: objectType},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete %s relationships: %w", objectType, err)
+		}
+	}
+
+	return nil
+}
+
+// defaultLicenseFixture is an org o1 with a license for smarts with 10 seats, one of which is assigned to u1
+func defaultLicenseFixture() []Relationship {
+	return []Relationship{
+		{ResourceType: LicenseObjectType, ResourceID: "o1/smarts", Relation: "licensed", SubjectType: "org", SubjectID: "o1"},
+		{ResourceType: LicenseObjectType, ResourceID: "o1/smarts", Relation: "max", SubjectType: "max", SubjectID: "10"},
+		{ResourceType: LicenseObjectType, ResourceID: "o1/smarts", Relation: "seats", SubjectType: LicenseSeatObjectType, SubjectID: "o1/smarts"},
+		{ResourceType: LicenseObjectType, ResourceID: "o1/smarts", Relation: LicenseVersionStr, SubjectType: LicenseVersionStr, SubjectID: "141B2939/1"},
+		{ResourceType: LicenseSeatObjectType, ResourceID: "o1/smarts", Relation: "assigned", SubjectType: SubjectType, SubjectID: "u1"},
+	}
+}
+
+func readSchema(file string) (string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	bootstrap := struct {
+		Schema string `yaml:"schema"`
+	}{}
+	if err := yaml.Unmarshal(content, &bootstrap); err != nil {
+		return "", err
+	}
+
+	return bootstrap.Schema, nil
+}
+
+func definitionNames(schema string) []string {
+	names := make([]string, 0)
+	for _, line := range strings.Split(schema, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "definition" {
+			names = append(names, fields[1])
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,171 @@
+package authzed
+
+import (
+	"authz/domain"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+// defaultRelationshipPageSize and maxRelationshipPageSize bound the relationships returned per page by ReadRelationships
+const (
+	defaultRelationshipPageSize = 100
+	maxRelationshipPageSize     = 1000
+)
+
+// maxRelationshipOffset is the number of relationships ReadRelationships pages through at most.
+// SpiceDB cannot resume reading at a cursor, so each page streams and discards all relationships before it. Bounding the offset bounds that work per request.
+const maxRelationshipOffset = 100000
+
+// relationshipAllowlist holds the kinds of relationships that may be written and deleted directly, as <resource type>#<relation>@<subject type>
+type relationshipAllowlist map[string]bool
+
+// newRelationshipAllowlist parses entries of the form <resource type>#<relation>@<subject type>, ex: group#member@user.
+// Licenses and seats cannot be allowed, their relationships must only be changed together with the license version that keeps the seat count.
+func newRelationshipAllowlist(entries []string) (relationshipAllowlist, error) {
+	allowlist := make(relationshipAllowlist, len(entries))
+	for _, entry := range entries {
+		resourceType, rest, ok := strings.Cut(entry, "#")
+		relation, subjectType, ok2 := strings.Cut(rest, "@")
+		if !ok || !ok2 || resourceType == "" || relation == "" || subjectType == "" {
+			return nil, fmt.Errorf("allowed relationship %q is not of the form <resource type>#<relation>@<subject type>", entry)
+		}
+		if resourceType == LicenseObjectType || resourceType == LicenseSeatObjectType {
+			return nil, fmt.Errorf("allowed relationship %q would bypass the seat count of licenses", entry)
+		}
+		allowlist[entry] = true
+	}
+	return allowlist, nil
+}
+
+func (a relationshipAllowlist) allows(relationship domain.Relationship) bool {
+	return a[fmt.Sprintf("%s#%s@%s", relationship.Resource.Type, relationship.Relation, relationship.Subject.Type)]
+}
+
+// WriteRelationship creates the relationship if its kind is allowed by the configured allowlist, otherwise domain.ErrRelationshipNotAllowed is returned. Writing an existing relationship is not an error.
+func (s *SpiceDbAccessRepository) WriteRelationship(ctx context.Context, relationship domain.Relationship) error {
+	return s.updateRelationship(ctx, v1.RelationshipUpdate_OPERATION_TOUCH, relationship)
+}
+
+// DeleteRelationship removes the relationship if its kind is allowed by the configured allowlist, otherwise domain.ErrRelationshipNotAllowed is returned. Deleting a missing relationship is not an error.
+func (s *SpiceDbAccessRepository) DeleteRelationship(ctx context.Context, relationship domain.Relationship) error {
+	return s.updateRelationship(ctx, v1.RelationshipUpdate_OPERATION_DELETE, relationship)
+}
+
+func (s *SpiceDbAccessRepository) updateRelationship(ctx context.Context, operation v1.RelationshipUpdate_Operation, relationship domain.Relationship) error {
+	if !s.allowedRelationships.allows(relationship) {
+		return fmt.Errorf("%w: %s", domain.ErrRelationshipNotAllowed, relationship)
+	}
+
+	subject, object := createSubjectObjectTuple(relationship.Subject.Type, relationship.Subject.ID, relationship.Resource.Type, relationship.Resource.ID)
+	_, err := s.client.WriteRelationships(ctx, &v1.WriteRelationshipsRequest{
+		Updates: []*v1.RelationshipUpdate{{
+			Operation: operation,
+			Relationship: &v1.Relationship{
+				Resource: object,
+				Relation: relationship.Relation,
+				Subject:  subject,
+			},
+		}},
+	})
+	if err != nil {
+		return convertSpiceDbError(err)
+	}
+
+	return nil
+}
+
+// ReadRelationships lists up to limit relationships matching the filter, starting after the cursor. A limit of 0 uses the default page size, larger limits are capped at the maximum page size.
+// SpiceDB streams all matching relationships, so the cursor holds the revision the first page was read at and the number of relationships already returned.
+// Following pages read at that exact revision, so relationships written in between neither shift nor duplicate results.
+// Pages beyond the first maxRelationshipOffset relationships are refused with domain.ErrInvalidRequest, the filter has to be narrowed to read them.
+func (s *SpiceDbAccessRepository) ReadRelationships(ctx context.Context, filter domain.RelationshipFilter, cursor string, limit int) (*domain.RelationshipPage, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidRequest)
+	}
+	if limit == 0 {
+		limit = defaultRelationshipPageSize
+	}
+	if limit > maxRelationshipPageSize {
+		limit = maxRelationshipPageSize
+	}
+
+	offset, consistency, err := decodeRelationshipCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= maxRelationshipOffset {
+		return nil, fmt.Errorf("%w: cannot page beyond %d relationships, narrow the filter by resource ID or relation", domain.ErrInvalidRequest, maxRelationshipOffset)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() //stops the stream once the page is full
+
+	result, err := s.client.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{
+		Consistency: consistency,
+		RelationshipFilter: &v1.RelationshipFilter{
+			ResourceType:       filter.ResourceType,
+			OptionalResourceId: filter.ResourceID,
+			OptionalRelation:   filter.Relation,
+		},
+	})
+	if err != nil {
+		return nil, convertSpiceDbError(err)
+	}
+
+	page := &domain.RelationshipPage{Relationships: make([]domain.Relationship, 0)}
+	for i := 0; ; i++ {
+		next, err := result.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, convertSpiceDbError(err)
+		}
+		if i < offset {
+			continue
+		}
+		if len(page.Relationships) == limit {
+			page.NextCursor = encodeRelationshipCursor(i, next.ReadAt.GetToken())
+			break
+		}
+
+		rel := next.Relationship
+		page.Relationships = append(page.Relationships, domain.Relationship{
+			Resource: domain.Resource{Type: rel.Resource.ObjectType, ID: rel.Resource.ObjectId},
+			Relation: rel.Relation,
+			Subject:  domain.Resource{Type: rel.Subject.Object.ObjectType, ID: rel.Subject.Object.ObjectId},
+		})
+	}
+
+	return page, nil
+}
+
+func encodeRelationshipCursor(offset int, revision string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", offset, revision)))
+}
+
+// decodeRelationshipCursor returns the number of relationships to skip and the consistency to read them with. An empty cursor reads the first page fully consistent.
+func decodeRelationshipCursor(cursor string) (int, *v1.Consistency, error) {
+	if cursor == "" {
+		return 0, &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}}, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidRequest)
+	}
+	offsetText, revision, ok := strings.Cut(string(decoded), ":")
+	offset, err := strconv.Atoi(offsetText)
+	if !ok || err != nil || offset < 0 || revision == "" {
+		return 0, nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidRequest)
+	}
+
+	return offset, &v1.Consistency{Requirement: &v1.Consistency_AtExactSnapshot{AtExactSnapshot: &v1.ZedToken{Token: revision}}}, nil
+}
@@ -0,0 +1,115 @@
+package authzed
+
+import (
+	"authz/domain"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelationshipAllowlistAllowsOnlyListedKinds(t *testing.T) {
+	allowlist, err := newRelationshipAllowlist([]string{"group#member@user"})
+	assert.NoError(t, err)
+
+	assert.True(t, allowlist.allows(domain.Relationship{Resource: domain.Resource{Type: "group", ID: "g1"}, Relation: "member", Subject: domain.Resource{Type: "user", ID: "u1"}}))
+	assert.False(t, allowlist.allows(domain.Relationship{Resource: domain.Resource{Type: "group", ID: "g1"}, Relation: "admin", Subject: domain.Resource{Type: "user", ID: "u1"}}))
+	assert.False(t, allowlist.allows(domain.Relationship{Resource: domain.Resource{Type: "group", ID: "g1"}, Relation: "member", Subject: domain.Resource{Type: "group", ID: "g2"}}))
+}
+
+func TestRelationshipAllowlistRejectsMalformedAndSeatEntries(t *testing.T) {
+	for _, entry := range []string{"group#member", "group@user", "#member@user", LicenseSeatObjectType + "#assigned@user", LicenseObjectType + "#max@max"} {
+		_, err := newRelationshipAllowlist([]string{entry})
+
+		assert.Error(t, err, "Should have rejected %s", entry)
+	}
+}
+
+func TestWriteRelationshipRejectsKindsNotAllowed(t *testing.T) {
+	repo := &SpiceDbAccessRepository{}
+
+	err := repo.WriteRelationship(context.Background(), domain.Relationship{Resource: domain.Resource{Type: "group", ID: "g1"}, Relation: "member", Subject: domain.Resource{Type: "user", ID: "u1"}})
+
+	assert.ErrorIs(t, err, domain.ErrRelationshipNotAllowed)
+}
+
+func TestWriteAndDeleteRelationship(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+	client.allowedRelationships = relationshipAllowlist{"service#licensed@license": true}
+	rel := domain.Relationship{Resource: domain.Resource{Type: "service", ID: "smarts"}, Relation: "licensed", Subject: domain.Resource{Type: "license", ID: "o1/smarts"}}
+
+	assert.NoError(t, client.WriteRelationship(context.Background(), rel))
+	assert.NoError(t, client.WriteRelationship(context.Background(), rel), "Writing again should not be an error.")
+	assert.NoError(t, client.DeleteRelationship(context.Background(), rel))
+	assert.NoError(t, client.DeleteRelationship(context.Background(), rel), "Deleting again should not be an error.")
+}
+
+func TestRelationshipCursorRoundTrip(t *testing.T) {
+	offset, consistency, err := decodeRelationshipCursor(encodeRelationshipCursor(42, "GhUKEzE2"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, offset)
+	assert.Equal(t, "GhUKEzE2", consistency.GetAtExactSnapshot().GetToken())
+
+	_, _, err = decodeRelationshipCursor("not a cursor")
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+}
+
+func TestReadRelationshipsRejectsNegativeLimit(t *testing.T) {
+	repo := &SpiceDbAccessRepository{}
+
+	_, err := repo.ReadRelationships(context.Background(), domain.RelationshipFilter{ResourceType: LicenseObjectType}, "", -1)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+}
+
+func TestReadRelationshipsRejectsCursorBeyondMaxOffset(t *testing.T) {
+	repo := &SpiceDbAccessRepository{}
+	cursor := encodeRelationshipCursor(maxRelationshipOffset, "GhUKEzE2")
+
+	_, err := repo.ReadRelationships(context.Background(), domain.RelationshipFilter{ResourceType: LicenseObjectType}, cursor, 10)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+}
+
+func TestReadRelationshipsPagesThroughAllRelationships(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+	filter := domain.RelationshipFilter{ResourceType: LicenseObjectType, ResourceID: "o1/smarts"}
+
+	first, err := client.ReadRelationships(context.Background(), filter, "", 3)
+	assert.NoError(t, err)
+	assert.Len(t, first.Relationships, 3)
+	assert.NotEmpty(t, first.NextCursor)
+
+	second, err := client.ReadRelationships(context.Background(), filter, first.NextCursor, 3)
+	assert.NoError(t, err)
+	assert.Len(t, second.Relationships, 1)
+	assert.Empty(t, second.NextCursor)
+
+	assert.NotContains(t, first.Relationships, second.Relationships[0], "Pages should not overlap.")
+}
+
+func TestReadRelationshipsDefaultsPageSize(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	page, err := client.ReadRelationships(context.Background(), domain.RelationshipFilter{ResourceType: LicenseObjectType, ResourceID: "o1/smarts"}, "", 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Relationships, 4, "A limit of 0 should use the default page size, not return nothing.")
+	assert.Empty(t, page.NextCursor)
+}
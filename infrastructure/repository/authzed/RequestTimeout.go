@@ -0,0 +1,64 @@
+package authzed
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc"
+)
+
+// timeoutUnaryInterceptor bounds each unary SpiceDB call by the timeout. A shorter deadline of the caller's context still applies.
+func timeoutUnaryInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		logIfTimedOut(ctx, method, timeout, err)
+		return err
+	}
+}
+
+// timeoutStreamInterceptor bounds each streaming SpiceDB call, including reading its results, by the timeout. A shorter deadline of the caller's context still applies.
+func timeoutStreamInterceptor(timeout time.Duration) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			logIfTimedOut(ctx, method, timeout, err)
+			cancel()
+			return nil, err
+		}
+
+		return &timeoutClientStream{ClientStream: stream, ctx: ctx, cancel: cancel, method: method, timeout: timeout}, nil
+	}
+}
+
+// timeoutClientStream releases the timeout of a stream once the stream ends
+type timeoutClientStream struct {
+	grpc.ClientStream
+	ctx     context.Context
+	cancel  context.CancelFunc
+	method  string
+	timeout time.Duration
+}
+
+// RecvMsg receives the next message of the stream, and releases the timeout once the stream ended or failed
+func (s *timeoutClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		logIfTimedOut(s.ctx, s.method, s.timeout, err)
+		s.cancel()
+	}
+	return err
+}
+
+// logIfTimedOut logs the method if it failed because its deadline, the request timeout or an earlier one of the caller, expired
+func logIfTimedOut(ctx context.Context, method string, timeout time.Duration, err error) {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		glog.Warningf("SpiceDB call %s exceeded its deadline (request timeout %s): %v", method, timeout, err)
+	}
+}
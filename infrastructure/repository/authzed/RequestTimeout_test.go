@@ -0,0 +1,64 @@
+package authzed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTimeoutUnaryInterceptorBoundsCallsWithoutDeadline(t *testing.T) {
+	interceptor := timeoutUnaryInterceptor(50 * time.Millisecond)
+
+	start := time.Now()
+	err := interceptor(context.Background(), "/test/Method", nil, nil, nil, waitForDeadline)
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestTimeoutUnaryInterceptorKeepsShorterCallerDeadline(t *testing.T) {
+	interceptor := timeoutUnaryInterceptor(time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := interceptor(ctx, "/test/Method", nil, nil, nil, waitForDeadline)
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestTimeoutStreamInterceptorReleasesTimeoutWhenStreamEnds(t *testing.T) {
+	interceptor := timeoutStreamInterceptor(time.Hour)
+	var streamCtx context.Context
+
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/test/Stream",
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			streamCtx = ctx
+			return endedClientStream{}, nil
+		})
+	assert.NoError(t, err)
+	assert.NoError(t, streamCtx.Err())
+
+	err = stream.RecvMsg(nil)
+	assert.Error(t, err)
+	assert.ErrorIs(t, streamCtx.Err(), context.Canceled)
+}
+
+func waitForDeadline(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	<-ctx.Done()
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+type endedClientStream struct {
+	grpc.ClientStream
+}
+
+func (endedClientStream) RecvMsg(m interface{}) error {
+	return status.Error(codes.Unavailable, "stream ended")
+}
@@ -0,0 +1,114 @@
+package authzed
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// JitterStrategy randomizes the backoff between retries, so that many clients failing at the same time do not retry at the same time
+type JitterStrategy string
+
+const (
+	// JitterNone waits the exponential backoff
+	JitterNone JitterStrategy = "none"
+	// JitterFull waits a random duration between 0 and the exponential backoff
+	JitterFull JitterStrategy = "full"
+	// JitterEqual waits half the exponential backoff plus a random duration up to the other half
+	JitterEqual JitterStrategy = "equal"
+)
+
+// RetryPolicy configures how often and with which exponential backoff reads from SpiceDB are retried on transient errors
+type RetryPolicy struct {
+	MaxAttempts    int //including the first attempt, 1 or less disables retries
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Jitter         JitterStrategy //empty is JitterNone
+	random         *lockedRand    //nil uses the global source of math/rand, see WithSeed
+}
+
+// DefaultRetryPolicy retries reads twice, waiting up to 50ms and 100ms
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 50 * time.Millisecond,
+	MaxBackoff:     time.Second,
+	Jitter:         JitterFull,
+}
+
+// WithSeed returns a copy of the policy whose jitter is drawn from a source with the given seed, ex: to get the same backoffs in tests
+func (p RetryPolicy) WithSeed(seed int64) RetryPolicy {
+	p.random = &lockedRand{rand: rand.New(rand.NewSource(seed))}
+	return p
+}
+
+// delay returns the duration to wait for the given exponential backoff, according to the jitter strategy
+func (p RetryPolicy) delay(backoff time.Duration) time.Duration {
+	switch p.Jitter {
+	case JitterFull:
+		return time.Duration(p.float64() * float64(backoff))
+	case JitterEqual:
+		return backoff/2 + time.Duration(p.float64()*float64(backoff/2))
+	default:
+		return backoff
+	}
+}
+
+func (p RetryPolicy) float64() float64 {
+	if p.random == nil {
+		return rand.Float64()
+	}
+	return p.random.Float64()
+}
+
+// lockedRand makes a seeded source safe for the concurrent retries of one repository
+type lockedRand struct {
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+// Float64 returns a random number in [0.0,1.0)
+func (r *lockedRand) Float64() float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.rand.Float64()
+}
+
+// withRetry runs the operation until it succeeds, fails with a non-transient error, the attempts are exhausted or the context is done, and returns its last error
+func (p RetryPolicy) withRetry(ctx context.Context, operation string, op func() error) error {
+	backoff := p.InitialBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = op()
+		if err == nil || !isTransient(err) || attempt >= p.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		delay := p.delay(backoff)
+		glog.Warningf("Transient error in %s (attempt %d of %d), retrying in %s: %v", operation, attempt, p.MaxAttempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		backoff *= 2
+		if backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
+
+// isTransient returns whether the error is likely to go away when retrying, ex: SpiceDB is briefly unavailable
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
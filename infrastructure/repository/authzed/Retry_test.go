@@ -0,0 +1,78 @@
+package authzed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithRetrySucceedsAfterTransientErrors(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	attempts := 0
+
+	err := policy.withRetry(context.Background(), "test", func() error {
+		attempts++
+		if attempts < 3 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	attempts := 0
+
+	err := policy.withRetry(context.Background(), "test", func() error {
+		attempts++
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestWithRetryDoesNotRetryPermanentErrors(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	attempts := 0
+
+	err := policy.withRetry(context.Background(), "test", func() error {
+		attempts++
+		return errors.New("permanent")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryDelayStaysWithinJitterBounds(t *testing.T) {
+	backoff := 100 * time.Millisecond
+	full := RetryPolicy{Jitter: JitterFull}.WithSeed(1)
+	equal := RetryPolicy{Jitter: JitterEqual}.WithSeed(1)
+
+	for i := 0; i < 100; i++ {
+		assert.GreaterOrEqual(t, full.delay(backoff), time.Duration(0))
+		assert.Less(t, full.delay(backoff), backoff)
+		assert.GreaterOrEqual(t, equal.delay(backoff), backoff/2)
+		assert.Less(t, equal.delay(backoff), backoff)
+	}
+	assert.Equal(t, backoff, RetryPolicy{}.delay(backoff), "Should not have jittered without a strategy.")
+	assert.Equal(t, backoff, RetryPolicy{Jitter: JitterNone}.delay(backoff))
+}
+
+func TestRetryDelayIsDeterministicWithSeed(t *testing.T) {
+	first := RetryPolicy{Jitter: JitterFull}.WithSeed(42)
+	second := RetryPolicy{Jitter: JitterFull}.WithSeed(42)
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first.delay(time.Second), second.delay(time.Second))
+	}
+}
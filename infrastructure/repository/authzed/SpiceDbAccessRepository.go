@@ -4,12 +4,15 @@ package authzed
 import (
 	"authz/domain"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/glog"
 
@@ -17,7 +20,10 @@ import (
 	"github.com/authzed/authzed-go/v1"
 	"github.com/authzed/grpcutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
 // SubjectType user
@@ -32,9 +38,25 @@ const LicenseObjectType = "license"
 // LicenseVersionStr - License Version realation
 const LicenseVersionStr = "version"
 
+// licenseDeletePageSize bounds the number of seat relationships deleted per write when deleting a license
+const licenseDeletePageSize = 500
+
+// DefaultKeepalive - keepalive parameters matching grpc-go's default server-side enforcement policy used by SpiceDB: pings at most every 5 minutes and none without active streams
+var DefaultKeepalive = keepalive.ClientParameters{
+	Time:                5 * time.Minute,
+	Timeout:             20 * time.Second,
+	PermitWithoutStream: false,
+}
+
 // SpiceDbAccessRepository -
 type SpiceDbAccessRepository struct {
 	authzedClient
+	retry               RetryPolicy
+	maxConcurrentChecks int
+	maxLookupResults    int
+	strictChecks        bool
+	//the kinds of relationships WriteRelationship and DeleteRelationship may change, none by default
+	allowedRelationships relationshipAllowlist
 }
 
 // authzedClient - Authz client struct
@@ -44,103 +66,271 @@ type authzedClient struct {
 }
 
 // CheckAccess - verify permission with subject type "user"
-func (s *SpiceDbAccessRepository) CheckAccess(subjectID domain.SubjectID, operation string, resource domain.Resource) (domain.AccessDecision, error) {
+func (s *SpiceDbAccessRepository) CheckAccess(ctx context.Context, subjectID domain.SubjectID, operation string, resource domain.Resource) (domain.AccessDecision, error) {
+	if resource.Type == LicenseObjectType {
+		if _, _, err := domain.ParseLicenseResourceID(resource.ID); err != nil {
+			return domain.AccessDecision{}, err
+		}
+	}
+
+	if s.strictChecks {
+		if err := s.ensureResourceExists(ctx, resource); err != nil {
+			return domain.AccessDecision{}, err
+		}
+	}
+
 	subject, object := createSubjectObjectTuple(SubjectType, string(subjectID), resource.Type, resource.ID)
 
-	result, err := s.client.CheckPermission(s.ctx, &v1.CheckPermissionRequest{
+	result, err := s.client.CheckPermission(ctx, &v1.CheckPermissionRequest{
 		Resource:   object,
 		Permission: operation,
 		Subject:    subject,
 	})
 
 	if err != nil {
-		glog.Errorf("Failed to check permission :%v", err.Error())
-		return false, err
+		err = convertSpiceDbError(err)
+		if errors.Is(err, domain.ErrCheckTooComplex) {
+			glog.Warningf("Permission check of %s on %s:%s for subject %s exceeds the maximum depth, check the schema and relationships for recursion: %v", operation, resource.Type, resource.ID, subjectID, err)
+		} else {
+			glog.Errorf("Failed to check permission :%v", err.Error())
+		}
+		return domain.AccessDecision{}, err
 	}
 
 	if result.Permissionship == v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION {
-		return true, nil
+		return domain.Allow(), nil
 	}
 
 	//DENIED BY DEFAULT
-	return false, nil
+	return domain.NewAccessDecision(false), nil
 }
 
-// AssignSeat create the relation
-func (s *SpiceDbAccessRepository) AssignSeat(subjectID domain.SubjectID, orgID string, svc domain.Service) error {
-	subject, object := createSubjectObjectTuple(SubjectType, string(subjectID), LicenseSeatObjectType, fmt.Sprintf("%s/%s", orgID, svc.ID))
-	var relationshipUpdates = []*v1.RelationshipUpdate{
-		{Operation: v1.RelationshipUpdate_OPERATION_CREATE, Relationship: &v1.Relationship{
-			Subject:  subject,
-			Resource: object,
-			Relation: "assigned",
-		}},
-	}
+// ensureResourceExists returns domain.ErrResourceNotFound if the resource is not the resource of any relationship, by reading at most one of its relationships
+func (s *SpiceDbAccessRepository) ensureResourceExists(ctx context.Context, resource domain.Resource) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() //Stops the stream after the first relationship
 
-	result, err := s.client.WriteRelationships(s.ctx, &v1.WriteRelationshipsRequest{
-		Updates: relationshipUpdates,
+	resp, err := s.client.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{
+		RelationshipFilter: &v1.RelationshipFilter{
+			ResourceType:       resource.Type,
+			OptionalResourceId: resource.ID,
+		},
 	})
+	if err != nil {
+		return convertSpiceDbError(err)
+	}
 
+	_, err = resp.Recv()
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %s:%s", domain.ErrResourceNotFound, resource.Type, resource.ID)
+	}
 	if err != nil {
-		glog.Errorf("Failed to assign relation :%v", err.Error())
-		return err
+		return convertSpiceDbError(err)
 	}
 
-	glog.Infof("Assigned operation :%v", result)
+	return nil
+}
+
+// CheckAccessBulk - verify permissions for multiple events, the results and errors are in the same order as the events
+func (s *SpiceDbAccessRepository) CheckAccessBulk(ctx context.Context, events []domain.CheckEvent) ([]domain.AccessDecision, []error) {
+	//TODO: switch to BulkCheckPermission once authzed-go is upgraded, the pinned client does not offer it yet. Until then, the checks are issued concurrently.
+	return checkConcurrently(events, s.maxConcurrentChecks, func(evt domain.CheckEvent) (domain.AccessDecision, error) {
+		return s.CheckAccess(ctx, evt.SubjectID, evt.Operation, evt.Resource)
+	})
+}
+
+// DefaultMaxLookupResults is the default number of results one lookup returns at most
+const DefaultMaxLookupResults = 10000
+
+// LookupAccessibleResources - look up all resources of the given type on which the subject with type "user" has the permission.
+// The pinned client cannot page lookups, so lookups matching more than the configured maximum of results fail with domain.ErrTooManyResults instead of being held in memory.
+func (s *SpiceDbAccessRepository) LookupAccessibleResources(ctx context.Context, subjectID domain.SubjectID, operation string, resourceType string) ([]domain.Resource, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() //stops the stream if there are too many results
+
+	limit := s.lookupLimit()
+	result, err := s.client.LookupResources(ctx, &v1.LookupResourcesRequest{
+		ResourceObjectType: resourceType,
+		Permission:         operation,
+		Subject: &v1.SubjectReference{Object: &v1.ObjectReference{
+			ObjectType: SubjectType,
+			ObjectId:   string(subjectID),
+		}},
+	})
 
-	//Update the license version count - increment
-	err = s.modifyLicenseSeatsVersionCount(orgID, svc.ID, 1, true)
 	if err != nil {
-		glog.Errorf("Failed to update license version relation :%v", err.Error())
-		return err
+		glog.Errorf("Failed to lookup resources :%v", err.Error())
+		return nil, convertSpiceDbError(err)
 	}
 
-	return nil
+	resources := make([]domain.Resource, 0)
+	for {
+		next, err := result.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			glog.Errorf("Failed iterate lookup resources response :%v", err.Error())
+			return nil, convertSpiceDbError(err)
+		}
+		if len(resources) == limit {
+			return nil, fmt.Errorf("%w: subject %s can %s more than %d resources of type %s", domain.ErrTooManyResults, subjectID, operation, limit, resourceType)
+		}
+
+		resources = append(resources, domain.Resource{Type: resourceType, ID: next.ResourceObjectId})
+	}
+
+	return resources, nil
 }
 
-// UnAssignSeat delete the relation
-func (s *SpiceDbAccessRepository) UnAssignSeat(subjectID domain.SubjectID, orgID string, svc domain.Service) error {
-	result, err := s.client.DeleteRelationships(s.ctx, &v1.DeleteRelationshipsRequest{
-		RelationshipFilter: &v1.RelationshipFilter{
-			ResourceType:     LicenseSeatObjectType,
-			OptionalRelation: "assigned",
-			OptionalSubjectFilter: &v1.SubjectFilter{
-				SubjectType:       SubjectType,
-				OptionalSubjectId: string(subjectID),
-			},
+// LookupAccessingSubjects - look up all subjects with type "user" that have the permission on the resource, following the full permission graph.
+// Like LookupAccessibleResources, lookups matching more than the configured maximum of results fail with domain.ErrTooManyResults.
+func (s *SpiceDbAccessRepository) LookupAccessingSubjects(ctx context.Context, operation string, resource domain.Resource) ([]domain.SubjectID, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() //stops the stream if there are too many results
+
+	limit := s.lookupLimit()
+	result, err := s.client.LookupSubjects(ctx, &v1.LookupSubjectsRequest{
+		Resource: &v1.ObjectReference{
+			ObjectType: resource.Type,
+			ObjectId:   resource.ID,
 		},
+		Permission:        operation,
+		SubjectObjectType: SubjectType,
 	})
 
-	glog.Infof("Deleted relation :%v", result)
+	if err != nil {
+		glog.Errorf("Failed to lookup subjects :%v", err.Error())
+		return nil, convertSpiceDbError(err)
+	}
+
+	ids := make([]domain.SubjectID, 0)
+	for {
+		next, err := result.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			glog.Errorf("Failed iterate lookup subjects response :%v", err.Error())
+			return nil, convertSpiceDbError(err)
+		}
+		if len(ids) == limit {
+			return nil, fmt.Errorf("%w: more than %d subjects can %s %s:%s", domain.ErrTooManyResults, limit, operation, resource.Type, resource.ID)
+		}
+
+		ids = append(ids, domain.SubjectID(next.SubjectObjectId))
+	}
+
+	return ids, nil
+}
 
+// lookupLimit returns the maximum number of results of one lookup
+func (s *SpiceDbAccessRepository) lookupLimit() int {
+	if s.maxLookupResults > 0 {
+		return s.maxLookupResults
+	}
+	return DefaultMaxLookupResults
+}
+
+// AssignSeat assigns the given principal a seat for the given service. See AssignSeats.
+func (s *SpiceDbAccessRepository) AssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error {
+	return s.AssignSeats(ctx, []domain.SubjectID{subjectID}, orgID, svc)
+}
+
+// AssignSeats assigns the given principals seats for the given service.
+// All assignments and the license version count update are written in one WriteRelationships call, so they are applied atomically: if any subject is already assigned, nothing is written.
+func (s *SpiceDbAccessRepository) AssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	updates := make([]*v1.RelationshipUpdate, 0, len(subjectIDs)+2)
+	for _, subjectID := range subjectIDs {
+		subject, object := createSubjectObjectTuple(SubjectType, string(subjectID), LicenseSeatObjectType, domain.LicenseResourceID(orgID, svc.ID))
+		updates = append(updates, &v1.RelationshipUpdate{Operation: v1.RelationshipUpdate_OPERATION_CREATE, Relationship: &v1.Relationship{
+			Subject:  subject,
+			Resource: object,
+			Relation: "assigned",
+		}})
+	}
+
+	err := s.writeSeatUpdatesWithVersionCount(ctx, orgID, svc.ID, updates, nil, len(subjectIDs))
 	if err != nil {
-		glog.Errorf("Failed to delete relation :%v", err.Error())
+		glog.Errorf("Failed to assign seats :%v", err.Error())
 		return err
 	}
 
-	//Update the license version count - decrement
-	err = s.modifyLicenseSeatsVersionCount(orgID, svc.ID, 1, false)
+	return nil
+}
+
+// UnAssignSeat removes the seat assignment for the given principal for the given service. See UnAssignSeats.
+func (s *SpiceDbAccessRepository) UnAssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error {
+	return s.UnAssignSeats(ctx, []domain.SubjectID{subjectID}, orgID, svc)
+}
+
+// UnAssignSeats removes the seat assignments for the given principals for the given service.
+// All removals and the license version count update are written in one WriteRelationships call, so they are applied atomically: if any subject is not assigned, nothing is written.
+func (s *SpiceDbAccessRepository) UnAssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	updates := make([]*v1.RelationshipUpdate, 0, len(subjectIDs)+2)
+	preconditions := make([]*v1.Precondition, 0, len(subjectIDs)+1)
+	for _, subjectID := range subjectIDs {
+		subject, object := createSubjectObjectTuple(SubjectType, string(subjectID), LicenseSeatObjectType, domain.LicenseResourceID(orgID, svc.ID))
+		updates = append(updates, &v1.RelationshipUpdate{Operation: v1.RelationshipUpdate_OPERATION_DELETE, Relationship: &v1.Relationship{
+			Subject:  subject,
+			Resource: object,
+			Relation: "assigned",
+		}})
+		//Deleting a missing relationship is a no-op in SpiceDB, so require the assignment to exist to keep the count correct
+		preconditions = append(preconditions, &v1.Precondition{
+			Operation: v1.Precondition_OPERATION_MUST_MATCH,
+			Filter: &v1.RelationshipFilter{
+				ResourceType:       LicenseSeatObjectType,
+				OptionalResourceId: object.ObjectId,
+				OptionalRelation:   "assigned",
+				OptionalSubjectFilter: &v1.SubjectFilter{
+					SubjectType:       SubjectType,
+					OptionalSubjectId: string(subjectID),
+				},
+			},
+		})
+	}
+
+	err := s.writeSeatUpdatesWithVersionCount(ctx, orgID, svc.ID, updates, preconditions, -len(subjectIDs))
 	if err != nil {
-		glog.Errorf("Failed to update license version relation :%v", err.Error())
+		glog.Errorf("Failed to unassign seats :%v", err.Error())
 		return err
 	}
+
 	return nil
 }
 
-// GetLicense - Get the current license infoarmation
-func (s *SpiceDbAccessRepository) GetLicense(orgID string, serviceID string) (*domain.License, error) {
+// GetLicense - Get the current license infoarmation. domain.ErrLicenseNotFound is returned if there is no license for the org and service. Transient errors are retried.
+func (s *SpiceDbAccessRepository) GetLicense(ctx context.Context, orgID string, serviceID string) (*domain.License, error) {
+	var license *domain.License
+	err := s.retry.withRetry(ctx, "GetLicense", func() (err error) {
+		license, err = s.readLicense(ctx, orgID, serviceID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if license.OrgID == "" { //No relationships found
+		return nil, fmt.Errorf("%w: %s", domain.ErrLicenseNotFound, domain.LicenseResourceID(orgID, serviceID))
+	}
+
+	return license, nil
+}
+
+func (s *SpiceDbAccessRepository) readLicense(ctx context.Context, orgID string, serviceID string) (*domain.License, error) {
 	var license domain.License
-	resp, err := s.client.ReadRelationships(s.ctx, &v1.ReadRelationshipsRequest{
+	resp, err := s.client.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{
 		Consistency: &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
 		RelationshipFilter: &v1.RelationshipFilter{
 			ResourceType:       LicenseObjectType,
-			OptionalResourceId: fmt.Sprintf("%s/%s", orgID, serviceID),
+			OptionalResourceId: domain.LicenseResourceID(orgID, serviceID),
 		},
 	})
 
 	if err != nil {
 		glog.Errorf("Failed to read License relation :%v", err.Error())
-		return nil, err
+		return nil, convertSpiceDbError(err)
 	}
 
 	for {
@@ -150,7 +340,7 @@ func (s *SpiceDbAccessRepository) GetLicense(orgID string, serviceID string) (*d
 		}
 		if err != nil {
 			glog.Errorf("Failed iterate License read response :%v", err.Error())
-			return nil, err
+			return nil, convertSpiceDbError(err)
 		}
 		// The Max relation is read to extract the MAx count of the license
 		if v.Relationship.Relation == "max" {
@@ -182,19 +372,29 @@ func (s *SpiceDbAccessRepository) GetLicense(orgID string, serviceID string) (*d
 	return &license, nil
 }
 
-// GetAssigned - todo implementation
-func (s *SpiceDbAccessRepository) GetAssigned(orgID string, serviceID string) ([]domain.SubjectID, error) {
-	result, err := s.client.LookupSubjects(s.ctx, &v1.LookupSubjectsRequest{
+// GetAssigned retrieves the IDs of the subjects assigned seats in the current license. Transient errors are retried.
+func (s *SpiceDbAccessRepository) GetAssigned(ctx context.Context, orgID string, serviceID string) ([]domain.SubjectID, error) {
+	var ids []domain.SubjectID
+	err := s.retry.withRetry(ctx, "GetAssigned", func() (err error) {
+		ids, err = s.readAssigned(ctx, orgID, serviceID)
+		return err
+	})
+
+	return ids, err
+}
+
+func (s *SpiceDbAccessRepository) readAssigned(ctx context.Context, orgID string, serviceID string) ([]domain.SubjectID, error) {
+	result, err := s.client.LookupSubjects(ctx, &v1.LookupSubjectsRequest{
 		Resource: &v1.ObjectReference{
 			ObjectType: LicenseObjectType,
-			ObjectId:   fmt.Sprintf("%s/%s", orgID, serviceID),
+			ObjectId:   domain.LicenseResourceID(orgID, serviceID),
 		},
 		Permission:        "access",
 		SubjectObjectType: SubjectType,
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, convertSpiceDbError(err)
 	}
 
 	ids := make([]domain.SubjectID, 0)
@@ -204,7 +404,7 @@ func (s *SpiceDbAccessRepository) GetAssigned(orgID string, serviceID string) ([
 			break
 		}
 		if err != nil {
-			return nil, err
+			return nil, convertSpiceDbError(err)
 		}
 
 		ids = append(ids, domain.SubjectID(next.SubjectObjectId))
@@ -212,141 +412,651 @@ func (s *SpiceDbAccessRepository) GetAssigned(orgID string, serviceID string) ([
 	return ids, nil
 }
 
-func (s *SpiceDbAccessRepository) modifyLicenseSeatsVersionCount(orgID, serviceID string, count int, increment bool) error {
-	//Step1 - Read the current License version
-	resp, err := s.client.ReadRelationships(s.ctx, &v1.ReadRelationshipsRequest{
-		Consistency: &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
-		RelationshipFilter: &v1.RelationshipFilter{
-			ResourceType:       LicenseObjectType,
-			OptionalResourceId: fmt.Sprintf("%s/%s", orgID, serviceID),
-		},
+// ListServices retrieves the services the given organization holds a license for. Transient errors are retried.
+func (s *SpiceDbAccessRepository) ListServices(ctx context.Context, orgID string) ([]domain.Service, error) {
+	var services []domain.Service
+	err := s.retry.withRetry(ctx, "ListServices", func() (err error) {
+		services, err = s.readServices(ctx, orgID)
+		return err
+	})
+
+	return services, err
+}
+
+// readServices looks up the licenses the org is related to as licensed. License IDs are of the form <orgID>/<serviceID>, so the service is the second segment of each ID.
+func (s *SpiceDbAccessRepository) readServices(ctx context.Context, orgID string) ([]domain.Service, error) {
+	result, err := s.client.LookupResources(ctx, &v1.LookupResourcesRequest{
+		Consistency:        &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		ResourceObjectType: LicenseObjectType,
+		Permission:         "licensed",
+		Subject: &v1.SubjectReference{Object: &v1.ObjectReference{
+			ObjectType: "org",
+			ObjectId:   orgID,
+		}},
 	})
 
 	if err != nil {
-		glog.Errorf("Failed to read License relation :%v", err.Error())
+		return nil, convertSpiceDbError(err)
+	}
+
+	services := make([]domain.Service, 0)
+	for {
+		next, err := result.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, convertSpiceDbError(err)
+		}
+
+		licenseOrgID, serviceID, err := domain.ParseLicenseResourceID(next.ResourceObjectId)
+		if err != nil || licenseOrgID != orgID {
+			glog.Warningf("Skipping license %s of org %s, its ID is not of the form <orgID>/<serviceID> of the org", next.ResourceObjectId, orgID)
+			continue
+		}
+		services = append(services, domain.Service{ID: serviceID})
+	}
+	return services, nil
+}
+
+// ListAssignedServices retrieves the services of the given organization the given subject is assigned a seat for. Transient errors are retried.
+func (s *SpiceDbAccessRepository) ListAssignedServices(ctx context.Context, orgID string, subjectID domain.SubjectID) ([]domain.Service, error) {
+	var services []domain.Service
+	err := s.retry.withRetry(ctx, "ListAssignedServices", func() (err error) {
+		services, err = s.readAssignedServices(ctx, orgID, subjectID)
 		return err
+	})
+
+	return services, err
+}
+
+// readAssignedServices looks up the license seats the subject is assigned to. Seats have the ID of their license, so the services of the org are those of the IDs with the org as first segment.
+func (s *SpiceDbAccessRepository) readAssignedServices(ctx context.Context, orgID string, subjectID domain.SubjectID) ([]domain.Service, error) {
+	result, err := s.client.LookupResources(ctx, &v1.LookupResourcesRequest{
+		Consistency:        &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		ResourceObjectType: LicenseSeatObjectType,
+		Permission:         "assigned",
+		Subject: &v1.SubjectReference{Object: &v1.ObjectReference{
+			ObjectType: SubjectType,
+			ObjectId:   string(subjectID),
+		}},
+	})
+
+	if err != nil {
+		return nil, convertSpiceDbError(err)
 	}
 
-	var assignedCount int
-	var currentLicenseVersion string
+	services := make([]domain.Service, 0)
 	for {
-		v, err := resp.Recv()
-		if err != nil && err == io.EOF {
+		next, err := result.Recv()
+		if errors.Is(err, io.EOF) {
 			break
 		}
 		if err != nil {
-			glog.Errorf("Failed iterate License read response :%v", err.Error())
-			return err
+			return nil, convertSpiceDbError(err)
 		}
-		// The version is of the form: <Versionstring>/currentassignedseatscount
-		if v.Relationship.Relation == "version" {
-			glog.Infof("License - Version : %v", v.Relationship.Subject.Object.ObjectId)
-			//spilt with "/" and the second part of the string is the current assigned count
-			versionStrArr := strings.Split(v.Relationship.Subject.Object.ObjectId, "/")
-			if len(versionStrArr) != 2 {
-				return fmt.Errorf("invalid license version %s", v.Relationship.Subject.Object.ObjectId)
-			}
-			assignedCount, err = strconv.Atoi(versionStrArr[1])
-			if err != nil {
-				return err
-			}
-			currentLicenseVersion = versionStrArr[0]
+
+		licenseOrgID, serviceID, err := domain.ParseLicenseResourceID(next.ResourceObjectId)
+		if err != nil || licenseOrgID != orgID {
+			continue //Seats of other orgs, or with malformed IDs that were never written by this repository
 		}
+		services = append(services, domain.Service{ID: serviceID})
 	}
+	return services, nil
+}
 
-	// Step 2 Delete the existing License - Version relationship
-	err = s.deleteLicenseVersionRelation(orgID, serviceID, currentLicenseVersion, assignedCount)
+// ApplySchema writes the given SpiceDB schema, replacing the current one
+func (s *SpiceDbAccessRepository) ApplySchema(schema string) error {
+	_, err := s.client.WriteSchema(s.ctx, &v1.WriteSchemaRequest{Schema: schema})
 	if err != nil {
-		glog.Errorf("Failed to delete old License version relation :%v", err.Error())
+		glog.Errorf("Failed to write schema :%v", err.Error())
 		return err
 	}
 
-	//Step 3 - Write the new License - Version relationship
-	//Get the old Data and perform the modification
-	if increment {
-		assignedCount = assignedCount + count
-	} else {
-		assignedCount = assignedCount - count
+	return nil
+}
+
+// Ping checks SpiceDB is reachable and has a schema by reading the schema
+func (s *SpiceDbAccessRepository) Ping(ctx context.Context) error {
+	_, err := s.client.ReadSchema(ctx, &v1.ReadSchemaRequest{})
+	if err != nil {
+		return convertSpiceDbError(err)
 	}
-	err = s.writeLicenseVersionRelation(orgID, serviceID, currentLicenseVersion, assignedCount)
 
+	return nil
+}
+
+// SeedLicense creates a new license with the given max seats and no assigned seats for the given org and service
+func (s *SpiceDbAccessRepository) SeedLicense(orgID string, serviceID string, maxSeats int) error {
+	return s.CreateLicense(s.ctx, orgID, serviceID, maxSeats)
+}
+
+// CreateLicense writes the relationships of a new license with the given max seats and no assigned seats for the given org and service.
+// The absence of a license version is a precondition, so creating a license that already exists fails with domain.ErrLicenseAlreadyExists, also concurrently.
+func (s *SpiceDbAccessRepository) CreateLicense(ctx context.Context, orgID string, serviceID string, maxSeats int) error {
+	version, err := newLicenseVersion()
 	if err != nil {
-		glog.Errorf("Failed to write new License version relation :%v", err.Error())
 		return err
 	}
+
+	licenseID := domain.LicenseResourceID(orgID, serviceID)
+	relationships := []struct {
+		relation    string
+		subjectType string
+		subjectID   string
+	}{
+		{relation: "licensed", subjectType: "org", subjectID: orgID},
+		{relation: "max", subjectType: "max", subjectID: strconv.Itoa(maxSeats)},
+		{relation: "seats", subjectType: LicenseSeatObjectType, subjectID: licenseID},
+		{relation: LicenseVersionStr, subjectType: LicenseVersionStr, subjectID: fmt.Sprintf("%s/%d", version, 0)},
+	}
+
+	relationshipUpdates := make([]*v1.RelationshipUpdate, len(relationships))
+	for i, r := range relationships {
+		subject, object := createSubjectObjectTuple(r.subjectType, r.subjectID, LicenseObjectType, licenseID)
+		relationshipUpdates[i] = &v1.RelationshipUpdate{Operation: v1.RelationshipUpdate_OPERATION_CREATE, Relationship: &v1.Relationship{
+			Subject:  subject,
+			Resource: object,
+			Relation: r.relation,
+		}}
+	}
+
+	result, err := s.client.WriteRelationships(ctx, &v1.WriteRelationshipsRequest{
+		Updates: relationshipUpdates,
+		OptionalPreconditions: []*v1.Precondition{{
+			Operation: v1.Precondition_OPERATION_MUST_NOT_MATCH,
+			Filter: &v1.RelationshipFilter{
+				ResourceType:       LicenseObjectType,
+				OptionalResourceId: licenseID,
+				OptionalRelation:   LicenseVersionStr,
+			},
+		}},
+	})
+	if status.Code(err) == codes.FailedPrecondition || status.Code(err) == codes.AlreadyExists {
+		return fmt.Errorf("%w: %s", domain.ErrLicenseAlreadyExists, licenseID)
+	}
+	if err != nil {
+		glog.Errorf("Failed to create license :%v", err.Error())
+		return convertSpiceDbError(err)
+	}
+
+	glog.Infof("Created license :%v", result)
 	return nil
 }
 
-func (s *SpiceDbAccessRepository) deleteLicenseVersionRelation(_, _, versionStr string, count int) error {
-	resp, err := s.client.DeleteRelationships(s.ctx, &v1.DeleteRelationshipsRequest{
-		RelationshipFilter: &v1.RelationshipFilter{
-			ResourceType:     LicenseObjectType,
-			OptionalRelation: LicenseVersionStr,
-			OptionalSubjectFilter: &v1.SubjectFilter{
-				SubjectType:       LicenseVersionStr,
-				OptionalSubjectId: fmt.Sprintf("%s/%d", versionStr, count),
+// DeleteLicense deletes the seat relationships of the license in pages of licenseDeletePageSize, then the relationships of the license, and returns the number of seats released.
+// The license is deleted last, so a partial failure leaves a license that can be deleted again rather than orphaned seats. If a seat is assigned while the seats are released,
+// deleting the license fails its precondition and the new seats are released as well. Deleting a missing license is a no-op.
+func (s *SpiceDbAccessRepository) DeleteLicense(ctx context.Context, orgID string, serviceID string) (int, error) {
+	licenseID := domain.LicenseResourceID(orgID, serviceID)
+
+	released := 0
+	for {
+		n, err := s.releaseSeats(ctx, licenseID)
+		released += n
+		if err != nil {
+			glog.Errorf("Failed to delete seats of license %s after releasing %d :%v", licenseID, released, err.Error())
+			return released, err
+		}
+
+		_, err = s.client.DeleteRelationships(ctx, &v1.DeleteRelationshipsRequest{
+			RelationshipFilter: &v1.RelationshipFilter{
+				ResourceType:       LicenseObjectType,
+				OptionalResourceId: licenseID,
 			},
+			OptionalPreconditions: []*v1.Precondition{{
+				Operation: v1.Precondition_OPERATION_MUST_NOT_MATCH,
+				Filter: &v1.RelationshipFilter{
+					ResourceType:       LicenseSeatObjectType,
+					OptionalResourceId: licenseID,
+					OptionalRelation:   "assigned",
+				},
+			}},
+		})
+		if status.Code(err) == codes.FailedPrecondition {
+			continue //A seat was assigned concurrently
+		}
+		if err != nil {
+			glog.Errorf("Failed to delete license %s after releasing %d seats :%v", licenseID, released, err.Error())
+			return released, convertSpiceDbError(err)
+		}
+		break
+	}
+
+	glog.Infof("Deleted license %s and released %d seats", licenseID, released)
+	return released, nil
+}
+
+// releaseSeats deletes all seat relationships of the given license in pages of licenseDeletePageSize and returns the number of seats released, also on error
+func (s *SpiceDbAccessRepository) releaseSeats(ctx context.Context, licenseID string) (int, error) {
+	released := 0
+	for {
+		var seats []*v1.Relationship
+		err := s.retry.withRetry(ctx, "readSeatPage", func() (err error) {
+			seats, err = s.readSeatPage(ctx, licenseID, licenseDeletePageSize)
+			return err
+		})
+		if err != nil {
+			return released, err
+		}
+		if len(seats) == 0 {
+			return released, nil
+		}
+
+		updates := make([]*v1.RelationshipUpdate, len(seats))
+		for i, seat := range seats {
+			updates[i] = &v1.RelationshipUpdate{Operation: v1.RelationshipUpdate_OPERATION_DELETE, Relationship: seat}
+		}
+		if _, err := s.client.WriteRelationships(ctx, &v1.WriteRelationshipsRequest{Updates: updates}); err != nil {
+			return released, convertSpiceDbError(err)
+		}
+		released += len(seats)
+	}
+}
+
+// readSeatPage reads up to limit seat relationships of the given license, fully consistent. The stream is cancelled once the limit is reached.
+func (s *SpiceDbAccessRepository) readSeatPage(ctx context.Context, licenseID string, limit int) ([]*v1.Relationship, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resp, err := s.client.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{
+		Consistency: &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		RelationshipFilter: &v1.RelationshipFilter{
+			ResourceType:       LicenseSeatObjectType,
+			OptionalResourceId: licenseID,
+			OptionalRelation:   "assigned",
 		},
 	})
 	if err != nil {
-		glog.Errorf("Failed to delete License Version relation :%v", err.Error())
+		return nil, convertSpiceDbError(err)
+	}
+
+	seats := make([]*v1.Relationship, 0)
+	for len(seats) < limit {
+		v, err := resp.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, convertSpiceDbError(err)
+		}
+		seats = append(seats, v.Relationship)
+	}
+
+	return seats, nil
+}
+
+// newLicenseVersion generates a random version string for the license version relationship
+func newLicenseVersion() (string, error) {
+	versionBytes := make([]byte, 4)
+	if _, err := rand.Read(versionBytes); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(versionBytes)), nil
+}
+
+// writeSeatUpdatesWithVersionCount writes the given seat updates together with the replacement of the license version relationship by one with the assigned count changed by delta.
+// The current version relationship is a precondition, so the write fails instead of corrupting the count if the license was modified concurrently.
+// If the count would exceed the max seats of the license, domain.ErrSeatLimitExceeded is returned and nothing is written.
+func (s *SpiceDbAccessRepository) writeSeatUpdatesWithVersionCount(ctx context.Context, orgID, serviceID string, updates []*v1.RelationshipUpdate, preconditions []*v1.Precondition, delta int) error {
+	var currentLicenseVersion string
+	var assignedCount int
+	err := s.retry.withRetry(ctx, "readLicenseVersion", func() (err error) {
+		currentLicenseVersion, assignedCount, err = s.readLicenseVersion(ctx, orgID, serviceID)
+		return err
+	})
+	if err != nil {
+		glog.Errorf("Failed to read License version relation :%v", err.Error())
 		return err
 	}
-	glog.Infof("Deleted license version relation :%v", resp)
+
+	if delta > 0 { //The count read above is guarded by the version precondition, so concurrent assignments cannot oversell the license
+		license, err := s.readLicense(ctx, orgID, serviceID)
+		if err != nil {
+			return err
+		}
+		if assignedCount+delta > license.MaxSeats {
+			return &domain.SeatLimitExceededError{LicenseID: domain.LicenseResourceID(orgID, serviceID), MaxSeats: license.MaxSeats, InUse: assignedCount, Requested: delta}
+		}
+	}
+
+	versionUpdates, versionPrecondition := licenseVersionCountUpdate(orgID, serviceID, currentLicenseVersion, assignedCount, assignedCount+delta)
+	updates = append(updates, versionUpdates...)
+	preconditions = append(preconditions, versionPrecondition)
+
+	result, err := s.client.WriteRelationships(ctx, &v1.WriteRelationshipsRequest{
+		Updates:               updates,
+		OptionalPreconditions: preconditions,
+	})
+	if err != nil {
+		return convertSpiceDbError(err)
+	}
+
+	glog.Infof("Seat update operation :%v", result)
 	return nil
 }
 
-func (s *SpiceDbAccessRepository) writeLicenseVersionRelation(orgID, srvcID, versionStr string, count int) error {
+// licenseVersionCountUpdate returns the updates replacing the license version relationship with the given count by one with the new count,
+// and the precondition that the relationship with the given count still exists, so the write fails if the license was modified concurrently.
+func licenseVersionCountUpdate(orgID, serviceID, version string, count int, newCount int) ([]*v1.RelationshipUpdate, *v1.Precondition) {
+	return licenseVersionUpdate(orgID, serviceID, version, count, version, newCount)
+}
+
+// licenseVersionUpdate works like licenseVersionCountUpdate, but also replaces the version string, to fail concurrent writes that preconditioned on the old one.
+func licenseVersionUpdate(orgID, serviceID, version string, count int, newVersion string, newCount int) ([]*v1.RelationshipUpdate, *v1.Precondition) {
+	oldSubject, object := createSubjectObjectTuple(LicenseVersionStr, fmt.Sprintf("%s/%d", version, count),
+		LicenseObjectType, domain.LicenseResourceID(orgID, serviceID))
+	newSubject, _ := createSubjectObjectTuple(LicenseVersionStr, fmt.Sprintf("%s/%d", newVersion, newCount),
+		LicenseObjectType, domain.LicenseResourceID(orgID, serviceID))
 
-	subject, object := createSubjectObjectTuple(LicenseVersionStr, fmt.Sprintf("%s/%d", versionStr, count),
-		LicenseObjectType, fmt.Sprintf("%s/%s", orgID, srvcID))
-	var relationshipUpdates = []*v1.RelationshipUpdate{
+	updates := []*v1.RelationshipUpdate{
+		{Operation: v1.RelationshipUpdate_OPERATION_DELETE, Relationship: &v1.Relationship{
+			Subject:  oldSubject,
+			Resource: object,
+			Relation: LicenseVersionStr,
+		}},
 		{Operation: v1.RelationshipUpdate_OPERATION_CREATE, Relationship: &v1.Relationship{
-			Subject:  subject,
+			Subject:  newSubject,
 			Resource: object,
 			Relation: LicenseVersionStr,
 		}},
 	}
-	result, err := s.client.WriteRelationships(s.ctx, &v1.WriteRelationshipsRequest{
-		Updates: relationshipUpdates,
+	precondition := &v1.Precondition{
+		Operation: v1.Precondition_OPERATION_MUST_MATCH,
+		Filter: &v1.RelationshipFilter{
+			ResourceType:       LicenseObjectType,
+			OptionalResourceId: object.ObjectId,
+			OptionalRelation:   LicenseVersionStr,
+			OptionalSubjectFilter: &v1.SubjectFilter{
+				SubjectType:       LicenseVersionStr,
+				OptionalSubjectId: oldSubject.Object.ObjectId,
+			},
+		},
+	}
+
+	return updates, precondition
+}
+
+// RecountSeats replaces the assigned count of the license by the number of seat relationships, to repair drift from partial writes or manual edits.
+// The seats are read fully consistent after the count, and the write fails if the license was modified in between.
+func (s *SpiceDbAccessRepository) RecountSeats(ctx context.Context, orgID string, serviceID string) (recorded int, actual int, err error) {
+	var version string
+	err = s.retry.withRetry(ctx, "readLicenseVersion", func() (err error) {
+		version, recorded, err = s.readLicenseVersion(ctx, orgID, serviceID)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	if version == "" {
+		return 0, 0, fmt.Errorf("%w: %s", domain.ErrLicenseNotFound, domain.LicenseResourceID(orgID, serviceID))
+	}
+
+	err = s.retry.withRetry(ctx, "readSeatCount", func() (err error) {
+		actual, err = s.readSeatCount(ctx, orgID, serviceID)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if actual == recorded {
+		return recorded, actual, nil
+	}
+
+	updates, precondition := licenseVersionCountUpdate(orgID, serviceID, version, recorded, actual)
+	_, err = s.client.WriteRelationships(ctx, &v1.WriteRelationshipsRequest{
+		Updates:               updates,
+		OptionalPreconditions: []*v1.Precondition{precondition},
+	})
+	if err != nil {
+		return 0, 0, convertSpiceDbError(err)
+	}
+
+	glog.Warningf("Corrected the assigned seat count of license %s from %d to %d", domain.LicenseResourceID(orgID, serviceID), recorded, actual)
+	return recorded, actual, nil
+}
+
+// SetMaxSeats replaces the max relationship of the license, unless more seats are in use than the new limit allows.
+// The version string is replaced as well, so seat assignments that checked the old limit concurrently fail instead of overselling the license.
+func (s *SpiceDbAccessRepository) SetMaxSeats(ctx context.Context, orgID string, serviceID string, maxSeats int) error {
+	var license *domain.License
+	var version string
+	var inUse int
+	err := s.retry.withRetry(ctx, "readLicenseVersion", func() (err error) {
+		if version, inUse, err = s.readLicenseVersion(ctx, orgID, serviceID); err != nil {
+			return err
+		}
+		license, err = s.readLicense(ctx, orgID, serviceID)
+		return err
 	})
 	if err != nil {
-		glog.Errorf("Failed to create license version relation :%v", err.Error())
 		return err
 	}
-	glog.Infof("License Version create operation :%v", result)
+	licenseID := domain.LicenseResourceID(orgID, serviceID)
+	if version == "" {
+		return fmt.Errorf("%w: %s", domain.ErrLicenseNotFound, licenseID)
+	}
+	if maxSeats < inUse {
+		return &domain.SeatLimitBelowInUseError{LicenseID: licenseID, MaxSeats: maxSeats, InUse: inUse}
+	}
+	if maxSeats == license.MaxSeats {
+		return nil
+	}
+
+	newVersion, err := newLicenseVersion()
+	if err != nil {
+		return err
+	}
+
+	oldMax, object := createSubjectObjectTuple("max", strconv.Itoa(license.MaxSeats), LicenseObjectType, licenseID)
+	newMax, _ := createSubjectObjectTuple("max", strconv.Itoa(maxSeats), LicenseObjectType, licenseID)
+	updates, precondition := licenseVersionUpdate(orgID, serviceID, version, inUse, newVersion, inUse)
+	updates = append(updates,
+		&v1.RelationshipUpdate{Operation: v1.RelationshipUpdate_OPERATION_DELETE, Relationship: &v1.Relationship{
+			Subject:  oldMax,
+			Resource: object,
+			Relation: "max",
+		}},
+		&v1.RelationshipUpdate{Operation: v1.RelationshipUpdate_OPERATION_CREATE, Relationship: &v1.Relationship{
+			Subject:  newMax,
+			Resource: object,
+			Relation: "max",
+		}})
+
+	_, err = s.client.WriteRelationships(ctx, &v1.WriteRelationshipsRequest{
+		Updates:               updates,
+		OptionalPreconditions: []*v1.Precondition{precondition},
+	})
+	if err != nil {
+		return convertSpiceDbError(err)
+	}
+
+	glog.Infof("Changed the max seats of license %s from %d to %d", licenseID, license.MaxSeats, maxSeats)
 	return nil
 }
 
+// readSeatCount counts the seat relationships of the given license, fully consistent
+func (s *SpiceDbAccessRepository) readSeatCount(ctx context.Context, orgID, serviceID string) (int, error) {
+	resp, err := s.client.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{
+		Consistency: &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		RelationshipFilter: &v1.RelationshipFilter{
+			ResourceType:       LicenseSeatObjectType,
+			OptionalResourceId: domain.LicenseResourceID(orgID, serviceID),
+			OptionalRelation:   "assigned",
+		},
+	})
+	if err != nil {
+		return 0, convertSpiceDbError(err)
+	}
+
+	count := 0
+	for {
+		_, err := resp.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, convertSpiceDbError(err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// readLicenseVersion reads the current version string and assigned seat count of the given license
+func (s *SpiceDbAccessRepository) readLicenseVersion(ctx context.Context, orgID, serviceID string) (string, int, error) {
+	resp, err := s.client.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{
+		Consistency: &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		RelationshipFilter: &v1.RelationshipFilter{
+			ResourceType:       LicenseObjectType,
+			OptionalResourceId: domain.LicenseResourceID(orgID, serviceID),
+			OptionalRelation:   LicenseVersionStr,
+		},
+	})
+	if err != nil {
+		return "", 0, convertSpiceDbError(err)
+	}
+
+	var assignedCount int
+	var currentLicenseVersion string
+	for {
+		v, err := resp.Recv()
+		if err != nil && err == io.EOF {
+			break
+		}
+		if err != nil {
+			glog.Errorf("Failed iterate License read response :%v", err.Error())
+			return "", 0, convertSpiceDbError(err)
+		}
+		// The version is of the form: <Versionstring>/currentassignedseatscount
+		glog.Infof("License - Version : %v", v.Relationship.Subject.Object.ObjectId)
+		//spilt with "/" and the second part of the string is the current assigned count
+		versionStrArr := strings.Split(v.Relationship.Subject.Object.ObjectId, "/")
+		if len(versionStrArr) != 2 {
+			return "", 0, fmt.Errorf("invalid license version %s", v.Relationship.Subject.Object.ObjectId)
+		}
+		assignedCount, err = strconv.Atoi(versionStrArr[1])
+		if err != nil {
+			return "", 0, err
+		}
+		currentLicenseVersion = versionStrArr[0]
+	}
+
+	return currentLicenseVersion, assignedCount, nil
+}
+
 // NewConnection creates a new connection to an underlying SpiceDB store and saves it to the package variable conn
 func (s *SpiceDbAccessRepository) NewConnection(spiceDbEndpoint string, token string, isBlocking, useTLS bool) {
+	s.NewConnectionWithKeepalive(spiceDbEndpoint, token, isBlocking, useTLS, DefaultKeepalive)
+}
+
+// NewConnectionWithKeepalive creates a new connection like NewConnection, using the given keepalive parameters.
+// The connection is long-lived and shared by all calls of this repository, so it should be created once and the repository reused.
+func (s *SpiceDbAccessRepository) NewConnectionWithKeepalive(spiceDbEndpoint string, token string, isBlocking, useTLS bool, params keepalive.ClientParameters) {
+	err := s.connect(SpiceDbConfig{
+		Endpoint:     spiceDbEndpoint,
+		PresharedKey: token,
+		UseTLS:       useTLS,
+		IsBlocking:   isBlocking,
+		Keepalive:    params,
+	})
+
+	if err != nil {
+		log.Fatalf("unable to initialize client: %s", err)
+	}
+}
+
+func (s *SpiceDbAccessRepository) connect(config SpiceDbConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	keepaliveParams := config.Keepalive
+	if keepaliveParams == (keepalive.ClientParameters{}) {
+		keepaliveParams = DefaultKeepalive
+	}
 
 	opts := []grpc.DialOption{
-		grpcutil.WithInsecureBearerToken(token),
+		grpc.WithKeepaliveParams(keepaliveParams),
 	}
 
-	if isBlocking {
+	if config.IsBlocking {
 		opts = append(opts, grpc.WithBlock())
 	}
 
-	if !useTLS {
+	if config.RequestTimeout > 0 {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(timeoutUnaryInterceptor(config.RequestTimeout)),
+			grpc.WithChainStreamInterceptor(timeoutStreamInterceptor(config.RequestTimeout)))
+	}
+
+	if !config.UseTLS {
+		opts = append(opts, grpcutil.WithInsecureBearerToken(config.PresharedKey))
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	} else {
-		tlsConfig, _ := grpcutil.WithSystemCerts(grpcutil.VerifyCA)
+		opts = append(opts, grpcutil.WithBearerToken(config.PresharedKey))
+
+		var tlsConfig grpc.DialOption
+		var err error
+		if config.CACertPath != "" {
+			tlsConfig, err = grpcutil.WithCustomCerts(grpcutil.VerifyCA, config.CACertPath)
+		} else {
+			tlsConfig, err = grpcutil.WithSystemCerts(grpcutil.VerifyCA)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load TLS certificates: %w", err)
+		}
 		opts = append(opts, tlsConfig)
 	}
 
 	client, err := authzed.NewClient(
-		spiceDbEndpoint,
+		config.Endpoint,
 		opts...,
 	)
 
 	if err != nil {
-		log.Fatalf("unable to initialize client: %s", err)
+		return err
 	}
 
 	s.client = client
 	s.ctx = context.Background()
+	s.retry = config.Retry
+	if s.retry == (RetryPolicy{}) {
+		s.retry = DefaultRetryPolicy
+	}
+	s.strictChecks = config.StrictChecks
+	s.maxLookupResults = config.MaxLookupResults
+	if s.allowedRelationships, err = newRelationshipAllowlist(config.AllowedRelationships); err != nil {
+		return err
+	}
+	s.maxConcurrentChecks = config.MaxConcurrentChecks
+	if s.maxConcurrentChecks <= 0 {
+		s.maxConcurrentChecks = DefaultMaxConcurrentChecks
+	}
+	return nil
+}
+
+// convertSpiceDbError detects errors caused by a missing or incomplete schema and converts them into domain.ErrSchemaNotInitialized,
+// and errors caused by exceeding the maximum depth of the permission graph into domain.ErrCheckTooComplex. Other errors are returned as they are.
+func convertSpiceDbError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	msg := st.Message()
+	if strings.Contains(strings.ToLower(msg), "max depth exceeded") {
+		return fmt.Errorf("%w: %s", domain.ErrCheckTooComplex, msg)
+	}
+
+	if strings.Contains(msg, "No schema has been defined") || (strings.Contains(msg, "object definition") && strings.Contains(msg, "not found")) {
+		glog.Errorf("SpiceDB schema is missing or incomplete, has it been applied? %s", msg)
+		return fmt.Errorf("%w: %s", domain.ErrSchemaNotInitialized, msg)
+	}
+
+	return err
 }
 
 func createSubjectObjectTuple(subjectType string, subjectValue string, objectType string, objectValue string) (*v1.SubjectReference, *v1.ObjectReference) {
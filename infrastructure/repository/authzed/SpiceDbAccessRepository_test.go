@@ -2,67 +2,31 @@ package authzed
 
 import (
 	"authz/domain"
-	"crypto/rand"
-	"encoding/base64"
+	"context"
 	"fmt"
 	"os"
-	"path"
-	"path/filepath"
-	"runtime"
 	"testing"
 
-	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-var port string
+var container *LocalSpiceDbContainer
 
 func TestMain(m *testing.M) {
-	pool, err := dockertest.NewPool("") // Empty string uses default docker env
+	var err error
+	container, err = CreateSpiceDbContainer()
 	if err != nil {
-		return
+		fmt.Printf("SpiceDB test container not available, skipping integration tests: %s\n", err)
 	}
 
-	var (
-		_, b, _, _ = runtime.Caller(0)
-		basepath   = filepath.Dir(b)
-	)
-
-	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
-		Repository:   "authzed/spicedb",
-		Tag:          "v1.17.0", // Replace this with an actual version
-		Cmd:          []string{"serve-testing", "--load-configs", "/mnt/spicedb_bootstrap.yaml"},
-		Mounts:       []string{path.Join(basepath, "../../../schema/spicedb_bootstrap.yaml") + ":/mnt/spicedb_bootstrap.yaml"},
-		ExposedPorts: []string{"50051/tcp", "50052/tcp"},
-	})
-	if err != nil {
-		return
-	}
-
-	port = resource.GetPort("50051/tcp")
-
 	result := m.Run()
-	_ = pool.Purge(resource)
-
-	os.Exit(result)
-}
-
-// spicedbTestClient creates a new SpiceDB client with random credentials.
-//
-// The test server gives each set of a credentials its own isolated datastore
-// so that tests can be ran in parallel.
-func spicedbTestClient() (*SpiceDbAccessRepository, error) {
-	// Generate a random credential to isolate this client from any others.
-	buf := make([]byte, 20)
-	if _, err := rand.Read(buf); err != nil {
-		return nil, err
+	if container != nil {
+		container.Close()
 	}
-	randomKey := base64.StdEncoding.EncodeToString(buf)
-
-	e := &SpiceDbAccessRepository{}
-	e.NewConnection("localhost:"+port, randomKey, true, false)
 
-	return e, nil
+	os.Exit(result)
 }
 
 func TestCheckAccess(t *testing.T) {
@@ -70,8 +34,7 @@ func TestCheckAccess(t *testing.T) {
 		t.SkipNow()
 	}
 	t.Parallel()
-	client, err := spicedbTestClient()
-	assert.NoError(t, err)
+	client := container.NewClient(t, defaultLicenseFixture())
 
 	cases := []struct {
 		sub       domain.SubjectID
@@ -79,28 +42,126 @@ func TestCheckAccess(t *testing.T) {
 		resource  domain.Resource
 		expected  domain.AccessDecision
 	}{
-		{sub: "u1", operation: "access", resource: domain.Resource{Type: "license", ID: "o1/smarts"}, expected: true},
-		{sub: "u1", operation: "access", resource: domain.Resource{Type: "license", ID: "o1/doesnotexist"}, expected: false},
-		{sub: "doesnotexist", operation: "access", resource: domain.Resource{Type: "license", ID: "o1/smarts"}, expected: false},
+		{sub: "u1", operation: "access", resource: domain.Resource{Type: "license", ID: "o1/smarts"}, expected: domain.NewAccessDecision(true)},
+		{sub: "u1", operation: "access", resource: domain.Resource{Type: "license", ID: "o1/doesnotexist"}, expected: domain.NewAccessDecision(false)},
+		{sub: "doesnotexist", operation: "access", resource: domain.Resource{Type: "license", ID: "o1/smarts"}, expected: domain.NewAccessDecision(false)},
 	}
 
 	for _, testcase := range cases {
-		actual, err := client.CheckAccess(testcase.sub, testcase.operation, testcase.resource)
+		actual, err := client.CheckAccess(context.Background(), testcase.sub, testcase.operation, testcase.resource)
 		assert.NoError(t, err, fmt.Sprintf("Error in case (subject: %s, operation: %s, resource: [%s, %s])", testcase.sub, testcase.operation, testcase.resource.Type, testcase.resource.ID))
 		assert.Equal(t, testcase.expected, actual, "Unexpected result for case (subject: %s, operation: %s, resource: [%s, %s])", testcase.sub, testcase.operation, testcase.resource.Type, testcase.resource.ID)
 	}
 }
 
-func TestGetLicense(t *testing.T) {
+func TestCheckAccessRejectsMalformedLicenseID(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	_, err := client.CheckAccess(context.Background(), "u1", "access", domain.Resource{Type: "license", ID: "o1/smarts/extra"})
+	assert.ErrorIs(t, err, domain.ErrInvalidResourceID)
+}
+
+func TestCheckAccessReportsMissingSchema(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	client := container.NewClientWithoutSchema(t)
+
+	_, err := client.CheckAccess(context.Background(), "u1", "access", domain.Resource{Type: "license", ID: "o1/smarts"})
+	assert.ErrorIs(t, err, domain.ErrSchemaNotInitialized)
+}
+
+func TestCheckAccessBulk(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	events := []domain.CheckEvent{
+		{SubjectID: "u1", Operation: "access", Resource: domain.Resource{Type: "license", ID: "o1/smarts"}},
+		{SubjectID: "u1", Operation: "access", Resource: domain.Resource{Type: "license", ID: "o1/doesnotexist"}},
+		{SubjectID: "doesnotexist", Operation: "access", Resource: domain.Resource{Type: "license", ID: "o1/smarts"}},
+	}
+
+	actual, errs := client.CheckAccessBulk(context.Background(), events)
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Equal(t, []domain.AccessDecision{domain.NewAccessDecision(true), domain.NewAccessDecision(false), domain.NewAccessDecision(false)}, actual)
+}
+
+func TestLookupAccessibleResources(t *testing.T) {
 	if testing.Short() {
 		t.SkipNow()
 	}
 	t.Parallel()
+	client := container.NewClient(t, defaultLicenseFixture())
 
-	client, err := spicedbTestClient()
+	resources, err := client.LookupAccessibleResources(context.Background(), "u1", "access", "license")
 	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.Resource{{Type: "license", ID: "o1/smarts"}}, resources)
+
+	resources, err = client.LookupAccessibleResources(context.Background(), "doesnotexist", "access", "license")
+	assert.NoError(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestLookupAccessibleResourcesFailsWithTooManyResults(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	fixture := append(defaultLicenseFixture(),
+		Relationship{ResourceType: LicenseObjectType, ResourceID: "o1/other", Relation: "seats", SubjectType: LicenseSeatObjectType, SubjectID: "o1/other"},
+		Relationship{ResourceType: LicenseSeatObjectType, ResourceID: "o1/other", Relation: "assigned", SubjectType: SubjectType, SubjectID: "u1"})
+	client := container.NewClient(t, fixture)
+	client.maxLookupResults = 1
+
+	_, err := client.LookupAccessibleResources(context.Background(), "u1", "access", "license")
+
+	assert.ErrorIs(t, err, domain.ErrTooManyResults)
+}
+
+func TestLookupAccessingSubjects(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	subjects, err := client.LookupAccessingSubjects(context.Background(), "access", domain.Resource{Type: "license", ID: "o1/smarts"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.SubjectID{"u1"}, subjects)
+}
+
+func TestLookupAccessingSubjectsFailsWithTooManyResults(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	fixture := append(defaultLicenseFixture(),
+		Relationship{ResourceType: LicenseSeatObjectType, ResourceID: "o1/smarts", Relation: "assigned", SubjectType: SubjectType, SubjectID: "u2"})
+	client := container.NewClient(t, fixture)
+	client.maxLookupResults = 1
+
+	_, err := client.LookupAccessingSubjects(context.Background(), "access", domain.Resource{Type: "license", ID: "o1/smarts"})
+
+	assert.ErrorIs(t, err, domain.ErrTooManyResults)
+}
 
-	lic, err := client.GetLicense("o1", "smarts")
+func TestGetLicense(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
 	assert.NoError(t, err)
 
 	assert.Equal(t, "o1", lic.OrgID)
@@ -115,31 +176,98 @@ func TestGetAssigned(t *testing.T) {
 	}
 	t.Parallel()
 
-	client, err := spicedbTestClient()
-	assert.NoError(t, err)
+	client := container.NewClient(t, defaultLicenseFixture())
 
-	assigned, err := client.GetAssigned("o1", "smarts")
+	assigned, err := client.GetAssigned(context.Background(), "o1", "smarts")
 	assert.NoError(t, err)
 
 	assert.ElementsMatch(t, []domain.SubjectID{"u1"}, assigned)
 }
 
-func TestRapidAssignments(t *testing.T) {
+func TestSeedLicense(t *testing.T) {
 	if testing.Short() {
 		t.SkipNow()
 	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	err := client.SeedLicense("o2", "smarts", 5)
+	assert.NoError(t, err)
+
+	lic, err := client.GetLicense(context.Background(), "o2", "smarts")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "o2", lic.OrgID)
+	assert.Equal(t, "smarts", lic.ServiceID)
+	assert.Equal(t, 5, lic.MaxSeats)
+	assert.Equal(t, 0, lic.InUse)
+}
+
+func TestCreateLicenseFailsForExistingLicense(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
 
+	err := client.CreateLicense(context.Background(), "o1", "smarts", 20)
+	assert.ErrorIs(t, err, domain.ErrLicenseAlreadyExists)
+
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, lic.MaxSeats, "The existing license should not have been modified.")
+}
+
+func TestDeleteLicenseLeavesNoSeats(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
 	t.Parallel()
 
-	client, err := spicedbTestClient()
+	client := container.NewClient(t, defaultLicenseFixture())
+	subjects := make([]domain.SubjectID, 0, licenseDeletePageSize+1)
+	for i := 1; i <= licenseDeletePageSize+1; i++ {
+		subjects = append(subjects, domain.SubjectID(fmt.Sprintf("bulk%d", i)))
+	}
+	assert.NoError(t, client.CreateLicense(context.Background(), "o2", "smarts", len(subjects)))
+	assert.NoError(t, client.AssignSeats(context.Background(), subjects, "o2", domain.Service{ID: "smarts"}))
+
+	released, err := client.DeleteLicense(context.Background(), "o2", "smarts")
+
 	assert.NoError(t, err)
+	assert.Equal(t, len(subjects), released)
+	seats, err := client.readSeatPage(context.Background(), domain.LicenseResourceID("o2", "smarts"), 1)
+	assert.NoError(t, err)
+	assert.Empty(t, seats, "No seat relationships should remain.")
+	_, err = client.GetLicense(context.Background(), "o2", "smarts")
+	assert.ErrorIs(t, err, domain.ErrLicenseNotFound)
+
+	released, err = client.DeleteLicense(context.Background(), "o2", "smarts")
+	assert.NoError(t, err, "Deleting again should not be an error.")
+	assert.Zero(t, released)
+
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lic.InUse, "Other licenses should not have been modified.")
+}
+
+func TestRapidAssignments(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
 
 	for i := 2; i <= 10; i++ {
-		err = client.AssignSeat(domain.SubjectID(fmt.Sprintf("u%d", i)), "o1", domain.Service{ID: "smarts"})
+		err := client.AssignSeat(context.Background(), domain.SubjectID(fmt.Sprintf("u%d", i)), "o1", domain.Service{ID: "smarts"})
 		assert.NoError(t, err)
 	}
 
-	lic, err := client.GetLicense("o1", "smarts")
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
 	assert.NoError(t, err)
 
 	assert.Equal(t, 10, lic.InUse)
@@ -152,22 +280,223 @@ func TestAssignUnassign(t *testing.T) {
 
 	t.Parallel()
 
-	client, err := spicedbTestClient()
-	assert.NoError(t, err)
+	client := container.NewClient(t, defaultLicenseFixture())
 
-	err = client.AssignSeat("u2", "o1", domain.Service{ID: "smarts"})
+	err := client.AssignSeat(context.Background(), "u2", "o1", domain.Service{ID: "smarts"})
 	assert.NoError(t, err)
 
-	lic, err := client.GetLicense("o1", "smarts")
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
 	assert.NoError(t, err)
 
 	assert.Equal(t, 2, lic.InUse)
 
-	err = client.UnAssignSeat("u2", "o1", domain.Service{ID: "smarts"})
+	err = client.UnAssignSeat(context.Background(), "u2", "o1", domain.Service{ID: "smarts"})
+	assert.NoError(t, err)
+
+	lic, err = client.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, lic.InUse)
+}
+
+func TestAssignBatch(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	err := client.AssignSeats(context.Background(), []domain.SubjectID{"u100", "u101"}, "o1", domain.Service{ID: "smarts"})
+	assert.NoError(t, err)
+
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, lic.InUse)
+
+	assigned, err := client.GetAssigned(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.SubjectID{"u1", "u100", "u101"}, assigned)
+}
+
+func TestAssignBatchIsAtomic(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	err := client.AssignSeats(context.Background(), []domain.SubjectID{"u100", "u1"}, "o1", domain.Service{ID: "smarts"}) //u1 is already assigned
+	assert.Error(t, err)
+
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lic.InUse)
+
+	assigned, err := client.GetAssigned(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.SubjectID{"u1"}, assigned, "u100 should not have been assigned.")
+}
+
+func TestUnAssignBatchIsAtomic(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	err := client.UnAssignSeats(context.Background(), []domain.SubjectID{"u1", "u100"}, "o1", domain.Service{ID: "smarts"}) //u100 is not assigned
+	assert.Error(t, err)
+
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lic.InUse)
+
+	assigned, err := client.GetAssigned(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.SubjectID{"u1"}, assigned, "u1 should not have been unassigned.")
+}
+
+func TestGetLicenseReportsMissingLicense(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	_, err := client.GetLicense(context.Background(), "o1", "doesnotexist")
+	assert.ErrorIs(t, err, domain.ErrLicenseNotFound)
+}
+
+func TestListServices(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+	assert.NoError(t, client.SeedLicense("o1", "other", 5))
+	assert.NoError(t, client.SeedLicense("o2", "smarts", 5))
+
+	services, err := client.ListServices(context.Background(), "o1")
 	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.Service{{ID: "smarts"}, {ID: "other"}}, services)
 
-	lic, err = client.GetLicense("o1", "smarts")
+	services, err = client.ListServices(context.Background(), "doesnotexist")
 	assert.NoError(t, err)
+	assert.Empty(t, services)
+}
+
+func TestListAssignedServices(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+	assert.NoError(t, client.SeedLicense("o1", "other", 5))
+	assert.NoError(t, client.SeedLicense("o2", "smarts", 5))
+	assert.NoError(t, client.AssignSeat(context.Background(), "u1", "o2", domain.Service{ID: "smarts"}))
+
+	services, err := client.ListAssignedServices(context.Background(), "o1", "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Service{{ID: "smarts"}}, services)
+
+	services, err = client.ListAssignedServices(context.Background(), "o1", "u2")
+	assert.NoError(t, err)
+	assert.Empty(t, services)
+}
+
+func TestStrictCheckAccessReportsMissingResource(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+	client.strictChecks = true
+
+	_, err := client.CheckAccess(context.Background(), "u1", "access", domain.Resource{Type: LicenseObjectType, ID: "o1/doesnotexist"})
+	assert.ErrorIs(t, err, domain.ErrResourceNotFound)
+
+	decision, err := client.CheckAccess(context.Background(), "doesnotexist", "access", domain.Resource{Type: LicenseObjectType, ID: "o1/smarts"})
+	assert.NoError(t, err)
+	assert.False(t, decision.IsAllowed(), "An unknown subject on an existing resource should have been denied.")
+
+	decision, err = client.CheckAccess(context.Background(), "u1", "access", domain.Resource{Type: LicenseObjectType, ID: "o1/smarts"})
+	assert.NoError(t, err)
+	assert.True(t, decision.IsAllowed())
+}
+
+func TestConvertSpiceDbErrorDetectsMaxDepthExceeded(t *testing.T) {
+	t.Parallel()
+
+	//As returned by SpiceDB for a recursive relation
+	err := convertSpiceDbError(status.Error(codes.ResourceExhausted, "max depth exceeded: this usually indicates a recursive or too deep data dependency"))
+
+	assert.ErrorIs(t, err, domain.ErrCheckTooComplex)
+}
 
+func TestConvertSpiceDbErrorKeepsOtherErrors(t *testing.T) {
+	t.Parallel()
+
+	original := status.Error(codes.Unavailable, "connection refused")
+
+	assert.Equal(t, original, convertSpiceDbError(original))
+}
+
+func TestRecountSeatsCorrectsDriftedCount(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	fixture := defaultLicenseFixture()
+	for i, r := range fixture {
+		if r.Relation == LicenseVersionStr {
+			fixture[i].SubjectID = "141B2939/3" //Only u1 is assigned
+		}
+	}
+	client := container.NewClient(t, fixture)
+
+	recorded, actual, err := client.RecountSeats(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, recorded)
+	assert.Equal(t, 1, actual)
+
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
 	assert.Equal(t, 1, lic.InUse)
+
+	assert.NoError(t, client.AssignSeat(context.Background(), "u2", "o1", domain.Service{ID: "smarts"}), "The corrected version should allow further assignments.")
+}
+
+func TestSetMaxSeats(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	client := container.NewClient(t, defaultLicenseFixture())
+
+	assert.NoError(t, client.SetMaxSeats(context.Background(), "o1", "smarts", 1))
+	err := client.AssignSeat(context.Background(), "u2", "o1", domain.Service{ID: "smarts"})
+	assert.ErrorIs(t, err, domain.ErrSeatLimitExceeded)
+
+	err = client.SetMaxSeats(context.Background(), "o1", "smarts", 0)
+	assert.ErrorIs(t, err, domain.ErrSeatLimitBelowInUse)
+
+	assert.NoError(t, client.SetMaxSeats(context.Background(), "o1", "smarts", 20))
+	lic, err := client.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 20, lic.MaxSeats)
+	assert.Equal(t, 1, lic.InUse)
+
+	assert.NoError(t, client.AssignSeat(context.Background(), "u2", "o1", domain.Service{ID: "smarts"}), "The new version should allow further assignments.")
 }
@@ -0,0 +1,63 @@
+package authzed
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/keepalive"
+)
+
+// SpiceDbConfig includes the settings to connect to a SpiceDB instance
+type SpiceDbConfig struct {
+	Endpoint     string
+	PresharedKey string //sent as bearer token, required with TLS
+	UseTLS       bool
+	CACertPath   string                     //CA certificate to verify the server with, default: system certificates
+	IsBlocking   bool                       //whether to wait for the connection to be established when connecting
+	Keepalive    keepalive.ClientParameters //default: DefaultKeepalive
+	Retry        RetryPolicy                //retries of license reads on transient errors, default: DefaultRetryPolicy
+	// RequestTimeout bounds each call to SpiceDB, also when the caller set no deadline. 0 leaves calls bounded by the caller's deadline only.
+	RequestTimeout time.Duration
+	// MaxConcurrentChecks limits the checks of one bulk check that are in flight at the same time, default: DefaultMaxConcurrentChecks
+	MaxConcurrentChecks int
+	// MaxLookupResults limits the resources or subjects returned by one lookup, lookups matching more fail with domain.ErrTooManyResults, default: DefaultMaxLookupResults
+	MaxLookupResults int
+	// StrictChecks makes permission checks fail with domain.ErrResourceNotFound instead of denying if the resource does not exist. This costs an extra read per check.
+	StrictChecks bool
+	// AllowedRelationships are the kinds of relationships that may be written and deleted directly, as <resource type>#<relation>@<subject type>, ex: group#member@user. Default: none.
+	AllowedRelationships []string
+}
+
+// Validate returns an error if the configuration is incomplete
+func (c SpiceDbConfig) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("spicedb endpoint is required")
+	}
+
+	if c.UseTLS && c.PresharedKey == "" {
+		return errors.New("spicedb preshared key is required when using TLS")
+	}
+
+	if _, err := newRelationshipAllowlist(c.AllowedRelationships); err != nil {
+		return err
+	}
+
+	switch c.Retry.Jitter {
+	case "", JitterNone, JitterFull, JitterEqual:
+	default:
+		return fmt.Errorf("unknown retry jitter strategy %q, expected %q, %q or %q", c.Retry.Jitter, JitterNone, JitterFull, JitterEqual)
+	}
+
+	return nil
+}
+
+// NewSpiceDbAccessRepositoryFromConfig creates a new SpiceDbAccessRepository connected to the configured SpiceDB instance, or returns an error if the configuration is invalid or the connection fails
+func NewSpiceDbAccessRepositoryFromConfig(config SpiceDbConfig) (*SpiceDbAccessRepository, error) {
+	repo := &SpiceDbAccessRepository{}
+	if err := repo.connect(config); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
@@ -0,0 +1,35 @@
+package authzed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpiceDbConfigRequiresPresharedKeyWithTLS(t *testing.T) {
+	_, err := NewSpiceDbAccessRepositoryFromConfig(SpiceDbConfig{Endpoint: "localhost:50051", UseTLS: true})
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "preshared key")
+	}
+}
+
+func TestSpiceDbConfigRequiresEndpoint(t *testing.T) {
+	err := SpiceDbConfig{PresharedKey: "key"}.Validate()
+
+	assert.Error(t, err)
+}
+
+func TestSpiceDbConfigAllowsInsecureWithoutPresharedKey(t *testing.T) {
+	err := SpiceDbConfig{Endpoint: "localhost:50051"}.Validate()
+
+	assert.NoError(t, err)
+}
+
+func TestSpiceDbConfigRejectsUnknownRetryJitter(t *testing.T) {
+	err := SpiceDbConfig{Endpoint: "localhost:50051", Retry: RetryPolicy{Jitter: "random"}}.Validate()
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "jitter")
+	}
+}
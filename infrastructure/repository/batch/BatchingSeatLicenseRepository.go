@@ -0,0 +1,167 @@
+// Package batch implements write batching decorators of repositories
+package batch
+
+import (
+	"authz/domain"
+	"authz/domain/contracts"
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchingSeatLicenseRepository decorates a SeatLicenseRepository, coalescing seat assignments (and unassignments) of the same license that arrive within a short window into one write.
+// Each caller waits for the write of its batch and gets its own result: if the coalesced write fails, ex: because one subject is already assigned or the batch exceeds the seat limit,
+// the requests of the batch are written one by one, so each request is still applied all or nothing and the seat limit is checked as without batching.
+// Reads are not batched.
+type BatchingSeatLicenseRepository struct {
+	inner   contracts.SeatLicenseRepository
+	window  time.Duration
+	pending map[batchKey]*seatBatch
+	lock    sync.Mutex
+}
+
+type batchKey struct {
+	orgID     string
+	serviceID string
+	assign    bool
+}
+
+type seatBatch struct {
+	svc      domain.Service
+	requests []*seatRequest
+}
+
+type seatRequest struct {
+	ctx        context.Context
+	subjectIDs []domain.SubjectID
+	done       chan error
+}
+
+// NewBatchingSeatLicenseRepository constructs a new BatchingSeatLicenseRepository decorating the given repository, which writes seat modifications the given window after the first one of a batch
+func NewBatchingSeatLicenseRepository(inner contracts.SeatLicenseRepository, window time.Duration) *BatchingSeatLicenseRepository {
+	return &BatchingSeatLicenseRepository{
+		inner:   inner,
+		window:  window,
+		pending: map[batchKey]*seatBatch{},
+	}
+}
+
+// AssignSeat assigns the given principal a seat for the given service, batched with other assignments of the license. See AssignSeats.
+func (b *BatchingSeatLicenseRepository) AssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error {
+	return b.AssignSeats(ctx, []domain.SubjectID{subjectID}, orgID, svc)
+}
+
+// AssignSeats assigns the given principals seats for the given service, batched with other assignments of the license. Either all or none of the given principals are assigned.
+func (b *BatchingSeatLicenseRepository) AssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	return b.enqueue(ctx, batchKey{orgID: orgID, serviceID: svc.ID, assign: true}, svc, subjectIDs)
+}
+
+// UnAssignSeat removes the seat assignment for the given principal for the given service, batched with other unassignments of the license. See UnAssignSeats.
+func (b *BatchingSeatLicenseRepository) UnAssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error {
+	return b.UnAssignSeats(ctx, []domain.SubjectID{subjectID}, orgID, svc)
+}
+
+// UnAssignSeats removes the seat assignments for the given principals for the given service, batched with other unassignments of the license. Either all or none of the given assignments are removed.
+func (b *BatchingSeatLicenseRepository) UnAssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	return b.enqueue(ctx, batchKey{orgID: orgID, serviceID: svc.ID, assign: false}, svc, subjectIDs)
+}
+
+// GetLicense retrieves the stored license for the given organization and service, it is not batched
+func (b *BatchingSeatLicenseRepository) GetLicense(ctx context.Context, orgID string, serviceID string) (*domain.License, error) {
+	return b.inner.GetLicense(ctx, orgID, serviceID)
+}
+
+// GetAssigned retrieves the IDs of the subjects assigned seats in the current license, it is not batched
+func (b *BatchingSeatLicenseRepository) GetAssigned(ctx context.Context, orgID string, serviceID string) ([]domain.SubjectID, error) {
+	return b.inner.GetAssigned(ctx, orgID, serviceID)
+}
+
+// RecountSeats replaces the recorded number of seats in use of the license by the number of assigned seats, it is not batched
+func (b *BatchingSeatLicenseRepository) RecountSeats(ctx context.Context, orgID string, serviceID string) (int, int, error) {
+	return b.inner.RecountSeats(ctx, orgID, serviceID)
+}
+
+// CreateLicense creates a license, it is not batched
+func (b *BatchingSeatLicenseRepository) CreateLicense(ctx context.Context, orgID string, serviceID string, maxSeats int) error {
+	return b.inner.CreateLicense(ctx, orgID, serviceID, maxSeats)
+}
+
+// DeleteLicense deletes the license and its seats, it is not batched
+func (b *BatchingSeatLicenseRepository) DeleteLicense(ctx context.Context, orgID string, serviceID string) (int, error) {
+	return b.inner.DeleteLicense(ctx, orgID, serviceID)
+}
+
+// SetMaxSeats replaces the max seats of the license, it is not batched
+func (b *BatchingSeatLicenseRepository) SetMaxSeats(ctx context.Context, orgID string, serviceID string, maxSeats int) error {
+	return b.inner.SetMaxSeats(ctx, orgID, serviceID, maxSeats)
+}
+
+// ListServices retrieves the services the given organization holds a license for, it is not batched
+func (b *BatchingSeatLicenseRepository) ListServices(ctx context.Context, orgID string) ([]domain.Service, error) {
+	return b.inner.ListServices(ctx, orgID)
+}
+
+// ListAssignedServices retrieves the services of the given organization the given subject is assigned a seat for, it is not batched
+func (b *BatchingSeatLicenseRepository) ListAssignedServices(ctx context.Context, orgID string, subjectID domain.SubjectID) ([]domain.Service, error) {
+	return b.inner.ListAssignedServices(ctx, orgID, subjectID)
+}
+
+// enqueue adds the request to the pending batch of the key, starting the batch if there is none, and waits for the result of the request
+func (b *BatchingSeatLicenseRepository) enqueue(ctx context.Context, key batchKey, svc domain.Service, subjectIDs []domain.SubjectID) error {
+	req := &seatRequest{ctx: ctx, subjectIDs: subjectIDs, done: make(chan error, 1)}
+
+	b.lock.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &seatBatch{svc: svc}
+		b.pending[key] = batch
+		time.AfterFunc(b.window, func() { b.flush(key) })
+	}
+	batch.requests = append(batch.requests, req)
+	b.lock.Unlock()
+
+	return <-req.done //Not aborted on ctx.Done(), the request may be part of a write in flight
+}
+
+// flush writes the pending batch of the key and reports the result to each request
+func (b *BatchingSeatLicenseRepository) flush(key batchKey) {
+	b.lock.Lock()
+	batch := b.pending[key]
+	delete(b.pending, key)
+	b.lock.Unlock()
+
+	live := make([]*seatRequest, 0, len(batch.requests))
+	for _, req := range batch.requests {
+		if err := req.ctx.Err(); err != nil { //The caller gave up while waiting for the batch
+			req.done <- err
+			continue
+		}
+		live = append(live, req)
+	}
+
+	if len(live) > 1 {
+		subjectIDs := make([]domain.SubjectID, 0, len(live))
+		for _, req := range live {
+			subjectIDs = append(subjectIDs, req.subjectIDs...)
+		}
+
+		//The batch is shared, so it is not aborted if one of the callers gives up
+		if err := b.write(context.Background(), key, batch.svc, subjectIDs); err == nil {
+			for _, req := range live {
+				req.done <- nil
+			}
+			return
+		}
+	}
+
+	for _, req := range live { //Alone, or the coalesced write failed: find out which requests fail
+		req.done <- b.write(req.ctx, key, batch.svc, req.subjectIDs)
+	}
+}
+
+func (b *BatchingSeatLicenseRepository) write(ctx context.Context, key batchKey, svc domain.Service, subjectIDs []domain.SubjectID) error {
+	if key.assign {
+		return b.inner.AssignSeats(ctx, subjectIDs, key.orgID, svc)
+	}
+	return b.inner.UnAssignSeats(ctx, subjectIDs, key.orgID, svc)
+}
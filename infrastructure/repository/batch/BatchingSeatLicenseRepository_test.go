@@ -0,0 +1,123 @@
+package batch
+
+import (
+	"authz/domain"
+	"authz/infrastructure/repository/mock"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentAssignmentsAreCoalesced(t *testing.T) {
+	inner := &countingRepository{InMemoryAccessRepository: mock.NewInMemoryAccessRepository()}
+	assert.NoError(t, inner.SeedLicense("o1", "smarts", 10))
+	repo := NewBatchingSeatLicenseRepository(inner, 50*time.Millisecond)
+
+	errs := assignConcurrently(repo, "u1", "u2", "u3", "u4", "u5")
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), inner.writes.Load())
+	lic, err := repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, lic.InUse)
+}
+
+func TestFailedBatchReportsResultPerRequest(t *testing.T) {
+	inner := &countingRepository{InMemoryAccessRepository: mock.NewInMemoryAccessRepository()}
+	assert.NoError(t, inner.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, inner.AssignSeat(context.Background(), "u2", "o1", domain.Service{ID: "smarts"}))
+	repo := NewBatchingSeatLicenseRepository(inner, 50*time.Millisecond)
+
+	errs := assignConcurrently(repo, "u1", "u2", "u3")
+
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1], "Should have failed, u2 is already assigned.")
+	assert.NoError(t, errs[2])
+	assigned, err := repo.GetAssigned(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.SubjectID{"u1", "u2", "u3"}, assigned)
+}
+
+func TestBatchKeepsSeatLimit(t *testing.T) {
+	inner := &countingRepository{InMemoryAccessRepository: mock.NewInMemoryAccessRepository()}
+	assert.NoError(t, inner.SeedLicense("o1", "smarts", 2))
+	repo := NewBatchingSeatLicenseRepository(inner, 50*time.Millisecond)
+
+	errs := assignConcurrently(repo, "u1", "u2", "u3")
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			assert.ErrorIs(t, err, domain.ErrSeatLimitExceeded)
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+	lic, err := repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lic.InUse)
+}
+
+func TestUnassignmentsAreBatchedSeparately(t *testing.T) {
+	inner := &countingRepository{InMemoryAccessRepository: mock.NewInMemoryAccessRepository()}
+	assert.NoError(t, inner.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, inner.AssignSeat(context.Background(), "u1", "o1", domain.Service{ID: "smarts"}))
+	repo := NewBatchingSeatLicenseRepository(inner, 50*time.Millisecond)
+
+	wait := sync.WaitGroup{}
+	wait.Add(2)
+	var assignErr, unassignErr error
+	go func() {
+		defer wait.Done()
+		assignErr = repo.AssignSeat(context.Background(), "u2", "o1", domain.Service{ID: "smarts"})
+	}()
+	go func() {
+		defer wait.Done()
+		unassignErr = repo.UnAssignSeat(context.Background(), "u1", "o1", domain.Service{ID: "smarts"})
+	}()
+	wait.Wait()
+
+	assert.NoError(t, assignErr)
+	assert.NoError(t, unassignErr)
+	assert.Equal(t, int32(2), inner.writes.Load())
+	assigned, err := repo.GetAssigned(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.SubjectID{"u2"}, assigned)
+}
+
+// assignConcurrently assigns each subject a seat of o1/smarts in its own request, started in the given order, and returns the results in the same order
+func assignConcurrently(repo *BatchingSeatLicenseRepository, subjects ...domain.SubjectID) []error {
+	errs := make([]error, len(subjects))
+	wait := sync.WaitGroup{}
+	for i, subject := range subjects {
+		wait.Add(1)
+		go func(i int, subject domain.SubjectID) {
+			defer wait.Done()
+			errs[i] = repo.AssignSeat(context.Background(), subject, "o1", domain.Service{ID: "smarts"})
+		}(i, subject)
+		time.Sleep(time.Millisecond) //Keeps the order of the requests in the batch
+	}
+	wait.Wait()
+	return errs
+}
+
+type countingRepository struct {
+	*mock.InMemoryAccessRepository
+	writes atomic.Int32
+}
+
+func (r *countingRepository) AssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	r.writes.Add(1)
+	return r.InMemoryAccessRepository.AssignSeats(ctx, subjectIDs, orgID, svc)
+}
+
+func (r *countingRepository) UnAssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	r.writes.Add(1)
+	return r.InMemoryAccessRepository.UnAssignSeats(ctx, subjectIDs, orgID, svc)
+}
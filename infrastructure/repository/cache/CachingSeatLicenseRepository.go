@@ -0,0 +1,158 @@
+// Package cache implements caching decorators of repositories
+package cache
+
+import (
+	"authz/domain"
+	"authz/domain/contracts"
+	"context"
+	"sync"
+	"time"
+)
+
+// CachingSeatLicenseRepository decorates a SeatLicenseRepository, caching GetLicense results for a fixed duration.
+// Seat assignments and unassignments through this repository invalidate the license they modify, so the InUse count is never stale after a write through it.
+// Writes by other instances are only seen after the TTL expires.
+type CachingSeatLicenseRepository struct {
+	inner    contracts.SeatLicenseRepository
+	ttl      time.Duration
+	licenses map[string]cachedLicense
+	reads    map[string]*pendingReads //reads in flight by license, to detect writes racing them. Dropped once none is left.
+	lock     sync.Mutex
+	now      func() time.Time
+}
+
+type cachedLicense struct {
+	license domain.License
+	expires time.Time
+}
+
+type pendingReads struct {
+	count  int
+	writes uint64 //number of invalidations while reads were in flight
+}
+
+// NewCachingSeatLicenseRepository constructs a new CachingSeatLicenseRepository decorating the given repository
+func NewCachingSeatLicenseRepository(inner contracts.SeatLicenseRepository, ttl time.Duration) *CachingSeatLicenseRepository {
+	return &CachingSeatLicenseRepository{
+		inner:    inner,
+		ttl:      ttl,
+		licenses: map[string]cachedLicense{},
+		reads:    map[string]*pendingReads{},
+		now:      time.Now,
+	}
+}
+
+// GetLicense returns the cached license for the given organization and service, or retrieves and caches it
+func (c *CachingSeatLicenseRepository) GetLicense(ctx context.Context, orgID string, serviceID string) (*domain.License, error) {
+	key := domain.LicenseResourceID(orgID, serviceID)
+
+	c.lock.Lock()
+	entry, ok := c.licenses[key]
+	if ok && c.now().Before(entry.expires) {
+		c.lock.Unlock()
+		lic := entry.license
+		return &lic, nil
+	}
+
+	pending, ok := c.reads[key]
+	if !ok {
+		pending = &pendingReads{}
+		c.reads[key] = pending
+	}
+	pending.count++
+	writes := pending.writes
+	c.lock.Unlock()
+
+	lic, err := c.inner.GetLicense(ctx, orgID, serviceID)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if pending.count--; pending.count == 0 {
+		delete(c.reads, key)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pending.writes == writes { //Otherwise the license was modified while reading, and lic may be stale
+		c.licenses[key] = cachedLicense{license: *lic, expires: c.now().Add(c.ttl)}
+	}
+
+	return lic, nil
+}
+
+// GetAssigned retrieves the IDs of the subjects assigned seats in the current license, it is not cached
+func (c *CachingSeatLicenseRepository) GetAssigned(ctx context.Context, orgID string, serviceID string) ([]domain.SubjectID, error) {
+	return c.inner.GetAssigned(ctx, orgID, serviceID)
+}
+
+// RecountSeats replaces the recorded number of seats in use of the license by the number of assigned seats and invalidates the cached license
+func (c *CachingSeatLicenseRepository) RecountSeats(ctx context.Context, orgID string, serviceID string) (int, int, error) {
+	defer c.invalidate(orgID, serviceID)
+	return c.inner.RecountSeats(ctx, orgID, serviceID)
+}
+
+// CreateLicense creates a license, there is nothing to invalidate as missing licenses are not cached
+func (c *CachingSeatLicenseRepository) CreateLicense(ctx context.Context, orgID string, serviceID string, maxSeats int) error {
+	return c.inner.CreateLicense(ctx, orgID, serviceID, maxSeats)
+}
+
+// DeleteLicense deletes the license and its seats, and invalidates the cached license
+func (c *CachingSeatLicenseRepository) DeleteLicense(ctx context.Context, orgID string, serviceID string) (int, error) {
+	defer c.invalidate(orgID, serviceID)
+	return c.inner.DeleteLicense(ctx, orgID, serviceID)
+}
+
+// SetMaxSeats replaces the max seats of the license and invalidates the cached license
+func (c *CachingSeatLicenseRepository) SetMaxSeats(ctx context.Context, orgID string, serviceID string, maxSeats int) error {
+	defer c.invalidate(orgID, serviceID)
+	return c.inner.SetMaxSeats(ctx, orgID, serviceID, maxSeats)
+}
+
+// ListServices retrieves the services the given organization holds a license for, it is not cached
+func (c *CachingSeatLicenseRepository) ListServices(ctx context.Context, orgID string) ([]domain.Service, error) {
+	return c.inner.ListServices(ctx, orgID)
+}
+
+// ListAssignedServices retrieves the services of the given organization the given subject is assigned a seat for, it is not cached
+func (c *CachingSeatLicenseRepository) ListAssignedServices(ctx context.Context, orgID string, subjectID domain.SubjectID) ([]domain.Service, error) {
+	return c.inner.ListAssignedServices(ctx, orgID, subjectID)
+}
+
+// AssignSeat assigns the given principal a seat for the given service and invalidates the cached license
+func (c *CachingSeatLicenseRepository) AssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error {
+	defer c.invalidate(orgID, svc.ID)
+	return c.inner.AssignSeat(ctx, subjectID, orgID, svc)
+}
+
+// UnAssignSeat removes the seat assignment for the given principal for the given service and invalidates the cached license
+func (c *CachingSeatLicenseRepository) UnAssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error {
+	defer c.invalidate(orgID, svc.ID)
+	return c.inner.UnAssignSeat(ctx, subjectID, orgID, svc)
+}
+
+// AssignSeats assigns the given principals seats for the given service and invalidates the cached license
+func (c *CachingSeatLicenseRepository) AssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	defer c.invalidate(orgID, svc.ID)
+	return c.inner.AssignSeats(ctx, subjectIDs, orgID, svc)
+}
+
+// UnAssignSeats removes the seat assignments for the given principals for the given service and invalidates the cached license
+func (c *CachingSeatLicenseRepository) UnAssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	defer c.invalidate(orgID, svc.ID)
+	return c.inner.UnAssignSeats(ctx, subjectIDs, orgID, svc)
+}
+
+// invalidate drops the cached license. It runs after the write, also on error, as the write may have been applied anyway.
+func (c *CachingSeatLicenseRepository) invalidate(orgID string, serviceID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := domain.LicenseResourceID(orgID, serviceID)
+	delete(c.licenses, key)
+	if pending, ok := c.reads[key]; ok {
+		pending.writes++
+	}
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"authz/domain"
+	"authz/domain/contracts"
+	"authz/infrastructure/repository/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLicenseIsCachedUntilExpired(t *testing.T) {
+	inner := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, inner.SeedLicense("o1", "smarts", 10))
+	now := time.Now()
+	repo := NewCachingSeatLicenseRepository(inner, time.Second)
+	repo.now = func() time.Time { return now }
+
+	_, err := repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+
+	assert.NoError(t, inner.AssignSeat(context.Background(), "u1", "o1", domain.Service{ID: "smarts"})) //Bypasses the cache, ex: another instance
+
+	lic, err := repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, lic.InUse, "Should have been cached.")
+
+	now = now.Add(time.Second)
+	lic, err = repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lic.InUse, "Should have expired.")
+}
+
+func TestSeatModificationsInvalidateLicense(t *testing.T) {
+	inner := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, inner.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, inner.SeedLicense("o1", "other", 10))
+	repo := NewCachingSeatLicenseRepository(inner, time.Hour)
+
+	_, err := repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	_, err = repo.GetLicense(context.Background(), "o1", "other")
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.AssignSeats(context.Background(), []domain.SubjectID{"u1", "u2"}, "o1", domain.Service{ID: "smarts"}))
+	assert.NoError(t, inner.AssignSeat(context.Background(), "u1", "o1", domain.Service{ID: "other"}))
+
+	lic, err := repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lic.InUse, "Should have been invalidated.")
+
+	lic, err = repo.GetLicense(context.Background(), "o1", "other")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, lic.InUse, "Should not have been invalidated.")
+
+	assert.NoError(t, repo.UnAssignSeat(context.Background(), "u1", "o1", domain.Service{ID: "smarts"}))
+
+	lic, err = repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lic.InUse, "Should have been invalidated.")
+}
+
+func TestWriteDuringReadIsNotCachedAndReadsAreForgotten(t *testing.T) {
+	inner := mock.NewInMemoryAccessRepository()
+	assert.NoError(t, inner.SeedLicense("o1", "smarts", 10))
+	var repo *CachingSeatLicenseRepository
+	racing := &racingSeatLicenseRepository{SeatLicenseRepository: inner, beforeRead: func() {
+		assert.NoError(t, repo.AssignSeat(context.Background(), "u1", "o1", domain.Service{ID: "smarts"}))
+	}}
+	repo = NewCachingSeatLicenseRepository(racing, time.Hour)
+
+	_, err := repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Empty(t, repo.licenses, "Should not have cached the license read during a write.")
+	assert.Empty(t, repo.reads, "Should have forgotten the read once done.")
+
+	racing.beforeRead = nil
+	_, err = repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Len(t, repo.licenses, 1)
+	assert.Empty(t, repo.reads)
+}
+
+// racingSeatLicenseRepository calls beforeRead, if set, before reading a license, ex: to write concurrently
+type racingSeatLicenseRepository struct {
+	contracts.SeatLicenseRepository
+	beforeRead func()
+}
+
+func (r *racingSeatLicenseRepository) GetLicense(ctx context.Context, orgID string, serviceID string) (*domain.License, error) {
+	if r.beforeRead != nil {
+		r.beforeRead()
+	}
+	return r.SeatLicenseRepository.GetLicense(ctx, orgID, serviceID)
+}
@@ -0,0 +1,312 @@
+package mock
+
+import (
+	"authz/domain"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// InMemoryAccessRepository is an in-memory authorization system with the same licensing semantics as the SpiceDB implementation, for tests and local development without a SpiceDB instance.
+// Licenses are seeded with SeedLicense. Subjects can "access" a license if they are assigned a seat on it, and perform operations on organizations granted with GrantOrgOperation. All other checks are denied.
+type InMemoryAccessRepository struct {
+	licenses  map[string]int                           //max seats by license resource ID
+	seats     map[string]map[domain.SubjectID]struct{} //assigned subjects by license resource ID
+	orgGrants map[string]map[domain.SubjectID]struct{} //allowed subjects by <org ID>#<operation>
+	lock      sync.RWMutex
+}
+
+// NewInMemoryAccessRepository constructs a new, empty InMemoryAccessRepository
+func NewInMemoryAccessRepository() *InMemoryAccessRepository {
+	return &InMemoryAccessRepository{
+		licenses:  map[string]int{},
+		seats:     map[string]map[domain.SubjectID]struct{}{},
+		orgGrants: map[string]map[domain.SubjectID]struct{}{},
+	}
+}
+
+// NewConnection is not used, there is nothing to connect to
+func (r *InMemoryAccessRepository) NewConnection(_ string, _ string, _ bool, _ bool) {
+	// NOT USED IN MEMORY
+}
+
+// SeedLicense creates a new license with the given max seats and no assigned seats for the given org and service
+func (r *InMemoryAccessRepository) SeedLicense(orgID string, serviceID string, maxSeats int) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := domain.LicenseResourceID(orgID, serviceID)
+	r.licenses[id] = maxSeats
+	r.seats[id] = map[domain.SubjectID]struct{}{}
+	return nil
+}
+
+// GrantOrgOperation allows the subjects to perform the operation on the organization, ex: to manage its licenses
+func (r *InMemoryAccessRepository) GrantOrgOperation(orgID string, operation string, subjects ...domain.SubjectID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := orgGrantKey(orgID, operation)
+	if r.orgGrants[key] == nil {
+		r.orgGrants[key] = map[domain.SubjectID]struct{}{}
+	}
+	for _, subject := range subjects {
+		r.orgGrants[key][subject] = struct{}{}
+	}
+}
+
+// CheckAccess returns whether the subject can perform the operation on the resource. The "access" operation on licenses is allowed if the subject is assigned a seat, operations on organizations if they were granted.
+func (r *InMemoryAccessRepository) CheckAccess(_ context.Context, subjectID domain.SubjectID, operation string, resource domain.Resource) (domain.AccessDecision, error) {
+	if resource.Type == "license" {
+		if _, _, err := domain.ParseLicenseResourceID(resource.ID); err != nil {
+			return domain.AccessDecision{}, err
+		}
+	}
+
+	if resource.Type == "organization" {
+		r.lock.RLock()
+		defer r.lock.RUnlock()
+
+		_, granted := r.orgGrants[orgGrantKey(resource.ID, operation)][subjectID]
+		return domain.NewAccessDecision(granted), nil
+	}
+
+	if resource.Type != "license" || operation != "access" {
+		return domain.NewAccessDecision(false), nil
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	_, assigned := r.seats[resource.ID][subjectID]
+	return domain.NewAccessDecision(assigned), nil
+}
+
+// CheckAccessBulk checks each event one by one using CheckAccess and returns the decisions and errors in the same order.
+func (r *InMemoryAccessRepository) CheckAccessBulk(ctx context.Context, events []domain.CheckEvent) ([]domain.AccessDecision, []error) {
+	results := make([]domain.AccessDecision, len(events))
+	errs := make([]error, len(events))
+	for i, evt := range events {
+		results[i], errs[i] = r.CheckAccess(ctx, evt.SubjectID, evt.Operation, evt.Resource)
+	}
+
+	return results, errs
+}
+
+// LookupAccessibleResources returns the licenses the subject is assigned a seat on, if the operation is "access". See CheckAccess.
+func (r *InMemoryAccessRepository) LookupAccessibleResources(_ context.Context, subjectID domain.SubjectID, operation string, resourceType string) ([]domain.Resource, error) {
+	resources := make([]domain.Resource, 0)
+	if resourceType != "license" || operation != "access" {
+		return resources, nil
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for id, assignments := range r.seats {
+		if _, ok := assignments[subjectID]; ok {
+			resources = append(resources, domain.Resource{Type: "license", ID: id})
+		}
+	}
+
+	return resources, nil
+}
+
+// LookupAccessingSubjects returns the subjects assigned a seat on the license, if the operation is "access". See CheckAccess.
+func (r *InMemoryAccessRepository) LookupAccessingSubjects(_ context.Context, operation string, resource domain.Resource) ([]domain.SubjectID, error) {
+	subjects := make([]domain.SubjectID, 0)
+	if resource.Type != "license" || operation != "access" {
+		return subjects, nil
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for subjectID := range r.seats[resource.ID] {
+		subjects = append(subjects, subjectID)
+	}
+
+	return subjects, nil
+}
+
+// GetLicense retrieves the stored license for the given organization and service. domain.ErrLicenseNotFound is returned if it was not seeded.
+func (r *InMemoryAccessRepository) GetLicense(_ context.Context, orgID string, serviceID string) (*domain.License, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	id := domain.LicenseResourceID(orgID, serviceID)
+	maxSeats, ok := r.licenses[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrLicenseNotFound, id)
+	}
+	return domain.NewLicense(orgID, serviceID, maxSeats, len(r.seats[id])), nil
+}
+
+// GetAssigned retrieves the IDs of the subjects assigned seats in the current license
+func (r *InMemoryAccessRepository) GetAssigned(_ context.Context, orgID string, serviceID string) ([]domain.SubjectID, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	subjects := make([]domain.SubjectID, 0)
+	for subjectID := range r.seats[domain.LicenseResourceID(orgID, serviceID)] {
+		subjects = append(subjects, subjectID)
+	}
+
+	return subjects, nil
+}
+
+// RecountSeats returns the number of assigned seats twice, the in-memory repository derives the seats in use from the assignments and cannot drift
+func (r *InMemoryAccessRepository) RecountSeats(_ context.Context, orgID string, serviceID string) (int, int, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	id := domain.LicenseResourceID(orgID, serviceID)
+	if _, ok := r.licenses[id]; !ok {
+		return 0, 0, fmt.Errorf("%w: %s", domain.ErrLicenseNotFound, id)
+	}
+
+	return len(r.seats[id]), len(r.seats[id]), nil
+}
+
+// CreateLicense creates a new license like SeedLicense, unless the license was already created or seeded
+func (r *InMemoryAccessRepository) CreateLicense(_ context.Context, orgID string, serviceID string, maxSeats int) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := domain.LicenseResourceID(orgID, serviceID)
+	if _, ok := r.licenses[id]; ok {
+		return fmt.Errorf("%w: %s", domain.ErrLicenseAlreadyExists, id)
+	}
+
+	r.licenses[id] = maxSeats
+	r.seats[id] = map[domain.SubjectID]struct{}{}
+	return nil
+}
+
+// DeleteLicense deletes the license and its seats, and returns the number of seats released
+func (r *InMemoryAccessRepository) DeleteLicense(_ context.Context, orgID string, serviceID string) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := domain.LicenseResourceID(orgID, serviceID)
+	released := len(r.seats[id])
+	delete(r.licenses, id)
+	delete(r.seats, id)
+	return released, nil
+}
+
+// SetMaxSeats replaces the max seats of the seeded license, unless more seats are assigned than the new limit allows
+func (r *InMemoryAccessRepository) SetMaxSeats(_ context.Context, orgID string, serviceID string, maxSeats int) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := domain.LicenseResourceID(orgID, serviceID)
+	if _, ok := r.licenses[id]; !ok {
+		return fmt.Errorf("%w: %s", domain.ErrLicenseNotFound, id)
+	}
+	if inUse := len(r.seats[id]); maxSeats < inUse {
+		return &domain.SeatLimitBelowInUseError{LicenseID: id, MaxSeats: maxSeats, InUse: inUse}
+	}
+
+	r.licenses[id] = maxSeats
+	return nil
+}
+
+// ListServices retrieves the services the given organization holds a seeded license for
+func (r *InMemoryAccessRepository) ListServices(_ context.Context, orgID string) ([]domain.Service, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	services := make([]domain.Service, 0)
+	for id := range r.licenses {
+		licenseOrgID, serviceID, err := domain.ParseLicenseResourceID(id)
+		if err == nil && licenseOrgID == orgID {
+			services = append(services, domain.Service{ID: serviceID})
+		}
+	}
+
+	return services, nil
+}
+
+// ListAssignedServices retrieves the services of the given organization the given subject is assigned a seat for
+func (r *InMemoryAccessRepository) ListAssignedServices(_ context.Context, orgID string, subjectID domain.SubjectID) ([]domain.Service, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	services := make([]domain.Service, 0)
+	for id, assignments := range r.seats {
+		licenseOrgID, serviceID, err := domain.ParseLicenseResourceID(id)
+		if err != nil || licenseOrgID != orgID {
+			continue
+		}
+		if _, assigned := assignments[subjectID]; assigned {
+			services = append(services, domain.Service{ID: serviceID})
+		}
+	}
+
+	return services, nil
+}
+
+// AssignSeat assigns the given principal a seat for the given service. See AssignSeats.
+func (r *InMemoryAccessRepository) AssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error {
+	return r.AssignSeats(ctx, []domain.SubjectID{subjectID}, orgID, svc)
+}
+
+// AssignSeats assigns the given principals seats for the given service. If any of them is already assigned or the seats would exceed the license's max seats, an error is returned and none are assigned.
+func (r *InMemoryAccessRepository) AssignSeats(_ context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := domain.LicenseResourceID(orgID, svc.ID)
+	assignments, ok := r.seats[id]
+	if !ok {
+		return fmt.Errorf("%w: license %s does not exist", domain.ErrInvalidRequest, id)
+	}
+
+	batch := make(map[domain.SubjectID]struct{}, len(subjectIDs))
+	for _, subjectID := range subjectIDs {
+		_, assigned := assignments[subjectID]
+		_, duplicate := batch[subjectID]
+		if assigned || duplicate {
+			return fmt.Errorf("%w: subject %s is already assigned a seat on license %s", domain.ErrInvalidRequest, subjectID, id)
+		}
+		batch[subjectID] = struct{}{}
+	}
+
+	if len(assignments)+len(batch) > r.licenses[id] {
+		return &domain.SeatLimitExceededError{LicenseID: id, MaxSeats: r.licenses[id], InUse: len(assignments), Requested: len(batch)}
+	}
+
+	for subjectID := range batch {
+		assignments[subjectID] = struct{}{}
+	}
+	return nil
+}
+
+// UnAssignSeat removes the seat assignment for the given principal for the given service. See UnAssignSeats.
+func (r *InMemoryAccessRepository) UnAssignSeat(ctx context.Context, subjectID domain.SubjectID, orgID string, svc domain.Service) error {
+	return r.UnAssignSeats(ctx, []domain.SubjectID{subjectID}, orgID, svc)
+}
+
+// UnAssignSeats removes the seat assignments for the given principals for the given service. If any of them is not assigned, an error is returned and none are removed.
+func (r *InMemoryAccessRepository) UnAssignSeats(_ context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := domain.LicenseResourceID(orgID, svc.ID)
+	assignments := r.seats[id]
+	for _, subjectID := range subjectIDs {
+		if _, assigned := assignments[subjectID]; !assigned {
+			return fmt.Errorf("%w: subject %s is not assigned a seat on license %s", domain.ErrInvalidRequest, subjectID, id)
+		}
+	}
+
+	for _, subjectID := range subjectIDs {
+		delete(assignments, subjectID)
+	}
+	return nil
+}
+
+func orgGrantKey(orgID string, operation string) string {
+	return orgID + "#" + operation
+}
@@ -0,0 +1,140 @@
+package mock
+
+import (
+	"authz/domain"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryCheckAccess(t *testing.T) {
+	repo := seededInMemoryAccessRepository(t)
+
+	cases := []struct {
+		sub      domain.SubjectID
+		resource domain.Resource
+		expected bool
+	}{
+		{sub: "u1", resource: domain.Resource{Type: "license", ID: "o1/smarts"}, expected: true},
+		{sub: "u1", resource: domain.Resource{Type: "license", ID: "o1/doesnotexist"}, expected: false},
+		{sub: "doesnotexist", resource: domain.Resource{Type: "license", ID: "o1/smarts"}, expected: false},
+	}
+
+	for _, testcase := range cases {
+		actual, err := repo.CheckAccess(context.Background(), testcase.sub, "access", testcase.resource)
+		assert.NoError(t, err)
+		assert.Equal(t, testcase.expected, actual.IsAllowed(), "Unexpected result for %s on %s", testcase.sub, testcase.resource.ID)
+	}
+
+	_, err := repo.CheckAccess(context.Background(), "u1", "access", domain.Resource{Type: "license", ID: "o1/smarts/extra"})
+	assert.ErrorIs(t, err, domain.ErrInvalidResourceID)
+}
+
+func TestInMemoryCheckAccessOnOrganization(t *testing.T) {
+	repo := seededInMemoryAccessRepository(t)
+	repo.GrantOrgOperation("o1", "administer_licenses", "admin")
+
+	cases := []struct {
+		sub       domain.SubjectID
+		operation string
+		orgID     string
+		expected  bool
+	}{
+		{sub: "admin", operation: "administer_licenses", orgID: "o1", expected: true},
+		{sub: "admin", operation: "manage_licenses", orgID: "o1", expected: false},
+		{sub: "admin", operation: "administer_licenses", orgID: "o2", expected: false},
+		{sub: "u1", operation: "administer_licenses", orgID: "o1", expected: false},
+	}
+
+	for _, testcase := range cases {
+		actual, err := repo.CheckAccess(context.Background(), testcase.sub, testcase.operation, domain.Organization{ID: testcase.orgID}.AsResource())
+		assert.NoError(t, err)
+		assert.Equal(t, testcase.expected, actual.IsAllowed(), "Unexpected result for %s to %s on %s", testcase.sub, testcase.operation, testcase.orgID)
+	}
+}
+
+func TestInMemoryGetLicense(t *testing.T) {
+	repo := seededInMemoryAccessRepository(t)
+
+	lic, err := repo.GetLicense(context.Background(), "o1", "smarts")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewLicense("o1", "smarts", 10, 1), lic)
+}
+
+func TestInMemoryAssignBatchIsAtomic(t *testing.T) {
+	repo := seededInMemoryAccessRepository(t)
+
+	err := repo.AssignSeats(context.Background(), []domain.SubjectID{"u100", "u1"}, "o1", domain.Service{ID: "smarts"}) //u1 is already assigned
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+
+	assigned, err := repo.GetAssigned(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.SubjectID{"u1"}, assigned, "u100 should not have been assigned.")
+}
+
+func TestInMemoryUnAssignBatchIsAtomic(t *testing.T) {
+	repo := seededInMemoryAccessRepository(t)
+
+	err := repo.UnAssignSeats(context.Background(), []domain.SubjectID{"u1", "u100"}, "o1", domain.Service{ID: "smarts"}) //u100 is not assigned
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+
+	assigned, err := repo.GetAssigned(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.SubjectID{"u1"}, assigned, "u1 should not have been unassigned.")
+}
+
+func TestInMemoryAssignUnassign(t *testing.T) {
+	repo := seededInMemoryAccessRepository(t)
+
+	err := repo.AssignSeat(context.Background(), "u2", "o1", domain.Service{ID: "smarts"})
+	assert.NoError(t, err)
+
+	lic, err := repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lic.InUse)
+
+	err = repo.UnAssignSeat(context.Background(), "u2", "o1", domain.Service{ID: "smarts"})
+	assert.NoError(t, err)
+
+	lic, err = repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lic.InUse)
+}
+
+func seededInMemoryAccessRepository(t *testing.T) *InMemoryAccessRepository {
+	repo := NewInMemoryAccessRepository()
+	assert.NoError(t, repo.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, repo.AssignSeat(context.Background(), "u1", "o1", domain.Service{ID: "smarts"}))
+	return repo
+}
+
+func TestInMemoryListServicesOnlyListsTheOrgsLicenses(t *testing.T) {
+	repo := NewInMemoryAccessRepository()
+	assert.NoError(t, repo.SeedLicense("o1", "smarts", 10))
+	assert.NoError(t, repo.SeedLicense("o1", "other", 10))
+	assert.NoError(t, repo.SeedLicense("o2", "smarts", 10))
+
+	services, err := repo.ListServices(context.Background(), "o1")
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []domain.Service{{ID: "smarts"}, {ID: "other"}}, services)
+}
+
+func TestInMemoryAssignSeatsRespectsTheSeatLimit(t *testing.T) {
+	repo := NewInMemoryAccessRepository()
+	assert.NoError(t, repo.SeedLicense("o1", "smarts", 2))
+	assert.NoError(t, repo.AssignSeat(context.Background(), "u1", "o1", domain.Service{ID: "smarts"}))
+
+	err := repo.AssignSeats(context.Background(), []domain.SubjectID{"u2", "u3"}, "o1", domain.Service{ID: "smarts"})
+
+	assert.ErrorIs(t, err, domain.ErrSeatLimitExceeded)
+	var exceeded *domain.SeatLimitExceededError
+	if assert.ErrorAs(t, err, &exceeded) {
+		assert.Equal(t, domain.SeatLimitExceededError{LicenseID: "o1/smarts", MaxSeats: 2, InUse: 1, Requested: 2}, *exceeded)
+	}
+	lic, err := repo.GetLicense(context.Background(), "o1", "smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lic.InUse, "No seat should have been assigned.")
+}
@@ -3,6 +3,8 @@ package mock
 
 import (
 	"authz/domain"
+	"context"
+	"fmt"
 )
 
 // StubAccessRepository represents an in-memory authorization system with a fixed state
@@ -19,19 +21,62 @@ func (s *StubAccessRepository) NewConnection(_ string, _ string, _ bool, _ bool)
 }
 
 // CheckAccess returns true if the subject has been specified to have access, otherwise false.
-func (s *StubAccessRepository) CheckAccess(subjectID domain.SubjectID, operation string, resource domain.Resource) (domain.AccessDecision, error) {
+func (s *StubAccessRepository) CheckAccess(_ context.Context, subjectID domain.SubjectID, operation string, resource domain.Resource) (domain.AccessDecision, error) {
 	if authz, ok := s.Data[subjectID]; ok {
 		if authz && operation == "use" {
-			return domain.AccessDecision(s.LicensedSeats[resource.ID][subjectID]), nil //Authorized, so return license status
+			return domain.NewAccessDecision(s.LicensedSeats[resource.ID][subjectID]), nil //Authorized, so return license status
 		}
-		return domain.AccessDecision(authz), nil //No licensing required, passthrough authz status
+		return domain.NewAccessDecision(authz), nil //No licensing required, passthrough authz status
 	}
 
-	return domain.AccessDecision(false), nil //Unknown principal, implicitly not authorized
+	return domain.NewAccessDecision(false), nil //Unknown principal, implicitly not authorized
+}
+
+// LookupAccessibleResources returns the services the subject is assigned a seat for, if the subject is authorized to use services at all. Other resources are unknown to the stub.
+func (s *StubAccessRepository) LookupAccessibleResources(_ context.Context, subjectID domain.SubjectID, operation string, resourceType string) ([]domain.Resource, error) {
+	resources := make([]domain.Resource, 0)
+	if authz := s.Data[subjectID]; !authz || operation != "use" || resourceType != "service" {
+		return resources, nil
+	}
+
+	for serviceID, assignments := range s.LicensedSeats {
+		if assignments[subjectID] {
+			resources = append(resources, domain.Service{ID: serviceID}.AsResource())
+		}
+	}
+
+	return resources, nil
+}
+
+// LookupAccessingSubjects returns the authorized subjects, limited to the ones assigned a seat if the operation is "use". See CheckAccess.
+func (s *StubAccessRepository) LookupAccessingSubjects(_ context.Context, operation string, resource domain.Resource) ([]domain.SubjectID, error) {
+	subjects := make([]domain.SubjectID, 0)
+	for subjectID, authz := range s.Data {
+		if !authz {
+			continue
+		}
+		if operation == "use" && !s.LicensedSeats[resource.ID][subjectID] {
+			continue
+		}
+		subjects = append(subjects, subjectID)
+	}
+
+	return subjects, nil
+}
+
+// CheckAccessBulk checks each event one by one using CheckAccess and returns the decisions and errors in the same order.
+func (s *StubAccessRepository) CheckAccessBulk(ctx context.Context, events []domain.CheckEvent) ([]domain.AccessDecision, []error) {
+	results := make([]domain.AccessDecision, len(events))
+	errs := make([]error, len(events))
+	for i, evt := range events {
+		results[i], errs[i] = s.CheckAccess(ctx, evt.SubjectID, evt.Operation, evt.Resource)
+	}
+
+	return results, errs
 }
 
 // GetLicense retrieves the stored license for the given organization and service, if any.
-func (s *StubAccessRepository) GetLicense(_ string, serviceID string) (*domain.License, error) {
+func (s *StubAccessRepository) GetLicense(_ context.Context, _ string, serviceID string) (*domain.License, error) {
 	lic := s.Licenses[serviceID]
 	inuse := 0
 
@@ -48,7 +93,7 @@ func (s *StubAccessRepository) GetLicense(_ string, serviceID string) (*domain.L
 }
 
 // GetAssigned retrieves the IDs of the subjects assigned seats in the current license
-func (s *StubAccessRepository) GetAssigned(_ string, serviceID string) ([]domain.SubjectID, error) {
+func (s *StubAccessRepository) GetAssigned(_ context.Context, _ string, serviceID string) ([]domain.SubjectID, error) {
 	subjects := make([]domain.SubjectID, 0)
 	if assignments, ok := s.LicensedSeats[serviceID]; ok {
 		for id, assigned := range assignments {
@@ -61,8 +106,91 @@ func (s *StubAccessRepository) GetAssigned(_ string, serviceID string) ([]domain
 	return subjects, nil
 }
 
+// RecountSeats returns the number of assigned seats twice, the stub derives the seats in use from the assignments and cannot drift
+func (s *StubAccessRepository) RecountSeats(ctx context.Context, orgID string, serviceID string) (int, int, error) {
+	lic, err := s.GetLicense(ctx, orgID, serviceID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return lic.InUse, lic.InUse, nil
+}
+
+// CreateLicense stubs a new license for the service, unless one is stubbed already
+func (s *StubAccessRepository) CreateLicense(_ context.Context, orgID string, serviceID string, maxSeats int) error {
+	if _, ok := s.Licenses[serviceID]; ok {
+		return fmt.Errorf("%w: %s", domain.ErrLicenseAlreadyExists, serviceID)
+	}
+
+	if s.Licenses == nil {
+		s.Licenses = map[string]domain.License{}
+	}
+	s.Licenses[serviceID] = *domain.NewLicense(orgID, serviceID, maxSeats, 0)
+	return nil
+}
+
+// DeleteLicense removes the stubbed license and seats of the service, and returns the number of seats released
+func (s *StubAccessRepository) DeleteLicense(_ context.Context, _ string, serviceID string) (int, error) {
+	released := 0
+	for _, assigned := range s.LicensedSeats[serviceID] {
+		if assigned {
+			released++
+		}
+	}
+
+	delete(s.Licenses, serviceID)
+	delete(s.LicensedSeats, serviceID)
+	return released, nil
+}
+
+// SetMaxSeats replaces the max seats of the stubbed license of the service, unless more seats are assigned than the new limit allows
+func (s *StubAccessRepository) SetMaxSeats(ctx context.Context, orgID string, serviceID string, maxSeats int) error {
+	lic, err := s.GetLicense(ctx, orgID, serviceID)
+	if err != nil {
+		return err
+	}
+	if maxSeats < lic.InUse {
+		return &domain.SeatLimitBelowInUseError{LicenseID: domain.LicenseResourceID(orgID, serviceID), MaxSeats: maxSeats, InUse: lic.InUse}
+	}
+
+	if s.Licenses == nil {
+		s.Licenses = map[string]domain.License{}
+	}
+	stored := s.Licenses[serviceID]
+	stored.MaxSeats = maxSeats
+	s.Licenses[serviceID] = stored
+	return nil
+}
+
+// ListServices retrieves the services of the stubbed licenses of the given organization
+func (s *StubAccessRepository) ListServices(_ context.Context, orgID string) ([]domain.Service, error) {
+	services := make([]domain.Service, 0)
+	for serviceID, lic := range s.Licenses {
+		if lic.OrgID == orgID {
+			services = append(services, domain.Service{ID: serviceID})
+		}
+	}
+
+	return services, nil
+}
+
+// ListAssignedServices retrieves the services the subject is assigned a stubbed seat for. Services with a stubbed license of another organization are left out.
+func (s *StubAccessRepository) ListAssignedServices(_ context.Context, orgID string, subjectID domain.SubjectID) ([]domain.Service, error) {
+	services := make([]domain.Service, 0)
+	for serviceID, seats := range s.LicensedSeats {
+		if lic, ok := s.Licenses[serviceID]; ok && lic.OrgID != orgID {
+			continue
+		}
+		if seats[subjectID] {
+			services = append(services, domain.Service{ID: serviceID})
+		}
+	}
+
+	return services, nil
+}
+
 // AssignSeat assigns the given principal a seat for the given service
-func (s *StubAccessRepository) AssignSeat(subjectID domain.SubjectID, _ string, svc domain.Service) error {
+func (s *StubAccessRepository) AssignSeat(_ context.Context, subjectID domain.SubjectID, _ string, svc domain.Service) error {
 	if lics, ok := s.LicensedSeats[svc.ID]; ok {
 		lics[subjectID] = true
 	} else {
@@ -71,8 +199,28 @@ func (s *StubAccessRepository) AssignSeat(subjectID domain.SubjectID, _ string,
 	return nil
 }
 
+// AssignSeats assigns the given principals seats for the given service
+func (s *StubAccessRepository) AssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	for _, subjectID := range subjectIDs {
+		if err := s.AssignSeat(ctx, subjectID, orgID, svc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnAssignSeats removes the seat assignments for the given principals for the given service
+func (s *StubAccessRepository) UnAssignSeats(ctx context.Context, subjectIDs []domain.SubjectID, orgID string, svc domain.Service) error {
+	for _, subjectID := range subjectIDs {
+		if err := s.UnAssignSeat(ctx, subjectID, orgID, svc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // UnAssignSeat removes the seat assignment for the given principal for the given service
-func (s *StubAccessRepository) UnAssignSeat(subjectID domain.SubjectID, _ string, svc domain.Service) error {
+func (s *StubAccessRepository) UnAssignSeat(_ context.Context, subjectID domain.SubjectID, _ string, svc domain.Service) error {
 	if lics, ok := s.LicensedSeats[svc.ID]; ok {
 		lics[subjectID] = false
 	}
@@ -9,11 +9,19 @@ import (
 type StubPrincipalRepository struct {
 	DefaultOrg string
 	Principals map[domain.SubjectID]domain.Principal
+	// RejectAnonymous makes GetByID return domain.ErrNotAuthenticated instead of an anonymous principal if no ID is provided
+	RejectAnonymous bool
+	// RejectUnknown makes GetByID return domain.ErrPrincipalNotFound for unknown IDs instead of creating a principal in the DefaultOrg. Creating them hides typos.
+	RejectUnknown bool
 }
 
 // GetByID retrieves a principal for the given ID. If no ID is provided (ex: empty string), it returns an anonymous principal. If any error occurs, it's returned.
+// Unknown principals are created as members of the DefaultOrg. See RejectAnonymous and RejectUnknown to change either behavior.
 func (s *StubPrincipalRepository) GetByID(id domain.SubjectID) (domain.Principal, error) {
 	if id == "" {
+		if s.RejectAnonymous {
+			return domain.Principal{}, domain.ErrNotAuthenticated
+		}
 		return domain.NewAnonymousPrincipal(), nil
 	}
 
@@ -22,6 +30,10 @@ func (s *StubPrincipalRepository) GetByID(id domain.SubjectID) (domain.Principal
 		return principal, nil
 	}
 
+	if s.RejectUnknown {
+		return domain.Principal{}, fmt.Errorf("%w: %s", domain.ErrPrincipalNotFound, id)
+	}
+
 	return s.createAndAddMissingPrincipal(id)
 }
 
@@ -50,6 +62,12 @@ func (s *StubPrincipalRepository) GetByOrgID(orgID string) ([]domain.SubjectID,
 	return ids, nil
 }
 
+// IsMember returns whether the given subject is a known member of the given organization. Unknown subjects are not members, they are not created.
+func (s *StubPrincipalRepository) IsMember(orgID string, subjectID domain.SubjectID) (bool, error) {
+	principal, ok := s.Principals[subjectID]
+	return ok && principal.OrgID == orgID, nil
+}
+
 func (s *StubPrincipalRepository) createAndAddMissingPrincipal(id domain.SubjectID) (domain.Principal, error) {
 	p := domain.Principal{
 		ID:          id,
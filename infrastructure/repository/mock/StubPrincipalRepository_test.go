@@ -0,0 +1,55 @@
+package mock
+
+import (
+	"authz/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByIDCreatesUnknownPrincipalsByDefault(t *testing.T) {
+	repo := &StubPrincipalRepository{Principals: map[domain.SubjectID]domain.Principal{}, DefaultOrg: "o1"}
+
+	principal, err := repo.GetByID("typo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "o1", principal.OrgID)
+}
+
+func TestGetByIDRejectsUnknownPrincipals(t *testing.T) {
+	repo := &StubPrincipalRepository{Principals: map[domain.SubjectID]domain.Principal{}, DefaultOrg: "o1", RejectUnknown: true}
+
+	_, err := repo.GetByID("typo")
+	assert.ErrorIs(t, err, domain.ErrPrincipalNotFound)
+
+	principal, err := repo.GetByID("")
+	assert.NoError(t, err, "Anonymous principals should be configured separately.")
+	assert.True(t, principal.IsAnonymous())
+}
+
+func TestGetByIDRejectsAnonymousPrincipal(t *testing.T) {
+	repo := &StubPrincipalRepository{Principals: map[domain.SubjectID]domain.Principal{}, DefaultOrg: "o1", RejectAnonymous: true}
+
+	_, err := repo.GetByID("")
+
+	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
+}
+
+func TestIsMember(t *testing.T) {
+	repo := &StubPrincipalRepository{Principals: map[domain.SubjectID]domain.Principal{
+		"u1": domain.NewPrincipal("u1", "O1 User 1", "o1"),
+	}, DefaultOrg: "o1"}
+
+	member, err := repo.IsMember("o1", "u1")
+	assert.NoError(t, err)
+	assert.True(t, member)
+
+	member, err = repo.IsMember("o2", "u1")
+	assert.NoError(t, err)
+	assert.False(t, member)
+
+	member, err = repo.IsMember("o1", "unknown")
+	assert.NoError(t, err)
+	assert.False(t, member)
+	assert.NotContains(t, repo.Principals, domain.SubjectID("unknown"), "Should not have created the unknown subject.")
+}
@@ -0,0 +1,69 @@
+// Package static implements repositories backed by data defined up front, ex: in a configuration file
+package static
+
+import (
+	"authz/domain"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StaticServiceRepository serves service metadata from a fixed list of services, for deployments without a separate service catalog
+type StaticServiceRepository struct {
+	services map[string]domain.Service
+}
+
+type serviceFile struct {
+	Services []struct {
+		ID          string `yaml:"id"`
+		DisplayName string `yaml:"displayName"`
+		Description string `yaml:"description"`
+	} `yaml:"services"`
+}
+
+// NewStaticServiceRepository constructs a new StaticServiceRepository serving the given services
+func NewStaticServiceRepository(services []domain.Service) *StaticServiceRepository {
+	r := &StaticServiceRepository{services: make(map[string]domain.Service, len(services))}
+	for _, svc := range services {
+		r.services[svc.ID] = svc
+	}
+	return r
+}
+
+// LoadStaticServiceRepository constructs a new StaticServiceRepository serving the services defined in the given YAML file, of the form:
+//
+//	services:
+//	  - id: smarts
+//	    displayName: Smarts
+//	    description: Smart management
+func LoadStaticServiceRepository(path string) (*StaticServiceRepository, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file serviceFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid service file %s: %w", path, err)
+	}
+
+	services := make([]domain.Service, len(file.Services))
+	for i, svc := range file.Services {
+		if svc.ID == "" {
+			return nil, fmt.Errorf("invalid service file %s: service %d has no id", path, i)
+		}
+		services[i] = domain.Service{ID: svc.ID, DisplayName: svc.DisplayName, Description: svc.Description}
+	}
+
+	return NewStaticServiceRepository(services), nil
+}
+
+// GetByID retrieves the service with the given ID. Unknown services are returned with only their ID set.
+func (r *StaticServiceRepository) GetByID(id string) (domain.Service, error) {
+	if svc, ok := r.services[id]; ok {
+		return svc, nil
+	}
+
+	return domain.Service{ID: id}, nil
+}
@@ -0,0 +1,47 @@
+package static
+
+import (
+	"authz/domain"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadStaticServiceRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+services:
+  - id: smarts
+    displayName: Smarts
+    description: Smart management
+`), 0600))
+
+	repo, err := LoadStaticServiceRepository(path)
+	assert.NoError(t, err)
+
+	svc, err := repo.GetByID("smarts")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Service{ID: "smarts", DisplayName: "Smarts", Description: "Smart management"}, svc)
+}
+
+func TestLoadStaticServiceRepositoryRejectsServiceWithoutID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "services.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+services:
+  - displayName: Smarts
+`), 0600))
+
+	_, err := LoadStaticServiceRepository(path)
+	assert.Error(t, err)
+}
+
+func TestStaticServiceRepositoryReturnsUnknownServiceWithIDOnly(t *testing.T) {
+	repo := NewStaticServiceRepository([]domain.Service{{ID: "smarts", DisplayName: "Smarts"}})
+
+	svc, err := repo.GetByID("other")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Service{ID: "other"}, svc)
+}